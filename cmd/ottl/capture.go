@@ -0,0 +1,211 @@
+// Copyright 2025 Dose de Telemetria GmbH
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+
+	"go.opentelemetry.io/collector/pdata/plog"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.opentelemetry.io/collector/pdata/pprofile"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+)
+
+// otlpCapture holds the telemetry of a collector capture, which may mix signals
+type otlpCapture struct {
+	traces   ptrace.Traces
+	logs     plog.Logs
+	metrics  pmetric.Metrics
+	profiles pprofile.Profiles
+}
+
+func newOTLPCapture() *otlpCapture {
+	return &otlpCapture{
+		traces:   ptrace.NewTraces(),
+		logs:     plog.NewLogs(),
+		metrics:  pmetric.NewMetrics(),
+		profiles: pprofile.NewProfiles(),
+	}
+}
+
+// detect returns the context and data of the signal of the capture. Captures holding several
+// signals are an error, since a single signal is processed and the others would be lost.
+func (c *otlpCapture) detect() (contextType, interface{}, error) {
+	contexts := c.contexts()
+	switch len(contexts) {
+	case 0:
+		return contextTypeUnknown, nil, fmt.Errorf("unable to detect data type from input")
+	case 1:
+		data, _ := c.data(contexts[0])
+		return contexts[0], data, nil
+	default:
+		signals := make([]string, len(contexts))
+		for i, ctx := range contexts {
+			signals[i] = signalForContext(ctx)
+		}
+		last := len(signals) - 1
+		return contextTypeUnknown, nil, fmt.Errorf("input holds %s and %s, but only one signal can be processed at a time",
+			strings.Join(signals[:last], ", "), signals[last])
+	}
+}
+
+// contexts returns the contexts of the signals the capture holds, in the order
+// detectContextType tries them
+func (c *otlpCapture) contexts() []contextType {
+	var contexts []contextType
+	for _, ctx := range []contextType{contextTypeSpan, contextTypeLog, contextTypeMetric, contextTypeProfile} {
+		if _, ok := c.data(ctx); ok {
+			contexts = append(contexts, ctx)
+		}
+	}
+	return contexts
+}
+
+// data returns the data of the capture for a context, and whether there is any
+func (c *otlpCapture) data(ctx contextType) (interface{}, bool) {
+	switch ctx {
+	case contextTypeSpan:
+		return c.traces, c.traces.ResourceSpans().Len() > 0
+	case contextTypeLog:
+		return c.logs, c.logs.ResourceLogs().Len() > 0
+	case contextTypeMetric, contextTypeDatapoint:
+		return c.metrics, c.metrics.ResourceMetrics().Len() > 0
+	case contextTypeProfile:
+		return c.profiles, c.profiles.ResourceProfiles().Len() > 0
+	default:
+		return nil, false
+	}
+}
+
+// parseCapture parses the formats collectors write captures in: the file exporter's
+// NDJSON, one OTLP JSON request per line, and the debug exporter's detailed text.
+// It reports false when the input is in neither format.
+func parseCapture(data []byte) (*otlpCapture, bool, error) {
+	switch {
+	case isNDJSON(data):
+		capture, err := parseNDJSON(data)
+		return capture, true, err
+	case isDebugText(data):
+		capture, err := parseDebugText(data)
+		return capture, true, err
+	default:
+		return nil, false, nil
+	}
+}
+
+// isNDJSON reports whether data has several lines that each hold a JSON object
+func isNDJSON(data []byte) bool {
+	lines := 0
+	for _, line := range bytes.Split(bytes.TrimSpace(data), []byte("\n")) {
+		line = bytes.TrimSpace(line)
+		if len(line) == 0 {
+			continue
+		}
+		if line[0] != '{' || line[len(line)-1] != '}' {
+			return false
+		}
+		lines++
+	}
+	return lines > 1
+}
+
+// parseNDJSON merges the requests of the lines of file exporter output
+func parseNDJSON(data []byte) (*otlpCapture, error) {
+	capture := newOTLPCapture()
+	profileLines := 0
+	for i, line := range bytes.Split(data, []byte("\n")) {
+		line = bytes.TrimSpace(line)
+		if len(line) == 0 {
+			continue
+		}
+
+		if traces, err := (&ptrace.JSONUnmarshaler{}).UnmarshalTraces(line); err == nil && traces.ResourceSpans().Len() > 0 {
+			traces.ResourceSpans().MoveAndAppendTo(capture.traces.ResourceSpans())
+			continue
+		}
+		if logs, err := (&plog.JSONUnmarshaler{}).UnmarshalLogs(line); err == nil && logs.ResourceLogs().Len() > 0 {
+			logs.ResourceLogs().MoveAndAppendTo(capture.logs.ResourceLogs())
+			continue
+		}
+		if metrics, err := (&pmetric.JSONUnmarshaler{}).UnmarshalMetrics(line); err == nil && metrics.ResourceMetrics().Len() > 0 {
+			metrics.ResourceMetrics().MoveAndAppendTo(capture.metrics.ResourceMetrics())
+			continue
+		}
+		if profiles, err := (&pprofile.JSONUnmarshaler{}).UnmarshalProfiles(line); err == nil && profiles.ResourceProfiles().Len() > 0 {
+			// Profiles reference a dictionary of their own request, so requests cannot be merged
+			if profileLines++; profileLines > 1 {
+				return nil, fmt.Errorf("line %d: profiles of several requests cannot be merged; split the file into one request per file", i+1)
+			}
+			profiles.MoveTo(capture.profiles)
+			continue
+		}
+		return nil, fmt.Errorf("line %d: not an OTLP JSON request", i+1)
+	}
+	return capture, nil
+}
+
+// rotatedTimestamp matches the timestamp the file exporter adds to the names of rotated files
+var rotatedTimestamp = regexp.MustCompile(`^\d{4}-\d{2}-\d{2}T\d{2}-\d{2}-\d{2}\.\d{3}$`)
+
+// rotatedFiles returns the files the file exporter rotated out of path, oldest first.
+// Rotated files are named after the file with the rotation time before the extension,
+// such as traces-2025-01-01T10-00-00.000.json for traces.json.
+func rotatedFiles(path string) ([]string, error) {
+	ext := filepath.Ext(path)
+	base := strings.TrimSuffix(path, ext)
+	matches, err := filepath.Glob(base + "-*" + ext)
+	if err != nil {
+		return nil, err
+	}
+
+	var files []string
+	for _, match := range matches {
+		timestamp := strings.TrimSuffix(strings.TrimPrefix(match, base+"-"), ext)
+		if rotatedTimestamp.MatchString(timestamp) {
+			files = append(files, match)
+		}
+	}
+	sort.Strings(files) // the timestamps sort chronologically
+	return files, nil
+}
+
+// readRotatedInput reads the files rotated out of path followed by path itself, which may
+// be missing when the collector has not written since the last rotation
+func readRotatedInput(path string) ([]byte, error) {
+	files, err := rotatedFiles(path)
+	if err != nil {
+		return nil, fmt.Errorf("cannot list rotated files of %s: %w", path, err)
+	}
+	if _, err := os.Stat(path); err == nil || len(files) == 0 {
+		files = append(files, path)
+	}
+
+	var data []byte
+	for _, file := range files {
+		content, err := readInputFile(file)
+		if err != nil {
+			return nil, err
+		}
+		data = append(data, bytes.TrimSpace(content)...)
+		data = append(data, '\n')
+	}
+	return data, nil
+}
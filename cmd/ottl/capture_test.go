@@ -0,0 +1,123 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/pdata/plog"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+)
+
+// ndjsonLine compacts a testdata file into a single NDJSON line
+func ndjsonLine(t *testing.T, name string) []byte {
+	var buf bytes.Buffer
+	require.NoError(t, json.Compact(&buf, readTestData(t, name)))
+	return append(buf.Bytes(), '\n')
+}
+
+func TestParseNDJSON(t *testing.T) {
+	logsLine := ndjsonLine(t, "logs.json")
+	single, err := (&plog.JSONUnmarshaler{}).UnmarshalLogs(logsLine)
+	require.NoError(t, err)
+
+	ctx, data, err := detectContextType(append(append([]byte{}, logsLine...), logsLine...))
+	require.NoError(t, err)
+	assert.Equal(t, contextTypeLog, ctx)
+	assert.Equal(t, 2*single.LogRecordCount(), data.(plog.Logs).LogRecordCount())
+}
+
+func TestParseNDJSONMixedSignals(t *testing.T) {
+	var input []byte
+	input = append(input, ndjsonLine(t, "metrics.json")...)
+	input = append(input, ndjsonLine(t, "traces.json")...)
+	input = append(input, ndjsonLine(t, "logs.json")...)
+
+	// Transforming one signal would silently drop the others
+	_, _, err := detectContextType(input)
+	assert.EqualError(t, err, "input holds traces, logs and metrics, but only one signal can be processed at a time")
+	_, _, err = parseInput(input, "", "")
+	assert.ErrorContains(t, err, "; pick one with --signal")
+
+	ctx, data, err := parseInput(input, "", "traces")
+	require.NoError(t, err)
+	assert.Equal(t, contextTypeSpan, ctx)
+	assert.Positive(t, data.(ptrace.Traces).SpanCount())
+
+	_, data, err = parseInput(input, "datapoint", "")
+	require.NoError(t, err)
+	assert.Positive(t, data.(pmetric.Metrics).DataPointCount())
+
+	_, data, err = parseInput(input, "log", "")
+	require.NoError(t, err)
+	assert.Positive(t, data.(plog.Logs).LogRecordCount())
+
+	_, _, err = parseInput(input, "profile", "")
+	assert.EqualError(t, err, "input has no profiles")
+}
+
+func TestParseNDJSONErrors(t *testing.T) {
+	profilesLine := ndjsonLine(t, "profiles.json")
+	_, err := parseNDJSON(append(append([]byte{}, profilesLine...), profilesLine...))
+	assert.ErrorContains(t, err, "line 2: profiles of several requests cannot be merged")
+
+	input := append(ndjsonLine(t, "traces.json"), []byte(`{"hello":"world"}`)...)
+	_, err = parseNDJSON(input)
+	assert.ErrorContains(t, err, "line 2: not an OTLP JSON request")
+}
+
+func TestIsNDJSON(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    string
+		expected bool
+	}{
+		{name: "several objects", input: "{\"a\":1}\n\n{\"b\":2}\n", expected: true},
+		{name: "single object", input: "{\"a\":1}\n", expected: false},
+		{name: "pretty printed", input: "{\n  \"a\": 1\n}\n", expected: false},
+		{name: "text", input: "ResourceSpans #0\nResource SchemaURL: \n", expected: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, isNDJSON([]byte(tt.input)))
+		})
+	}
+}
+
+func TestReadRotatedInput(t *testing.T) {
+	dir := t.TempDir()
+	write := func(name, content string) {
+		require.NoError(t, os.WriteFile(filepath.Join(dir, name), []byte(content), 0o600))
+	}
+	write("traces-2025-01-02T08-00-00.000.json", "{\"second\":true}")
+	write("traces-2025-01-01T10-00-00.000.json", "{\"first\":true}\n")
+	write("traces-backup.json", "{\"ignored\":true}")
+	write("traces.json", "{\"active\":true}\n")
+
+	path := filepath.Join(dir, "traces.json")
+	files, err := rotatedFiles(path)
+	require.NoError(t, err)
+	assert.Equal(t, []string{
+		filepath.Join(dir, "traces-2025-01-01T10-00-00.000.json"),
+		filepath.Join(dir, "traces-2025-01-02T08-00-00.000.json"),
+	}, files)
+
+	data, err := readRotatedInput(path)
+	require.NoError(t, err)
+	assert.Equal(t, "{\"first\":true}\n{\"second\":true}\n{\"active\":true}\n", string(data))
+
+	// The active file may be missing right after a rotation
+	require.NoError(t, os.Remove(path))
+	data, err = readRotatedInput(path)
+	require.NoError(t, err)
+	assert.Equal(t, "{\"first\":true}\n{\"second\":true}\n", string(data))
+
+	_, err = readRotatedInput(filepath.Join(dir, "logs.json"))
+	assert.Error(t, err)
+}
@@ -0,0 +1,553 @@
+// Copyright 2025 Dose de Telemetria GmbH
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"gopkg.in/yaml.v3"
+
+	"github.com/telemetrydrops/ottl-cli/internal/attraction"
+)
+
+var convertCmd = &cobra.Command{
+	Use:   "convert",
+	Short: "Translate attributes and resource processor configs into OTTL",
+	Long: `Reads attributes and resource processor configs and prints an equivalent
+transform processor config. The config is either a collector config, in which
+case every attributes and resource processor is converted unless --processor
+selects one, or the settings of a single processor identified by --processor.
+
+With --input-file, both the original processor and the generated transform
+processor run on a copy of the input and the command fails if their outputs
+differ.`,
+	Example: `  # Convert every attributes and resource processor of a collector config
+  ottl convert --config collector.yaml
+
+  # Convert one processor and check the result against sample data
+  ottl convert --config collector.yaml --processor attributes/pii --input-file spans.json
+
+  # Convert a standalone processor config
+  ottl convert --config pii.yaml --processor attributes/pii`,
+	Args: cobra.NoArgs,
+	RunE: runConvert,
+}
+
+var convertConfigFile string
+var convertProcessorID string
+var convertInputFile string
+
+func init() {
+	convertCmd.Flags().StringVarP(&convertConfigFile, "config", "c", "", "Path to a collector or processor config YAML (required)")
+	convertCmd.Flags().StringVarP(&convertProcessorID, "processor", "p", "", "Processor ID to convert, e.g. attributes/pii")
+	convertCmd.Flags().StringVarP(&convertInputFile, "input-file", "i", "", "Path to OTLP JSON input file used to verify the conversion")
+	_ = convertCmd.MarkFlagRequired("config")
+	rootCmd.AddCommand(convertCmd)
+}
+
+// convertedProcessor is an attributes or resource processor and its transform processor equivalent
+type convertedProcessor struct {
+	id       string
+	name     string
+	original pipelineProcessor
+	config   transformProcessorConfig
+	warnings []string
+}
+
+// runConvert executes the convert command
+func runConvert(cmd *cobra.Command, args []string) error {
+	configData, err := readInputFile(convertConfigFile)
+	if err != nil {
+		return fmt.Errorf("failed to read config: %w", err)
+	}
+
+	sections, err := loadConvertSections(configData, convertProcessorID)
+	if err != nil {
+		return err
+	}
+
+	var converted []convertedProcessor
+	for _, id := range sortedKeys(sections) {
+		c, err := convertProcessor(id, sections[id])
+		if err != nil {
+			return fmt.Errorf("cannot convert %s: %w", id, err)
+		}
+		for _, warning := range c.warnings {
+			_, _ = fmt.Fprintf(os.Stderr, "warning: %s: %s\n", id, warning)
+		}
+		converted = append(converted, c)
+	}
+
+	if convertInputFile != "" {
+		data, err := readInputFile(convertInputFile)
+		if err != nil {
+			return fmt.Errorf("failed to read input file: %w", err)
+		}
+		for _, c := range converted {
+			if err := verifyConversion(c, data); err != nil {
+				return fmt.Errorf("verification of %s failed: %w", c.id, err)
+			}
+			_, _ = fmt.Fprintf(os.Stderr, "verified: %s and %s produce the same output\n", c.id, c.name)
+		}
+	}
+
+	return writeConvertedConfig(os.Stdout, converted)
+}
+
+// loadConvertSections returns the attributes and resource processor sections of a
+// collector config, or the whole document as the settings of the given processor ID
+func loadConvertSections(data []byte, processorID string) (map[string]*yaml.Node, error) {
+	var doc yaml.Node
+	if err := yaml.Unmarshal(data, &doc); err != nil {
+		return nil, fmt.Errorf("cannot parse YAML: %w", err)
+	}
+	var cfg struct {
+		Processors map[string]yaml.Node `yaml:"processors"`
+	}
+	if err := doc.Decode(&cfg); err != nil {
+		return nil, fmt.Errorf("cannot parse config: %w", err)
+	}
+
+	if processorID != "" {
+		if t := componentType(processorID); t != "attributes" && t != "resource" {
+			return nil, fmt.Errorf("cannot convert processor type %q, only attributes and resource are supported", t)
+		}
+	}
+
+	if cfg.Processors == nil {
+		if processorID == "" {
+			return nil, errors.New("--processor is required to convert a standalone processor config")
+		}
+		if len(doc.Content) == 0 {
+			return nil, errors.New("config is empty")
+		}
+		return map[string]*yaml.Node{processorID: doc.Content[0]}, nil
+	}
+
+	if processorID != "" {
+		section, ok := cfg.Processors[processorID]
+		if !ok {
+			return nil, fmt.Errorf("processor %s not found in config", processorID)
+		}
+		return map[string]*yaml.Node{processorID: &section}, nil
+	}
+
+	sections := map[string]*yaml.Node{}
+	for id, section := range cfg.Processors {
+		if t := componentType(id); t == "attributes" || t == "resource" {
+			sections[id] = &section
+		}
+	}
+	if len(sections) == 0 {
+		return nil, errors.New("no attributes or resource processors found in config")
+	}
+	return sections, nil
+}
+
+// convertProcessor translates one attributes or resource processor config
+func convertProcessor(id string, section *yaml.Node) (convertedProcessor, error) {
+	c := convertedProcessor{id: id, name: "transform/" + strings.ReplaceAll(id, "/", "_")}
+
+	if componentType(id) == "resource" {
+		var cfg resourceProcessorConfig
+		if err := decodeSection(section, &cfg); err != nil {
+			return c, err
+		}
+		original, err := newResourceProcessor(cfg)
+		if err != nil {
+			return c, err
+		}
+		c.original = original
+
+		statements, warnings, err := convertActions(cfg.Attributes)
+		if err != nil {
+			return c, err
+		}
+		c.warnings = warnings
+		group := transformStatementsList{{Context: "resource", Statements: statements}}
+		c.config = transformProcessorConfig{TraceStatements: group, LogStatements: group, MetricStatements: group}
+		return c, nil
+	}
+
+	var cfg attributesProcessorConfig
+	if err := decodeSection(section, &cfg); err != nil {
+		return c, err
+	}
+	original, err := newAttributesProcessor(cfg)
+	if err != nil {
+		return c, err
+	}
+	c.original = original
+
+	statements, warnings, err := convertActions(cfg.Actions)
+	if err != nil {
+		return c, err
+	}
+	c.warnings = warnings
+
+	for _, signal := range []string{"traces", "logs", "metrics"} {
+		group, ok, err := convertMatchProperties(signal, cfg.Include, cfg.Exclude)
+		if err != nil {
+			return c, err
+		}
+		if !ok {
+			continue
+		}
+		group.Statements = statements
+		switch signal {
+		case "traces":
+			c.config.TraceStatements = transformStatementsList{group}
+		case "logs":
+			c.config.LogStatements = transformStatementsList{group}
+		case "metrics":
+			c.config.MetricStatements = transformStatementsList{group}
+		}
+	}
+	return c, nil
+}
+
+// convertActions translates attribute actions into statements on the "attributes" path
+func convertActions(actions []attraction.ActionKeyValue) ([]string, []string, error) {
+	var statements, warnings []string
+
+	for i, a := range actions {
+		if a.FromContext != "" {
+			return nil, nil, fmt.Errorf("action %d: from_context has no OTTL equivalent", i)
+		}
+
+		target := attributePath("attributes", a.Key)
+		var value string
+		var conditions []string
+		switch {
+		case a.FromAttribute != "":
+			value = attributePath("attributes", a.FromAttribute)
+			conditions = append(conditions, value+" != nil")
+		case a.Value != nil:
+			literal, err := ottlLiteral(a.Value)
+			if err != nil {
+				return nil, nil, fmt.Errorf("action %d: %w", i, err)
+			}
+			value = literal
+		}
+
+		switch attraction.Action(strings.ToLower(string(a.Action))) {
+		case attraction.INSERT:
+			statements = append(statements, withWhere(fmt.Sprintf("set(%s, %s)", target, value), append([]string{target + " == nil"}, conditions...)))
+		case attraction.UPDATE:
+			statements = append(statements, withWhere(fmt.Sprintf("set(%s, %s)", target, value), append([]string{target + " != nil"}, conditions...)))
+		case attraction.UPSERT:
+			statements = append(statements, withWhere(fmt.Sprintf("set(%s, %s)", target, value), conditions))
+		case attraction.DELETE:
+			if a.Key != "" {
+				statements = append(statements, fmt.Sprintf("delete_key(attributes, %s)", strconv.Quote(a.Key)))
+			}
+			if a.RegexPattern != "" {
+				statements = append(statements, fmt.Sprintf("delete_matching_keys(attributes, %s)", strconv.Quote(a.RegexPattern)))
+			}
+		case attraction.HASH:
+			if a.RegexPattern != "" {
+				return nil, nil, fmt.Errorf("action %d: hashing keys that match a pattern has no OTTL equivalent", i)
+			}
+			statements = append(statements, fmt.Sprintf("set(%s, SHA256(%s)) where IsString(%s)", target, target, target))
+			warnings = append(warnings, fmt.Sprintf("action %d: only string values of %q are hashed; the attributes processor also hashes numbers and booleans", i, a.Key))
+		case attraction.EXTRACT:
+			statements = append(statements, fmt.Sprintf(`merge_maps(attributes, ExtractPatterns(%s, %s), "upsert") where IsString(%s)`, target, strconv.Quote(a.RegexPattern), target))
+		case attraction.CONVERT:
+			converter, ok := map[string]string{"int": "Int", "double": "Double", "string": "String"}[a.ConvertedType]
+			if !ok {
+				return nil, nil, fmt.Errorf("action %d: unsupported converted_type %q", i, a.ConvertedType)
+			}
+			statements = append(statements, fmt.Sprintf("set(%s, %s(%s)) where %s != nil", target, converter, target, target))
+		default:
+			return nil, nil, fmt.Errorf("action %d: unsupported action %q", i, a.Action)
+		}
+	}
+
+	return statements, warnings, nil
+}
+
+// convertMatchProperties builds the statement group of a signal from include/exclude
+// properties. It returns false when include can never match the signal's records.
+func convertMatchProperties(signal string, include, exclude *matchProperties) (transformStatements, bool, error) {
+	group := transformStatements{Context: map[string]string{"traces": "span", "logs": "log", "metrics": "datapoint"}[signal]}
+
+	var conditions []string
+	if include != nil {
+		condition, ok, err := matchCondition(signal, include)
+		if err != nil {
+			return group, false, fmt.Errorf("include: %w", err)
+		}
+		if !ok {
+			return group, false, nil
+		}
+		conditions = append(conditions, condition)
+	}
+	if exclude != nil {
+		condition, ok, err := matchCondition(signal, exclude)
+		if err != nil {
+			return group, false, fmt.Errorf("exclude: %w", err)
+		}
+		if ok {
+			conditions = append(conditions, fmt.Sprintf("not (%s)", condition))
+		}
+	}
+
+	if len(conditions) > 0 {
+		group.Conditions = []string{strings.Join(conditions, " and ")}
+	}
+	return group, true, nil
+}
+
+// matchCondition translates match properties into a single OTTL condition. It
+// returns false when a property does not apply to the signal, so nothing matches.
+func matchCondition(signal string, props *matchProperties) (string, bool, error) {
+	regexpMatch := props.MatchType == matchTypeRegexp
+
+	compare := func(path, value string) string {
+		if regexpMatch {
+			return fmt.Sprintf("IsMatch(%s, %s)", path, strconv.Quote(value))
+		}
+		return fmt.Sprintf("%s == %s", path, strconv.Quote(value))
+	}
+
+	fields := []struct {
+		values  []string
+		signal  string
+		path    string
+		compare func(string) (string, error)
+	}{
+		{props.Services, "traces", `resource.attributes["service.name"]`, nil},
+		{props.SpanNames, "traces", "name", nil},
+		{props.SpanKinds, "traces", "kind", func(kind string) (string, error) {
+			if regexpMatch {
+				return compare("kind.deprecated_string", kind), nil
+			}
+			if !strings.HasPrefix(kind, "SPAN_KIND_") {
+				return "", fmt.Errorf("unknown span kind %q", kind)
+			}
+			return "kind == " + kind, nil
+		}},
+		{props.LogBodies, "logs", "body.string", nil},
+		{props.LogSeverityTexts, "logs", "severity_text", nil},
+		{props.MetricNames, "metrics", "metric.name", nil},
+	}
+
+	var all []string
+	for _, field := range fields {
+		if len(field.values) == 0 {
+			continue
+		}
+		if field.signal != signal {
+			return "", false, nil
+		}
+
+		anyOf := make([]string, 0, len(field.values))
+		for _, value := range field.values {
+			if field.compare == nil {
+				anyOf = append(anyOf, compare(field.path, value))
+				continue
+			}
+			condition, err := field.compare(value)
+			if err != nil {
+				return "", false, err
+			}
+			anyOf = append(anyOf, condition)
+		}
+		all = append(all, joinConditions(anyOf, " or "))
+	}
+
+	for _, attrs := range []struct {
+		matches []attributeMatch
+		path    string
+	}{
+		{props.Attributes, "attributes"},
+		{props.Resources, "resource.attributes"},
+	} {
+		for _, attr := range attrs.matches {
+			path := attributePath(attrs.path, attr.Key)
+			switch {
+			case attr.Value == nil:
+				all = append(all, path+" != nil")
+			case regexpMatch:
+				pattern, ok := attr.Value.(string)
+				if !ok {
+					return "", false, fmt.Errorf("attribute %q: regexp match requires a string value", attr.Key)
+				}
+				all = append(all, fmt.Sprintf("IsString(%s) and IsMatch(%s, %s)", path, path, strconv.Quote(pattern)))
+			default:
+				literal, err := ottlLiteral(attr.Value)
+				if err != nil {
+					return "", false, fmt.Errorf("attribute %q: %w", attr.Key, err)
+				}
+				all = append(all, fmt.Sprintf("%s == %s", path, literal))
+			}
+		}
+	}
+
+	if len(props.Libraries) > 0 {
+		anyOf := make([]string, 0, len(props.Libraries))
+		for _, library := range props.Libraries {
+			condition := compare("instrumentation_scope.name", library.Name)
+			if library.Version != nil {
+				condition = fmt.Sprintf("%s and %s", condition, compare("instrumentation_scope.version", *library.Version))
+				if len(props.Libraries) > 1 {
+					condition = "(" + condition + ")"
+				}
+			}
+			anyOf = append(anyOf, condition)
+		}
+		all = append(all, joinConditions(anyOf, " or "))
+	}
+
+	return strings.Join(all, " and "), true, nil
+}
+
+// joinConditions joins conditions with an operator, in parentheses when there are several
+func joinConditions(conditions []string, operator string) string {
+	if len(conditions) == 1 {
+		return conditions[0]
+	}
+	return "(" + strings.Join(conditions, operator) + ")"
+}
+
+// withWhere appends a where clause built from conditions to a statement
+func withWhere(statement string, conditions []string) string {
+	if len(conditions) == 0 {
+		return statement
+	}
+	return statement + " where " + strings.Join(conditions, " and ")
+}
+
+// attributePath returns the OTTL path of a key in an attributes map
+func attributePath(path, key string) string {
+	return fmt.Sprintf("%s[%s]", path, strconv.Quote(key))
+}
+
+// ottlLiteral renders a YAML config value as an OTTL literal
+func ottlLiteral(value any) (string, error) {
+	switch v := value.(type) {
+	case string:
+		return strconv.Quote(v), nil
+	case bool:
+		return strconv.FormatBool(v), nil
+	case int:
+		return strconv.Itoa(v), nil
+	case int64:
+		return strconv.FormatInt(v, 10), nil
+	case float64:
+		literal := strconv.FormatFloat(v, 'f', -1, 64)
+		if !strings.Contains(literal, ".") {
+			literal += ".0"
+		}
+		return literal, nil
+	case []any:
+		items := make([]string, 0, len(v))
+		for _, item := range v {
+			literal, err := ottlLiteral(item)
+			if err != nil {
+				return "", err
+			}
+			items = append(items, literal)
+		}
+		return "[" + strings.Join(items, ", ") + "]", nil
+	case map[string]any:
+		items := make([]string, 0, len(v))
+		for _, key := range sortedKeys(v) {
+			literal, err := ottlLiteral(v[key])
+			if err != nil {
+				return "", err
+			}
+			items = append(items, fmt.Sprintf("%s: %s", strconv.Quote(key), literal))
+		}
+		return "{" + strings.Join(items, ", ") + "}", nil
+	default:
+		return "", fmt.Errorf("unsupported value %v of type %T", value, value)
+	}
+}
+
+// sortedKeys returns the keys of a map in sorted order
+func sortedKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// verifyConversion runs the original and the converted processor on separate
+// copies of the input and compares their outputs
+func verifyConversion(c convertedProcessor, data []byte) error {
+	converted, err := newTransformProcessor(c.config)
+	if err != nil {
+		return fmt.Errorf("generated statements are invalid: %w", err)
+	}
+
+	outputs := make([][]byte, 0, 2)
+	for _, p := range []pipelineProcessor{c.original, converted} {
+		_, parsedData, err := detectContextType(data)
+		if err != nil {
+			return fmt.Errorf("failed to detect context type: %w", err)
+		}
+		if err := runPipelineStep(context.Background(), pipelineStep{id: c.id, processor: p}, parsedData); err != nil {
+			return err
+		}
+
+		output, err := marshalOTLPJSON(parsedData)
+		if err != nil {
+			return err
+		}
+		outputs = append(outputs, output)
+	}
+
+	if !bytes.Equal(outputs[0], outputs[1]) {
+		return fmt.Errorf("outputs differ\n  %s: %s\n  %s: %s", c.id, diffSnippet(outputs[0], outputs[1]), c.name, diffSnippet(outputs[1], outputs[0]))
+	}
+	return nil
+}
+
+// diffSnippet returns the part of a around the first byte that differs from b
+func diffSnippet(a, b []byte) string {
+	i := 0
+	for i < len(a) && i < len(b) && a[i] == b[i] {
+		i++
+	}
+	start, end := max(i-40, 0), min(i+40, len(a))
+	return "..." + string(a[start:end]) + "..."
+}
+
+// writeConvertedConfig writes the converted processors as a collector processors section
+func writeConvertedConfig(w io.Writer, converted []convertedProcessor) error {
+	processors := map[string]transformProcessorConfig{}
+	for _, c := range converted {
+		processors[c.name] = c.config
+	}
+
+	encoder := yaml.NewEncoder(w)
+	encoder.SetIndent(2)
+	if err := encoder.Encode(map[string]interface{}{"processors": processors}); err != nil {
+		return fmt.Errorf("failed to write config: %w", err)
+	}
+	return encoder.Close()
+}
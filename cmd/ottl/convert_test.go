@@ -0,0 +1,203 @@
+package main
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gopkg.in/yaml.v3"
+)
+
+const convertTestConfig = `
+processors:
+  batch:
+  resource:
+    attributes:
+      - key: team
+        value: core
+        action: insert
+  attributes/spans:
+    include:
+      match_type: regexp
+      span_names: ["^test-"]
+    exclude:
+      match_type: strict
+      attributes:
+        - key: http.method
+          value: POST
+    actions:
+      - key: env
+        value: prod
+        action: insert
+      - key: method
+        from_attribute: http.method
+        action: upsert
+      - key: http.status_code
+        action: convert
+        converted_type: double
+      - key: ratio
+        value: 2.0
+        action: upsert
+  attributes/logs:
+    include:
+      match_type: strict
+      log_severity_texts: [ERROR]
+    actions:
+      - key: component
+        action: hash
+      - pattern: ^log\.
+        action: delete
+`
+
+func TestLoadConvertSections(t *testing.T) {
+	sections, err := loadConvertSections([]byte(convertTestConfig), "")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"attributes/logs", "attributes/spans", "resource"}, sortedKeys(sections))
+
+	sections, err = loadConvertSections([]byte(convertTestConfig), "attributes/logs")
+	require.NoError(t, err)
+	assert.Len(t, sections, 1)
+
+	sections, err = loadConvertSections([]byte("actions:\n  - key: a\n    action: delete\n"), "attributes")
+	require.NoError(t, err)
+	assert.Contains(t, sections, "attributes")
+
+	_, err = loadConvertSections([]byte("actions: []"), "")
+	assert.Error(t, err, "standalone configs need a processor ID")
+
+	_, err = loadConvertSections([]byte(convertTestConfig), "batch")
+	assert.Error(t, err)
+
+	_, err = loadConvertSections([]byte(convertTestConfig), "attributes/missing")
+	assert.Error(t, err)
+}
+
+func TestConvertProcessor(t *testing.T) {
+	sections, err := loadConvertSections([]byte(convertTestConfig), "")
+	require.NoError(t, err)
+
+	spans, err := convertProcessor("attributes/spans", sections["attributes/spans"])
+	require.NoError(t, err)
+	assert.Equal(t, "transform/attributes_spans", spans.name)
+	assert.Empty(t, spans.config.LogStatements, "span_names never match logs")
+	require.Len(t, spans.config.TraceStatements, 1)
+	assert.Equal(t, transformStatements{
+		Context:    "span",
+		Conditions: []string{`IsMatch(name, "^test-") and not (attributes["http.method"] == "POST")`},
+		Statements: []string{
+			`set(attributes["env"], "prod") where attributes["env"] == nil`,
+			`set(attributes["method"], attributes["http.method"]) where attributes["http.method"] != nil`,
+			`set(attributes["http.status_code"], Double(attributes["http.status_code"])) where attributes["http.status_code"] != nil`,
+			`set(attributes["ratio"], 2.0)`,
+		},
+	}, spans.config.TraceStatements[0])
+
+	logs, err := convertProcessor("attributes/logs", sections["attributes/logs"])
+	require.NoError(t, err)
+	assert.Len(t, logs.warnings, 1)
+	assert.Equal(t, []string{
+		`set(attributes["component"], SHA256(attributes["component"])) where IsString(attributes["component"])`,
+		`delete_matching_keys(attributes, "^log\\.")`,
+	}, logs.config.LogStatements[0].Statements)
+
+	resource, err := convertProcessor("resource", sections["resource"])
+	require.NoError(t, err)
+	assert.Equal(t, "resource", resource.config.MetricStatements[0].Context)
+}
+
+func TestConvertUnsupportedActions(t *testing.T) {
+	tests := []struct {
+		name   string
+		config string
+	}{
+		{
+			name:   "from_context",
+			config: "actions:\n  - key: tenant\n    from_context: X-Tenant\n    action: insert",
+		},
+		{
+			name:   "hash with pattern",
+			config: "actions:\n  - pattern: ^user\\.\n    action: hash",
+		},
+		{
+			name:   "invalid processor config",
+			config: "actions: []",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			sections, err := loadConvertSections([]byte(test.config), "attributes")
+			require.NoError(t, err)
+			_, err = convertProcessor("attributes", sections["attributes"])
+			assert.Error(t, err)
+		})
+	}
+}
+
+func TestVerifyConversion(t *testing.T) {
+	sections, err := loadConvertSections([]byte(convertTestConfig), "")
+	require.NoError(t, err)
+
+	inputs := map[string][]byte{
+		"traces":  readTestData(t, "traces.json"),
+		"logs":    readTestData(t, "logs.json"),
+		"metrics": readTestData(t, "metrics.json"),
+	}
+	for _, id := range sortedKeys(sections) {
+		c, err := convertProcessor(id, sections[id])
+		require.NoError(t, err)
+		for name, data := range inputs {
+			assert.NoError(t, verifyConversion(c, data), "%s on %s", id, name)
+		}
+	}
+
+	// The attributes processor hashes numbers too, which the OTTL version does not
+	sections, err = loadConvertSections([]byte("actions:\n  - key: http.status_code\n    action: hash"), "attributes")
+	require.NoError(t, err)
+	c, err := convertProcessor("attributes", sections["attributes"])
+	require.NoError(t, err)
+	err = verifyConversion(c, inputs["traces"])
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "outputs differ")
+}
+
+func TestOttlLiteral(t *testing.T) {
+	tests := []struct {
+		value    any
+		expected string
+	}{
+		{value: `say "hi"`, expected: `"say \"hi\""`},
+		{value: 42, expected: "42"},
+		{value: 1.5, expected: "1.5"},
+		{value: 3.0, expected: "3.0"},
+		{value: true, expected: "true"},
+		{value: []any{"a", 1}, expected: `["a", 1]`},
+		{value: map[string]any{"b": 2, "a": "x"}, expected: `{"a": "x", "b": 2}`},
+	}
+
+	for _, test := range tests {
+		literal, err := ottlLiteral(test.value)
+		require.NoError(t, err)
+		assert.Equal(t, test.expected, literal)
+	}
+
+	_, err := ottlLiteral(nil)
+	assert.Error(t, err)
+}
+
+func TestWriteConvertedConfig(t *testing.T) {
+	var buf bytes.Buffer
+	require.NoError(t, writeConvertedConfig(&buf, []convertedProcessor{{
+		name: "transform/attributes",
+		config: transformProcessorConfig{
+			LogStatements: transformStatementsList{{Context: "log", Statements: []string{`delete_key(attributes, "a")`}}},
+		},
+	}}))
+
+	var cfg struct {
+		Processors map[string]transformProcessorConfig `yaml:"processors"`
+	}
+	require.NoError(t, yaml.Unmarshal(buf.Bytes(), &cfg))
+	assert.Equal(t, "log", cfg.Processors["transform/attributes"].LogStatements[0].Context)
+}
@@ -0,0 +1,786 @@
+// Copyright 2025 Dose de Telemetria GmbH
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"math"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/plog"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+)
+
+var (
+	// debugResourceHeader starts the text of one batch
+	debugResourceHeader = regexp.MustCompile(`^Resource(Spans|Log|Metrics|Profiles) #\d+$`)
+	// debugLogPrefix is the timestamp, level and optional caller the collector logs the text with
+	debugLogPrefix = regexp.MustCompile(`^\d{4}-\d{2}-\d{2}T\S+\t[A-Za-z]+\t(?:\S+\.go:\d+\t)?`)
+	// debugLogFields are the fields the collector logger appends to the last line of the text
+	debugLogFields = regexp.MustCompile(`\t\{".*\}$`)
+	// debugValueStart matches a typed value, such as Str(...), that may span several lines
+	debugValueStart = regexp.MustCompile(`: (?:Empty|Str|Int|Double|Bool|Map|Slice|Bytes)\(`)
+
+	debugRecordHeader          = regexp.MustCompile(`^(\w+) #(\d+)$`)
+	debugSchemaURL             = regexp.MustCompile(`^(Resource|Scope\w+) SchemaURL:(.*)$`)
+	debugExplicitBound         = regexp.MustCompile(`^ExplicitBounds #\d+: (\S+)$`)
+	debugBucketCount           = regexp.MustCompile(`^Buckets #\d+, Count: (\d+)$`)
+	debugExponentialBucketLine = regexp.MustCompile(`^Bucket ([\[(])(\S+), (\S+)[)\]], Count: (\d+)$`)
+	debugQuantileValue         = regexp.MustCompile(`^QuantileValue #\d+: Quantile (\S+), Value (\S+)$`)
+	debugSeverityNumber        = regexp.MustCompile(`\((\d+)\)$`)
+)
+
+// debugTimestampLayout is how the debug exporter prints timestamps
+const debugTimestampLayout = "2006-01-02 15:04:05.999999999 -0700 MST"
+
+// isDebugText reports whether data holds debug exporter output with detailed verbosity
+func isDebugText(data []byte) bool {
+	for _, line := range bytes.Split(data, []byte("\n")) {
+		line = bytes.TrimRight(line, "\r")
+		line = line[len(debugLogPrefix.Find(line)):]
+		if debugResourceHeader.Match(line) {
+			return true
+		}
+	}
+	return false
+}
+
+// debugTextLines returns the lines of the debug exporter text in data, without the
+// collector log lines around it, and with values spanning several lines joined
+func debugTextLines(data []byte) []string {
+	var lines []string
+	inText, openValue := false, false
+	for _, line := range strings.Split(string(data), "\n") {
+		line = strings.TrimRight(line, "\r")
+		if prefix := debugLogPrefix.FindString(line); prefix != "" {
+			line = line[len(prefix):]
+			inText, openValue = debugResourceHeader.MatchString(line), false
+		} else if !inText && !openValue {
+			inText = debugResourceHeader.MatchString(line)
+		}
+		if !inText {
+			continue
+		}
+		line = debugLogFields.ReplaceAllString(line, "")
+
+		if openValue {
+			lines[len(lines)-1] += "\n" + line
+			openValue = !strings.HasSuffix(line, ")")
+			continue
+		}
+		lines = append(lines, line)
+		openValue = debugValueStart.MatchString(line) && !strings.HasSuffix(line, ")")
+	}
+	return lines
+}
+
+// debugTextParser rebuilds pdata from debug exporter text, one line at a time
+type debugTextParser struct {
+	capture *otlpCapture
+	signal  string // the Resource header of the current batch, such as "ResourceSpans"
+
+	resource     pcommon.Resource
+	scope        pcommon.InstrumentationScope
+	setSchemaURL map[string]func(string)
+
+	resourceSpans   ptrace.ResourceSpans
+	scopeSpans      ptrace.ScopeSpans
+	span            ptrace.Span
+	event           ptrace.SpanEvent
+	link            ptrace.SpanLink
+	resourceLogs    plog.ResourceLogs
+	scopeLogs       plog.ScopeLogs
+	logRecord       plog.LogRecord
+	resourceMetrics pmetric.ResourceMetrics
+	scopeMetrics    pmetric.ScopeMetrics
+	metric          pmetric.Metric
+	dataPoint       any
+	exemplar        pmetric.Exemplar
+	buckets         *debugExponentialBuckets
+
+	record     string       // the record fields are set on, such as "Span" or "SpanEvent"
+	attributes *pcommon.Map // the map attribute lines are added to, if any
+}
+
+// parseDebugText parses the text the debug exporter logs with detailed verbosity.
+// The text does not hold everything: the scale and offsets of exponential histograms
+// are recovered from the bucket bounds, and profiles are not supported.
+func parseDebugText(data []byte) (*otlpCapture, error) {
+	p := &debugTextParser{capture: newOTLPCapture(), setSchemaURL: map[string]func(string){}}
+	for i, line := range debugTextLines(data) {
+		if err := p.parseLine(line); err != nil {
+			return nil, fmt.Errorf("debug exporter output, line %d: %q: %w", i+1, firstLine(line), err)
+		}
+	}
+	p.finishDataPoint()
+	return p.capture, nil
+}
+
+// firstLine returns the first line of a possibly multi-line text
+func firstLine(s string) string {
+	line, _, _ := strings.Cut(s, "\n")
+	return line
+}
+
+// parseLine parses one line of debug exporter text
+func (p *debugTextParser) parseLine(line string) error {
+	trimmed := strings.TrimSpace(line)
+	if trimmed == "" {
+		return nil
+	}
+	if field, ok := strings.CutPrefix(trimmed, "-> "); ok {
+		return p.parseArrowLine(field)
+	}
+	p.attributes = nil
+
+	if m := debugRecordHeader.FindStringSubmatch(trimmed); m != nil {
+		return p.startRecord(m[1])
+	}
+	if m := debugSchemaURL.FindStringSubmatch(trimmed); m != nil {
+		if set, ok := p.setSchemaURL[m[1]]; ok {
+			set(strings.TrimSpace(m[2]))
+		}
+		return nil
+	}
+
+	switch {
+	case trimmed == "Resource attributes:":
+		p.setAttributes(p.resource.Attributes())
+	case trimmed == "InstrumentationScope attributes:":
+		p.setAttributes(p.scope.Attributes())
+	case trimmed == "InstrumentationScope" || strings.HasPrefix(line, "InstrumentationScope "):
+		nameVersion := strings.TrimPrefix(line, "InstrumentationScope ")
+		if i := strings.LastIndex(nameVersion, " "); i >= 0 {
+			p.scope.SetName(nameVersion[:i])
+			p.scope.SetVersion(nameVersion[i+1:])
+		} else {
+			p.scope.SetName(nameVersion)
+		}
+	case trimmed == "Attributes:":
+		switch p.record {
+		case "Span":
+			p.setAttributes(p.span.Attributes())
+		case "LogRecord":
+			p.setAttributes(p.logRecord.Attributes())
+		}
+	case trimmed == "Data point attributes:":
+		if attributed, ok := p.dataPoint.(interface{ Attributes() pcommon.Map }); ok {
+			p.setAttributes(attributed.Attributes())
+		}
+	case trimmed == "Events:" || trimmed == "Links:" || trimmed == "Exemplars:" || trimmed == "Descriptor:":
+	default:
+		return p.parseDataPointLine(trimmed)
+	}
+	return nil
+}
+
+// setAttributes makes the following attribute lines add to m
+func (p *debugTextParser) setAttributes(m pcommon.Map) {
+	p.attributes = &m
+}
+
+// parseArrowLine parses a line starting with "->", which is an attribute or a field of a
+// span event, span link, metric descriptor or exemplar
+func (p *debugTextParser) parseArrowLine(field string) error {
+	switch field {
+	case "Attributes::":
+		switch p.record {
+		case "SpanEvent":
+			p.setAttributes(p.event.Attributes())
+		case "SpanLink":
+			p.setAttributes(p.link.Attributes())
+		}
+		return nil
+	case "FilteredAttributes:":
+		p.setAttributes(p.exemplar.FilteredAttributes())
+		return nil
+	}
+
+	key, value, _ := strings.Cut(field, ":")
+	value = strings.TrimSpace(value)
+	if p.attributes != nil {
+		return putDebugValue(*p.attributes, key, value)
+	}
+	return p.setField(key, value)
+}
+
+// startRecord starts a resource, scope, record or data point on a "Name #N" line
+func (p *debugTextParser) startRecord(name string) error {
+	if strings.HasPrefix(name, "Resource") || strings.HasPrefix(name, "Scope") || strings.HasSuffix(name, "DataPoints") || name == "Metric" {
+		p.finishDataPoint()
+	}
+	p.record = name
+
+	switch name {
+	case "ResourceSpans":
+		p.signal = name
+		p.resourceSpans = p.capture.traces.ResourceSpans().AppendEmpty()
+		p.resource = p.resourceSpans.Resource()
+		p.setSchemaURL["Resource"] = p.resourceSpans.SetSchemaUrl
+	case "ResourceLog":
+		p.signal = name
+		p.resourceLogs = p.capture.logs.ResourceLogs().AppendEmpty()
+		p.resource = p.resourceLogs.Resource()
+		p.setSchemaURL["Resource"] = p.resourceLogs.SetSchemaUrl
+	case "ResourceMetrics":
+		p.signal = name
+		p.resourceMetrics = p.capture.metrics.ResourceMetrics().AppendEmpty()
+		p.resource = p.resourceMetrics.Resource()
+		p.setSchemaURL["Resource"] = p.resourceMetrics.SetSchemaUrl
+	case "ResourceProfiles":
+		return fmt.Errorf("profiles are not supported in debug exporter output; capture them with the file exporter")
+	case "ScopeSpans":
+		if err := p.expectSignal("ResourceSpans"); err != nil {
+			return err
+		}
+		p.scopeSpans = p.resourceSpans.ScopeSpans().AppendEmpty()
+		p.scope = p.scopeSpans.Scope()
+		p.setSchemaURL[name] = p.scopeSpans.SetSchemaUrl
+	case "ScopeLogs":
+		if err := p.expectSignal("ResourceLog"); err != nil {
+			return err
+		}
+		p.scopeLogs = p.resourceLogs.ScopeLogs().AppendEmpty()
+		p.scope = p.scopeLogs.Scope()
+		p.setSchemaURL[name] = p.scopeLogs.SetSchemaUrl
+	case "ScopeMetrics":
+		if err := p.expectSignal("ResourceMetrics"); err != nil {
+			return err
+		}
+		p.scopeMetrics = p.resourceMetrics.ScopeMetrics().AppendEmpty()
+		p.scope = p.scopeMetrics.Scope()
+		p.setSchemaURL[name] = p.scopeMetrics.SetSchemaUrl
+	case "Span":
+		p.span = p.scopeSpans.Spans().AppendEmpty()
+	case "SpanEvent":
+		p.event = p.span.Events().AppendEmpty()
+	case "SpanLink":
+		p.link = p.span.Links().AppendEmpty()
+	case "LogRecord":
+		p.logRecord = p.scopeLogs.LogRecords().AppendEmpty()
+	case "Metric":
+		p.metric = p.scopeMetrics.Metrics().AppendEmpty()
+	case "NumberDataPoints":
+		if p.metric.Type() == pmetric.MetricTypeSum {
+			p.dataPoint = p.metric.Sum().DataPoints().AppendEmpty()
+		} else {
+			p.dataPoint = p.metric.Gauge().DataPoints().AppendEmpty()
+		}
+	case "HistogramDataPoints":
+		p.dataPoint = p.metric.Histogram().DataPoints().AppendEmpty()
+	case "ExponentialHistogramDataPoints":
+		dp := p.metric.ExponentialHistogram().DataPoints().AppendEmpty()
+		p.dataPoint = dp
+		p.buckets = &debugExponentialBuckets{dataPoint: dp}
+	case "SummaryDataPoints":
+		p.dataPoint = p.metric.Summary().DataPoints().AppendEmpty()
+	case "Exemplar":
+		withExemplars, ok := p.dataPoint.(interface{ Exemplars() pmetric.ExemplarSlice })
+		if !ok {
+			return fmt.Errorf("exemplar outside of a data point")
+		}
+		p.exemplar = withExemplars.Exemplars().AppendEmpty()
+	default:
+		return fmt.Errorf("unknown record %q", name)
+	}
+	return nil
+}
+
+// expectSignal checks that a scope belongs to the resource it follows
+func (p *debugTextParser) expectSignal(resource string) error {
+	if p.signal != resource {
+		return fmt.Errorf("scope outside of %s", resource)
+	}
+	return nil
+}
+
+// setField sets a field of the current record from a "Key: value" line
+func (p *debugTextParser) setField(key, value string) error {
+	key = strings.TrimSpace(key)
+	switch p.record {
+	case "Span":
+		return p.setSpanField(key, value)
+	case "SpanEvent":
+		return setDebugFields(key, value, map[string]func(string) error{
+			"Name":                   func(v string) error { p.event.SetName(v); return nil },
+			"Timestamp":              debugTimestampSetter(p.event.SetTimestamp),
+			"DroppedAttributesCount": debugUint32Setter(p.event.SetDroppedAttributesCount),
+		})
+	case "SpanLink":
+		return setDebugFields(key, value, map[string]func(string) error{
+			"Trace ID":               debugTraceIDSetter(p.link.SetTraceID),
+			"ID":                     debugSpanIDSetter(p.link.SetSpanID),
+			"TraceState":             func(v string) error { p.link.TraceState().FromRaw(v); return nil },
+			"DroppedAttributesCount": debugUint32Setter(p.link.SetDroppedAttributesCount),
+		})
+	case "LogRecord":
+		return p.setLogRecordField(key, value)
+	case "Metric":
+		return p.setMetricField(key, value)
+	case "Exemplar":
+		return setDebugFields(key, value, map[string]func(string) error{
+			"Trace ID":  debugTraceIDSetter(p.exemplar.SetTraceID),
+			"Span ID":   debugSpanIDSetter(p.exemplar.SetSpanID),
+			"Timestamp": debugTimestampSetter(p.exemplar.SetTimestamp),
+			"Value": func(v string) error {
+				if strings.Contains(v, ".") {
+					f, err := strconv.ParseFloat(v, 64)
+					p.exemplar.SetDoubleValue(f)
+					return err
+				}
+				i, err := strconv.ParseInt(v, 10, 64)
+				p.exemplar.SetIntValue(i)
+				return err
+			},
+		})
+	}
+	return p.setDataPointField(key, value)
+}
+
+// setSpanField sets a field of the current span
+func (p *debugTextParser) setSpanField(key, value string) error {
+	return setDebugFields(key, value, map[string]func(string) error{
+		"Trace ID":       debugTraceIDSetter(p.span.SetTraceID),
+		"Parent ID":      debugSpanIDSetter(p.span.SetParentSpanID),
+		"ID":             debugSpanIDSetter(p.span.SetSpanID),
+		"Name":           func(v string) error { p.span.SetName(v); return nil },
+		"TraceState":     func(v string) error { p.span.TraceState().FromRaw(v); return nil },
+		"Start time":     debugTimestampSetter(p.span.SetStartTimestamp),
+		"End time":       debugTimestampSetter(p.span.SetEndTimestamp),
+		"Status message": func(v string) error { p.span.Status().SetMessage(v); return nil },
+		"Kind": func(v string) error {
+			for kind := ptrace.SpanKindUnspecified; kind <= ptrace.SpanKindConsumer; kind++ {
+				if kind.String() == v {
+					p.span.SetKind(kind)
+					return nil
+				}
+			}
+			return fmt.Errorf("unknown span kind %q", v)
+		},
+		"Status code": func(v string) error {
+			for code := ptrace.StatusCodeUnset; code <= ptrace.StatusCodeError; code++ {
+				if code.String() == v {
+					p.span.Status().SetCode(code)
+					return nil
+				}
+			}
+			return fmt.Errorf("unknown status code %q", v)
+		},
+	})
+}
+
+// setLogRecordField sets a field of the current log record
+func (p *debugTextParser) setLogRecordField(key, value string) error {
+	return setDebugFields(key, value, map[string]func(string) error{
+		"ObservedTimestamp": debugTimestampSetter(p.logRecord.SetObservedTimestamp),
+		"Timestamp":         debugTimestampSetter(p.logRecord.SetTimestamp),
+		"SeverityText":      func(v string) error { p.logRecord.SetSeverityText(v); return nil },
+		"EventName":         func(v string) error { p.logRecord.SetEventName(v); return nil },
+		"Body":              func(v string) error { return setDebugValue(p.logRecord.Body(), v) },
+		"Trace ID":          debugTraceIDSetter(p.logRecord.SetTraceID),
+		"Span ID":           debugSpanIDSetter(p.logRecord.SetSpanID),
+		"SeverityNumber": func(v string) error {
+			m := debugSeverityNumber.FindStringSubmatch(v)
+			if m == nil {
+				return fmt.Errorf("invalid severity number %q", v)
+			}
+			n, err := strconv.ParseInt(m[1], 10, 32)
+			p.logRecord.SetSeverityNumber(plog.SeverityNumber(n))
+			return err
+		},
+		"Flags": func(v string) error {
+			n, err := strconv.ParseUint(v, 10, 32)
+			p.logRecord.SetFlags(plog.LogRecordFlags(n))
+			return err
+		},
+	})
+}
+
+// setMetricField sets a field of the descriptor of the current metric
+func (p *debugTextParser) setMetricField(key, value string) error {
+	return setDebugFields(key, value, map[string]func(string) error{
+		"Name":        func(v string) error { p.metric.SetName(v); return nil },
+		"Description": func(v string) error { p.metric.SetDescription(v); return nil },
+		"Unit":        func(v string) error { p.metric.SetUnit(v); return nil },
+		"DataType": func(v string) error {
+			switch v {
+			case pmetric.MetricTypeGauge.String():
+				p.metric.SetEmptyGauge()
+			case pmetric.MetricTypeSum.String():
+				p.metric.SetEmptySum()
+			case pmetric.MetricTypeHistogram.String():
+				p.metric.SetEmptyHistogram()
+			case pmetric.MetricTypeExponentialHistogram.String():
+				p.metric.SetEmptyExponentialHistogram()
+			case pmetric.MetricTypeSummary.String():
+				p.metric.SetEmptySummary()
+			case pmetric.MetricTypeEmpty.String():
+			default:
+				return fmt.Errorf("unknown metric type %q", v)
+			}
+			return nil
+		},
+		"IsMonotonic": func(v string) error {
+			b, err := strconv.ParseBool(v)
+			if p.metric.Type() == pmetric.MetricTypeSum {
+				p.metric.Sum().SetIsMonotonic(b)
+			}
+			return err
+		},
+		"AggregationTemporality": func(v string) error {
+			var temporality pmetric.AggregationTemporality
+			for t := pmetric.AggregationTemporalityUnspecified; t <= pmetric.AggregationTemporalityCumulative; t++ {
+				if t.String() == v {
+					temporality = t
+				}
+			}
+			switch p.metric.Type() {
+			case pmetric.MetricTypeSum:
+				p.metric.Sum().SetAggregationTemporality(temporality)
+			case pmetric.MetricTypeHistogram:
+				p.metric.Histogram().SetAggregationTemporality(temporality)
+			case pmetric.MetricTypeExponentialHistogram:
+				p.metric.ExponentialHistogram().SetAggregationTemporality(temporality)
+			}
+			return nil
+		},
+	})
+}
+
+// parseDataPointLine parses the data point lines that list buckets, bounds and quantiles
+func (p *debugTextParser) parseDataPointLine(line string) error {
+	switch dp := p.dataPoint.(type) {
+	case pmetric.HistogramDataPoint:
+		if m := debugExplicitBound.FindStringSubmatch(line); m != nil {
+			bound, err := strconv.ParseFloat(m[1], 64)
+			dp.ExplicitBounds().Append(bound)
+			return err
+		}
+		if m := debugBucketCount.FindStringSubmatch(line); m != nil {
+			count, err := strconv.ParseUint(m[1], 10, 64)
+			dp.BucketCounts().Append(count)
+			return err
+		}
+	case pmetric.ExponentialHistogramDataPoint:
+		if m := debugExponentialBucketLine.FindStringSubmatch(line); m != nil {
+			return p.buckets.add(m[1] == "(", m[2], m[3], m[4])
+		}
+	case pmetric.SummaryDataPoint:
+		if m := debugQuantileValue.FindStringSubmatch(line); m != nil {
+			quantile, err := strconv.ParseFloat(m[1], 64)
+			if err != nil {
+				return err
+			}
+			value, err := strconv.ParseFloat(m[2], 64)
+			q := dp.QuantileValues().AppendEmpty()
+			q.SetQuantile(quantile)
+			q.SetValue(value)
+			return err
+		}
+	}
+
+	key, value, ok := strings.Cut(line, ":")
+	if !ok {
+		return fmt.Errorf("unexpected line")
+	}
+	return p.setField(key, strings.TrimSpace(value))
+}
+
+// setDataPointField sets a field of the current data point
+func (p *debugTextParser) setDataPointField(key, value string) error {
+	if p.dataPoint == nil {
+		return fmt.Errorf("unexpected field %q", key)
+	}
+	timestamps := p.dataPoint.(interface {
+		SetStartTimestamp(pcommon.Timestamp)
+		SetTimestamp(pcommon.Timestamp)
+	})
+	switch key {
+	case "StartTimestamp":
+		return debugTimestampSetter(timestamps.SetStartTimestamp)(value)
+	case "Timestamp":
+		return debugTimestampSetter(timestamps.SetTimestamp)(value)
+	case "Value":
+		dp, ok := p.dataPoint.(pmetric.NumberDataPoint)
+		if !ok {
+			return fmt.Errorf("unexpected field %q", key)
+		}
+		if i, err := strconv.ParseInt(value, 10, 64); err == nil {
+			dp.SetIntValue(i)
+			return nil
+		}
+		f, err := strconv.ParseFloat(value, 64)
+		dp.SetDoubleValue(f)
+		return err
+	case "Count":
+		count, err := strconv.ParseUint(value, 10, 64)
+		if err != nil {
+			return err
+		}
+		p.dataPoint.(interface{ SetCount(uint64) }).SetCount(count)
+		return nil
+	case "Sum", "Min", "Max":
+		f, err := strconv.ParseFloat(value, 64)
+		if err != nil {
+			return err
+		}
+		setters := map[string]func(float64){}
+		if dp, ok := p.dataPoint.(interface{ SetSum(float64) }); ok {
+			setters["Sum"] = dp.SetSum
+		}
+		if dp, ok := p.dataPoint.(interface {
+			SetMin(float64)
+			SetMax(float64)
+		}); ok {
+			setters["Min"], setters["Max"] = dp.SetMin, dp.SetMax
+		}
+		if set, ok := setters[key]; ok {
+			set(f)
+			return nil
+		}
+	}
+	return fmt.Errorf("unexpected field %q", key)
+}
+
+// finishDataPoint completes the current data point once all of its lines are read
+func (p *debugTextParser) finishDataPoint() {
+	if p.buckets != nil {
+		p.buckets.finish()
+		p.buckets = nil
+	}
+	p.dataPoint = nil
+}
+
+// debugExponentialBucket is a bucket of an exponential histogram as the debug exporter prints it
+type debugExponentialBucket struct {
+	lower, upper float64 // absolute bounds
+	count        uint64
+}
+
+// debugExponentialBuckets collects the buckets of an exponential histogram data point.
+// The debug exporter prints bucket bounds rather than the scale and offsets, which
+// finish derives from the bounds.
+type debugExponentialBuckets struct {
+	dataPoint pmetric.ExponentialHistogramDataPoint
+	positive  []debugExponentialBucket
+	negative  []debugExponentialBucket // highest index first, as printed
+}
+
+// add adds a bucket from its printed bounds; the zero bucket is printed as [0, 0]
+func (b *debugExponentialBuckets) add(positive bool, lower, upper, count string) error {
+	lowerBound, err := strconv.ParseFloat(lower, 64)
+	if err != nil {
+		return err
+	}
+	upperBound, err := strconv.ParseFloat(upper, 64)
+	if err != nil {
+		return err
+	}
+	n, err := strconv.ParseUint(count, 10, 64)
+	if err != nil {
+		return err
+	}
+
+	switch {
+	case positive:
+		b.positive = append(b.positive, debugExponentialBucket{lower: lowerBound, upper: upperBound, count: n})
+	case lowerBound == 0 && upperBound == 0:
+		b.dataPoint.SetZeroCount(n)
+	default:
+		b.negative = append(b.negative, debugExponentialBucket{lower: -upperBound, upper: -lowerBound, count: n})
+	}
+	return nil
+}
+
+// finish sets the scale, offsets and bucket counts of the data point
+func (b *debugExponentialBuckets) finish() {
+	// The widest bucket has the most precise printed bounds
+	widest := debugExponentialBucket{}
+	for _, bucket := range append(append([]debugExponentialBucket{}, b.positive...), b.negative...) {
+		if bucket.lower > 0 && bucket.upper > widest.upper {
+			widest = bucket
+		}
+	}
+	if widest.upper == 0 {
+		return
+	}
+	factor := math.Log(widest.upper / widest.lower)
+	scale := int32(math.Round(-math.Log2(factor / math.Ln2)))
+	b.dataPoint.SetScale(scale)
+	factor = math.Ldexp(math.Ln2, -int(scale))
+
+	if len(b.positive) > 0 {
+		last := b.positive[len(b.positive)-1]
+		b.dataPoint.Positive().SetOffset(int32(math.Round(math.Log(last.lower)/factor)) - int32(len(b.positive)-1))
+		for _, bucket := range b.positive {
+			b.dataPoint.Positive().BucketCounts().Append(bucket.count)
+		}
+	}
+	if len(b.negative) > 0 {
+		highest := b.negative[0]
+		b.dataPoint.Negative().SetOffset(int32(math.Round(math.Log(highest.lower)/factor)) - int32(len(b.negative)-1))
+		for i := len(b.negative) - 1; i >= 0; i-- {
+			b.dataPoint.Negative().BucketCounts().Append(b.negative[i].count)
+		}
+	}
+}
+
+// setDebugFields calls the setter of a field
+func setDebugFields(key, value string, setters map[string]func(string) error) error {
+	set, ok := setters[key]
+	if !ok {
+		return fmt.Errorf("unexpected field %q", key)
+	}
+	return set(value)
+}
+
+// debugTimestampSetter returns a setter parsing a printed timestamp
+func debugTimestampSetter(set func(pcommon.Timestamp)) func(string) error {
+	return func(value string) error {
+		t, err := time.Parse(debugTimestampLayout, value)
+		if err != nil {
+			return fmt.Errorf("invalid timestamp: %w", err)
+		}
+		set(pcommon.NewTimestampFromTime(t))
+		return nil
+	}
+}
+
+// debugUint32Setter returns a setter parsing a count
+func debugUint32Setter(set func(uint32)) func(string) error {
+	return func(value string) error {
+		n, err := strconv.ParseUint(value, 10, 32)
+		set(uint32(n))
+		return err
+	}
+}
+
+// debugTraceIDSetter returns a setter parsing a hex trace ID, which is empty when not set
+func debugTraceIDSetter(set func(pcommon.TraceID)) func(string) error {
+	return func(value string) error {
+		var id pcommon.TraceID
+		if value != "" {
+			if n, err := hex.Decode(id[:], []byte(value)); err != nil || n != len(id) {
+				return fmt.Errorf("invalid trace ID %q", value)
+			}
+		}
+		set(id)
+		return nil
+	}
+}
+
+// debugSpanIDSetter returns a setter parsing a hex span ID, which is empty when not set
+func debugSpanIDSetter(set func(pcommon.SpanID)) func(string) error {
+	return func(value string) error {
+		var id pcommon.SpanID
+		if value != "" {
+			if n, err := hex.Decode(id[:], []byte(value)); err != nil || n != len(id) {
+				return fmt.Errorf("invalid span ID %q", value)
+			}
+		}
+		set(id)
+		return nil
+	}
+}
+
+// putDebugValue adds an attribute from a "key: Type(value)" line
+func putDebugValue(attributes pcommon.Map, key, value string) error {
+	v := pcommon.NewValueEmpty()
+	if err := setDebugValue(v, value); err != nil {
+		return fmt.Errorf("attribute %q: %w", key, err)
+	}
+	v.CopyTo(attributes.PutEmpty(key))
+	return nil
+}
+
+// setDebugValue sets a value from its printed form, such as Str(GET) or Map({"a":1})
+func setDebugValue(v pcommon.Value, printed string) error {
+	typ, content, ok := strings.Cut(printed, "(")
+	if !ok || !strings.HasSuffix(content, ")") {
+		return fmt.Errorf("invalid value %q", printed)
+	}
+	content = strings.TrimSuffix(content, ")")
+
+	switch typ {
+	case pcommon.ValueTypeEmpty.String():
+	case pcommon.ValueTypeStr.String():
+		v.SetStr(content)
+	case pcommon.ValueTypeInt.String():
+		i, err := strconv.ParseInt(content, 10, 64)
+		if err != nil {
+			return err
+		}
+		v.SetInt(i)
+	case pcommon.ValueTypeDouble.String():
+		f, err := strconv.ParseFloat(content, 64)
+		if err != nil {
+			return err
+		}
+		v.SetDouble(f)
+	case pcommon.ValueTypeBool.String():
+		b, err := strconv.ParseBool(content)
+		if err != nil {
+			return err
+		}
+		v.SetBool(b)
+	case pcommon.ValueTypeBytes.String():
+		b, err := base64.StdEncoding.DecodeString(content)
+		if err != nil {
+			return err
+		}
+		v.SetEmptyBytes().FromRaw(b)
+	case pcommon.ValueTypeMap.String(), pcommon.ValueTypeSlice.String():
+		decoder := json.NewDecoder(strings.NewReader(content))
+		decoder.UseNumber()
+		var raw any
+		if err := decoder.Decode(&raw); err != nil {
+			return fmt.Errorf("invalid %s value: %w", typ, err)
+		}
+		return v.FromRaw(debugJSONToRaw(raw))
+	default:
+		return fmt.Errorf("unknown value type %q", typ)
+	}
+	return nil
+}
+
+// debugJSONToRaw converts decoded JSON numbers to the int64 or float64 pcommon expects
+func debugJSONToRaw(raw any) any {
+	switch raw := raw.(type) {
+	case json.Number:
+		if i, err := raw.Int64(); err == nil {
+			return i
+		}
+		f, _ := raw.Float64()
+		return f
+	case map[string]any:
+		for k, v := range raw {
+			raw[k] = debugJSONToRaw(v)
+		}
+	case []any:
+		for i, v := range raw {
+			raw[i] = debugJSONToRaw(v)
+		}
+	}
+	return raw
+}
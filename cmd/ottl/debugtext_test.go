@@ -0,0 +1,285 @@
+package main
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/plog"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+)
+
+func TestIsDebugText(t *testing.T) {
+	assert.True(t, isDebugText(readTestData(t, "debug-exporter.log")))
+	assert.True(t, isDebugText([]byte("ResourceLog #0\nResource SchemaURL: \n")))
+	assert.False(t, isDebugText(readTestData(t, "traces.json")))
+	assert.False(t, isDebugText([]byte("2025-08-01T10:00:00.000Z\tinfo\tTraces\t{}")))
+}
+
+func TestParseDebugTextTraces(t *testing.T) {
+	ctx, data, err := detectContextType(readTestData(t, "debug-exporter.log"))
+	require.NoError(t, err)
+	require.Equal(t, contextTypeSpan, ctx)
+
+	traces := data.(ptrace.Traces)
+	require.Equal(t, 1, traces.ResourceSpans().Len())
+	rs := traces.ResourceSpans().At(0)
+	assert.Equal(t, "https://opentelemetry.io/schemas/1.26.0", rs.SchemaUrl())
+	assert.Equal(t, map[string]any{"service.name": "checkout", "host.cpu.count": int64(4)}, rs.Resource().Attributes().AsRaw())
+
+	ss := rs.ScopeSpans().At(0)
+	assert.Equal(t, "go.opentelemetry.io/contrib/instrumentation/net/http/otelhttp", ss.Scope().Name())
+	assert.Equal(t, "0.62.0", ss.Scope().Version())
+	require.Equal(t, 2, ss.Spans().Len())
+
+	root := ss.Spans().At(0)
+	assert.Equal(t, "5b8efff798038103d269b633813fc60c", root.TraceID().String())
+	assert.Equal(t, "eee19b7ec3c1b174", root.SpanID().String())
+	assert.True(t, root.ParentSpanID().IsEmpty())
+	assert.Equal(t, "POST /api/checkout", root.Name())
+	assert.Equal(t, ptrace.SpanKindServer, root.Kind())
+	assert.Equal(t, pcommon.Timestamp(1754042404500000000), root.StartTimestamp())
+	assert.Equal(t, pcommon.Timestamp(1754042404750000000), root.EndTimestamp())
+	assert.Equal(t, ptrace.StatusCodeError, root.Status().Code())
+	assert.Equal(t, "payment declined", root.Status().Message())
+	assert.Equal(t, map[string]any{"http.request.method": "POST", "http.response.status_code": int64(502), "retry": true}, root.Attributes().AsRaw())
+	require.Equal(t, 1, root.Events().Len())
+	assert.Equal(t, "exception", root.Events().At(0).Name())
+	assert.Equal(t, map[string]any{"exception.message": "card declined:\ninsufficient funds"}, root.Events().At(0).Attributes().AsRaw())
+
+	child := ss.Spans().At(1)
+	assert.Equal(t, root.SpanID(), child.ParentSpanID())
+	assert.Equal(t, ptrace.SpanKindClient, child.Kind())
+	assert.Empty(t, child.Status().Message())
+}
+
+func TestParseDebugTextLogs(t *testing.T) {
+	text := `ResourceLog #0
+Resource SchemaURL: 
+ScopeLogs #0
+ScopeLogs SchemaURL: 
+InstrumentationScope  
+LogRecord #0
+ObservedTimestamp: 1970-01-01 00:00:00 +0000 UTC
+Timestamp: 2020-02-11 20:26:13.000000789 +0000 UTC
+SeverityText: INFO
+SeverityNumber: Info(9)
+EventName: checkout.completed
+Body: Map({"key1":"val1","key2":{"key21":2,"key22":[1.5,true]}})
+Attributes:
+     -> bytes: Bytes(AQID)
+     -> ratio: Double(0.25)
+     -> none: Empty()
+Trace ID: 08040201000000000000000000000000
+Span ID: 0102040800000000
+Flags: 1
+LogRecord #1
+ObservedTimestamp: 1970-01-01 00:00:00 +0000 UTC
+Timestamp: 1970-01-01 00:00:00 +0000 UTC
+SeverityText: 
+SeverityNumber: Unspecified(0)
+Body: Str(first line
+second line)
+Trace ID: 
+Span ID: 
+Flags: 0
+`
+	capture, ok, err := parseCapture([]byte(text))
+	require.True(t, ok)
+	require.NoError(t, err)
+	logs, ok := capture.data(contextTypeLog)
+	require.True(t, ok)
+
+	records := logs.(plog.Logs).ResourceLogs().At(0).ScopeLogs().At(0).LogRecords()
+	require.Equal(t, 2, records.Len())
+
+	first := records.At(0)
+	assert.Equal(t, pcommon.Timestamp(0), first.ObservedTimestamp())
+	assert.Equal(t, pcommon.Timestamp(1581452773000000789), first.Timestamp())
+	assert.Equal(t, "INFO", first.SeverityText())
+	assert.Equal(t, plog.SeverityNumberInfo, first.SeverityNumber())
+	assert.Equal(t, "checkout.completed", first.EventName())
+	assert.Equal(t, map[string]any{"key1": "val1", "key2": map[string]any{"key21": int64(2), "key22": []any{1.5, true}}}, first.Body().Map().AsRaw())
+	assert.Equal(t, map[string]any{"bytes": []byte{1, 2, 3}, "ratio": 0.25, "none": nil}, first.Attributes().AsRaw())
+	assert.Equal(t, "08040201000000000000000000000000", first.TraceID().String())
+	assert.Equal(t, "0102040800000000", first.SpanID().String())
+	assert.Equal(t, plog.LogRecordFlags(1), first.Flags())
+
+	second := records.At(1)
+	assert.Equal(t, "first line\nsecond line", second.Body().Str())
+	assert.True(t, second.TraceID().IsEmpty())
+}
+
+func TestParseDebugTextMetrics(t *testing.T) {
+	text := `ResourceMetrics #0
+Resource SchemaURL: 
+Resource attributes:
+     -> service.name: Str(api)
+ScopeMetrics #0
+ScopeMetrics SchemaURL: 
+InstrumentationScope meter 1.0
+InstrumentationScope attributes:
+     -> scope.kind: Str(http)
+Metric #0
+Descriptor:
+     -> Name: requests
+     -> Description: Number of requests
+     -> Unit: 1
+     -> DataType: Sum
+     -> IsMonotonic: true
+     -> AggregationTemporality: Cumulative
+NumberDataPoints #0
+Data point attributes:
+     -> method: Str(GET)
+StartTimestamp: 2020-02-11 20:26:12.000000321 +0000 UTC
+Timestamp: 2020-02-11 20:26:13.000000789 +0000 UTC
+Value: 123
+NumberDataPoints #1
+StartTimestamp: 2020-02-11 20:26:12.000000321 +0000 UTC
+Timestamp: 2020-02-11 20:26:13.000000789 +0000 UTC
+Value: 4.560000
+Metric #1
+Descriptor:
+     -> Name: duration
+     -> Description: 
+     -> Unit: s
+     -> DataType: Histogram
+     -> AggregationTemporality: Delta
+HistogramDataPoints #0
+StartTimestamp: 2020-02-11 20:26:12.000000321 +0000 UTC
+Timestamp: 2020-02-11 20:26:13.000000789 +0000 UTC
+Count: 2
+Sum: 15.000000
+Min: 5.000000
+Max: 10.000000
+ExplicitBounds #0: 1.000000
+ExplicitBounds #1: 7.500000
+Buckets #0, Count: 0
+Buckets #1, Count: 1
+Buckets #2, Count: 1
+Exemplars:
+Exemplar #0
+     -> Trace ID: 0102030405060708090a0b0c0d0e0f10
+     -> Span ID: 1112131415161718
+     -> Timestamp: 2020-02-11 20:26:13.000000123 +0000 UTC
+     -> Value: 10.000000
+     -> FilteredAttributes:
+          -> user.id: Str(42)
+Metric #2
+Descriptor:
+     -> Name: latency
+     -> Description: 
+     -> Unit: s
+     -> DataType: ExponentialHistogram
+     -> AggregationTemporality: Delta
+ExponentialHistogramDataPoints #0
+StartTimestamp: 2020-02-11 20:26:12.000000321 +0000 UTC
+Timestamp: 2020-02-11 20:26:13.000000789 +0000 UTC
+Count: 5
+Sum: 0.150000
+Bucket [-1.414214, -1.000000), Count: 1
+Bucket [-1.000000, -0.707107), Count: 2
+Bucket [0, 0], Count: 1
+Bucket (1.414214, 2.000000], Count: 3
+Bucket (2.000000, 2.828427], Count: 4
+Metric #3
+Descriptor:
+     -> Name: gc
+     -> Description: 
+     -> Unit: s
+     -> DataType: Summary
+SummaryDataPoints #0
+StartTimestamp: 2020-02-11 20:26:12.000000321 +0000 UTC
+Timestamp: 2020-02-11 20:26:13.000000789 +0000 UTC
+Count: 1
+Sum: 15.000000
+QuantileValue #0: Quantile 0.500000, Value 15.000000
+QuantileValue #1: Quantile 0.990000, Value 15.000000
+`
+	ctx, data, err := detectContextType([]byte(text))
+	require.NoError(t, err)
+	require.Equal(t, contextTypeMetric, ctx)
+
+	sm := data.(pmetric.Metrics).ResourceMetrics().At(0).ScopeMetrics().At(0)
+	assert.Equal(t, "meter", sm.Scope().Name())
+	assert.Equal(t, "1.0", sm.Scope().Version())
+	assert.Equal(t, map[string]any{"scope.kind": "http"}, sm.Scope().Attributes().AsRaw())
+	require.Equal(t, 4, sm.Metrics().Len())
+
+	sum := sm.Metrics().At(0)
+	assert.Equal(t, "requests", sum.Name())
+	assert.Equal(t, "Number of requests", sum.Description())
+	require.Equal(t, pmetric.MetricTypeSum, sum.Type())
+	assert.True(t, sum.Sum().IsMonotonic())
+	assert.Equal(t, pmetric.AggregationTemporalityCumulative, sum.Sum().AggregationTemporality())
+	require.Equal(t, 2, sum.Sum().DataPoints().Len())
+	assert.Equal(t, int64(123), sum.Sum().DataPoints().At(0).IntValue())
+	assert.Equal(t, map[string]any{"method": "GET"}, sum.Sum().DataPoints().At(0).Attributes().AsRaw())
+	assert.Equal(t, 4.56, sum.Sum().DataPoints().At(1).DoubleValue())
+
+	histogram := sm.Metrics().At(1).Histogram()
+	assert.Equal(t, pmetric.AggregationTemporalityDelta, histogram.AggregationTemporality())
+	dp := histogram.DataPoints().At(0)
+	assert.Equal(t, uint64(2), dp.Count())
+	assert.Equal(t, 15.0, dp.Sum())
+	assert.Equal(t, 5.0, dp.Min())
+	assert.Equal(t, 10.0, dp.Max())
+	assert.Equal(t, []float64{1, 7.5}, dp.ExplicitBounds().AsRaw())
+	assert.Equal(t, []uint64{0, 1, 1}, dp.BucketCounts().AsRaw())
+	require.Equal(t, 1, dp.Exemplars().Len())
+	exemplar := dp.Exemplars().At(0)
+	assert.Equal(t, 10.0, exemplar.DoubleValue())
+	assert.Equal(t, "1112131415161718", exemplar.SpanID().String())
+	assert.Equal(t, map[string]any{"user.id": "42"}, exemplar.FilteredAttributes().AsRaw())
+
+	exponential := sm.Metrics().At(2).ExponentialHistogram().DataPoints().At(0)
+	assert.Equal(t, int32(1), exponential.Scale())
+	assert.Equal(t, uint64(1), exponential.ZeroCount())
+	assert.Equal(t, int32(1), exponential.Positive().Offset())
+	assert.Equal(t, []uint64{3, 4}, exponential.Positive().BucketCounts().AsRaw())
+	assert.Equal(t, int32(-1), exponential.Negative().Offset())
+	assert.Equal(t, []uint64{2, 1}, exponential.Negative().BucketCounts().AsRaw())
+
+	summary := sm.Metrics().At(3).Summary().DataPoints().At(0)
+	assert.Equal(t, uint64(1), summary.Count())
+	require.Equal(t, 2, summary.QuantileValues().Len())
+	assert.Equal(t, 0.99, summary.QuantileValues().At(1).Quantile())
+}
+
+func TestParseDebugTextErrors(t *testing.T) {
+	tests := []struct {
+		name     string
+		text     string
+		expected string
+	}{
+		{
+			name:     "profiles",
+			text:     "ResourceProfiles #0\n",
+			expected: "profiles are not supported",
+		},
+		{
+			name:     "invalid value",
+			text:     "ResourceSpans #0\nResource attributes:\n     -> a: Int(x)\n",
+			expected: `line 3: "     -> a: Int(x)"`,
+		},
+		{
+			name:     "invalid timestamp",
+			text:     "ResourceLog #0\nScopeLogs #0\nLogRecord #0\nTimestamp: yesterday\n",
+			expected: "invalid timestamp",
+		},
+		{
+			name:     "scope of another signal",
+			text:     "ResourceSpans #0\nScopeLogs #0\n",
+			expected: "scope outside of ResourceLog",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := parseDebugText([]byte(tt.text))
+			assert.ErrorContains(t, err, tt.expected)
+		})
+	}
+}
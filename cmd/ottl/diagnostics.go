@@ -0,0 +1,201 @@
+// Copyright 2025 Dose de Telemetria GmbH
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+	"unicode"
+
+	"github.com/telemetrydrops/ottl-cli/pkg/functions"
+)
+
+// statementDiagnostic is a parse error located in the source of the statements, rendered
+// with the failing line, a caret under the offending token and a suggested fix
+type statementDiagnostic struct {
+	source     string
+	line       int // one-based
+	column     int // one-based, in runes
+	length     int
+	text       string // the failing line of the source
+	message    string
+	suggestion string
+	err        error
+}
+
+func (d *statementDiagnostic) Error() string {
+	var b strings.Builder
+	gutter := strconv.Itoa(d.line)
+	fmt.Fprintf(&b, "%s:%d:%d: %s\n", d.source, d.line, d.column, d.message)
+	fmt.Fprintf(&b, "  %s | %s\n", gutter, d.text)
+	fmt.Fprintf(&b, "  %s | %s%s", strings.Repeat(" ", len(gutter)), caretIndent(d.text, d.column-1), strings.Repeat("^", d.length))
+	if d.suggestion != "" {
+		fmt.Fprintf(&b, "\nRemediation: %s", d.suggestion)
+	}
+	return b.String()
+}
+
+func (d *statementDiagnostic) Unwrap() error {
+	return d.err
+}
+
+// newStatementDiagnostic locates a parse error of a statement in its source
+func newStatementDiagnostic(sourceName, source string, statement sourceStatement, parseErr *statementParseError) *statementDiagnostic {
+	span := locateError(statement.text, parseErr)
+	lines := strings.Split(source, "\n")
+	line := min(statement.line+span.line, len(lines)-1)
+	return &statementDiagnostic{
+		source:     sourceName,
+		line:       line + 1,
+		column:     span.column + 1,
+		length:     max(span.length, 1),
+		text:       strings.TrimRight(lines[line], "\r"),
+		message:    parseErr.err.Error(),
+		suggestion: parseErrorSuggestion(parseErr.ctx, parseErr.err),
+		err:        parseErr,
+	}
+}
+
+// caretIndent returns the blanks that align a caret with a rune column, keeping tabs
+func caretIndent(line string, column int) string {
+	var b strings.Builder
+	for i, r := range []rune(line) {
+		if i >= column {
+			break
+		}
+		if r == '\t' {
+			b.WriteRune('\t')
+		} else {
+			b.WriteRune(' ')
+		}
+	}
+	return b.String()
+}
+
+// parseErrorMessage removes the statement that parse errors repeat and adds a suggested fix
+func parseErrorMessage(ctx contextType, statement string, err error) string {
+	message := err.Error()
+	var parseErr *statementParseError
+	if errors.As(err, &parseErr) {
+		message = parseErr.err.Error()
+	}
+	if suggestion := parseErrorSuggestion(ctx, err); suggestion != "" {
+		message += " (" + suggestion + ")"
+	}
+	return message
+}
+
+// parseErrorSuggestion proposes the closest function or path for unknown function and path errors
+func parseErrorSuggestion(ctx contextType, err error) string {
+	message := err.Error()
+	if match := undefinedFunctionPattern.FindStringSubmatch(message); match != nil {
+		// Converters start with an uppercase letter and editors with a lowercase one; a
+		// misspelled name is compared with the functions allowed where it is used
+		converter := match[1] != "" && unicode.IsUpper([]rune(match[1])[0]) || strings.Contains(message, "converter names")
+		candidates := slices.DeleteFunc(functionNames(ctx), func(name string) bool {
+			return unicode.IsUpper([]rune(name)[0]) != converter
+		})
+		if name, ok := closestName(match[1]+match[2], candidates); ok {
+			return fmt.Sprintf("did you mean %q?", name)
+		}
+		return ""
+	}
+	if match := invalidPathPattern.FindStringSubmatch(message); match != nil {
+		path, _, _ := strings.Cut(match[1], "[")
+		if path, ok := closestName(path, pathNames(ctx, path)); ok {
+			return fmt.Sprintf("did you mean %q?", path)
+		}
+	}
+	return ""
+}
+
+// functionNames returns the names of the editors and converters of a context
+func functionNames(ctx contextType) []string {
+	switch ctx {
+	case contextTypeSpan:
+		return sortedKeys(functions.Span)
+	case contextTypeLog:
+		return sortedKeys(functions.Log)
+	case contextTypeMetric:
+		return sortedKeys(functions.Metric)
+	case contextTypeDatapoint:
+		return sortedKeys(functions.DataPoint)
+	case contextTypeProfile:
+		return sortedKeys(functions.Profile)
+	default:
+		return nil
+	}
+}
+
+// pathNames returns the paths of a context, without the context name when the path
+// they are compared with has none
+func pathNames(ctx contextType, path string) []string {
+	name, _, _ := strings.Cut(path, ".")
+	prefix := ctx.String() + "."
+	var names []string
+	for _, p := range contextPaths[ctx] {
+		switch {
+		case contextNames[name]:
+			names = append(names, p.path)
+		case strings.HasPrefix(p.path, prefix):
+			names = append(names, strings.TrimPrefix(p.path, prefix))
+		}
+	}
+	return names
+}
+
+// closestName returns the candidate closest to a misspelled name, ignoring case, when it is
+// close enough to be what was meant
+func closestName(name string, candidates []string) (string, bool) {
+	best, bestDistance := "", -1
+	for _, candidate := range candidates {
+		if candidate == name {
+			continue
+		}
+		d := editDistance(strings.ToLower(name), strings.ToLower(candidate))
+		if bestDistance < 0 || d < bestDistance {
+			best, bestDistance = candidate, d
+		}
+	}
+	if bestDistance < 0 || bestDistance > max(1, len([]rune(name))/3) {
+		return "", false
+	}
+	return best, true
+}
+
+// editDistance is the Levenshtein distance between two strings
+func editDistance(a, b string) int {
+	ra, rb := []rune(a), []rune(b)
+	previous := make([]int, len(rb)+1)
+	current := make([]int, len(rb)+1)
+	for j := range previous {
+		previous[j] = j
+	}
+	for i := 1; i <= len(ra); i++ {
+		current[0] = i
+		for j := 1; j <= len(rb); j++ {
+			cost := 1
+			if ra[i-1] == rb[j-1] {
+				cost = 0
+			}
+			current[j] = min(previous[j]+1, current[j-1]+1, previous[j-1]+cost)
+		}
+		previous, current = current, previous
+	}
+	return previous[len(rb)]
+}
@@ -0,0 +1,100 @@
+package main
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+)
+
+func TestApplyStatements(t *testing.T) {
+	traces, err := (&ptrace.JSONUnmarshaler{}).UnmarshalTraces(readTestData(t, "traces.json"))
+	require.NoError(t, err)
+
+	source := "# two statements\nset(span.attributes[\"a\"], \"1\")\nset(span.attributes[\"b\"], span.attributes[\"a\"])\n    where span.name != \"\"\n"
+	require.NoError(t, applyStatements(source, "rules.ottl", contextTypeSpan, traces))
+	b, ok := traces.ResourceSpans().At(0).ScopeSpans().At(0).Spans().At(0).Attributes().Get("b")
+	require.True(t, ok)
+	assert.Equal(t, "1", b.Str())
+
+	// Statements wrapped without indentation continue while a bracket or a string is open
+	require.NoError(t, applyStatements("set(attributes[\"a\"],\n\"b\")\n", "rules.ottl", contextTypeSpan, traces))
+	a, ok := traces.ResourceSpans().At(0).ScopeSpans().At(0).Spans().At(0).Attributes().Get("a")
+	require.True(t, ok)
+	assert.Equal(t, "b", a.Str())
+
+	// Stdin is a single statement, so its lines continue it without indentation
+	require.NoError(t, applyStatements("set(span.name, \"x\")\nwhere span.kind == 1", stdinSource, contextTypeSpan, traces))
+	assert.Equal(t, "x", traces.ResourceSpans().At(0).ScopeSpans().At(0).Spans().At(0).Name())
+
+	err = applyStatements("set(span.name, \"y\")\nwhere span.kind ==", stdinSource, contextTypeSpan, traces)
+	var diagnostic *statementDiagnostic
+	require.True(t, errors.As(err, &diagnostic))
+	assert.Equal(t, 2, diagnostic.line)
+
+	err = applyStatements("# nothing", "rules.ottl", contextTypeSpan, traces)
+	assert.Error(t, err)
+
+	err = applyStatements("set(span.name, \"a\")\nset(span.attributes[\"n\"], 1 / 0)", "rules.ottl", contextTypeSpan, traces)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "rules.ottl:2:")
+}
+
+func TestStatementDiagnostic(t *testing.T) {
+	traces := ptrace.NewTraces()
+	source := "set(span.name, \"a\")\n\n\tsett(span.name, \"b\")\n"
+	err := applyStatements(source, "rules.ottl", contextTypeSpan, traces)
+
+	var diagnostic *statementDiagnostic
+	require.True(t, errors.As(err, &diagnostic))
+	assert.Equal(t, 3, diagnostic.line)
+	assert.Equal(t, 2, diagnostic.column)
+	assert.Equal(t, "rules.ottl:3:2: undefined function \"sett\"\n"+
+		"  3 | \tsett(span.name, \"b\")\n"+
+		"    | \t^^^^\n"+
+		"Remediation: did you mean \"set\"?", err.Error())
+
+	var parseErr *statementParseError
+	assert.True(t, errors.As(err, &parseErr))
+}
+
+func TestParseErrorSuggestion(t *testing.T) {
+	tests := []struct {
+		statement string
+		context   contextType
+		expected  string
+	}{
+		{statement: `sets(span.name, "x")`, context: contextTypeSpan, expected: `did you mean "set"?`},
+		{statement: `set(span.name, concat(["a"], ""))`, context: contextTypeSpan, expected: `did you mean "Concat"?`},
+		{statement: `set(span.attributes["a"], Sett("x"))`, context: contextTypeSpan, expected: ""},
+		{statement: `set(span.nme, "x")`, context: contextTypeSpan, expected: `did you mean "span.name"?`},
+		{statement: `set(severty_text, "x")`, context: contextTypeLog, expected: `did you mean "severity_text"?`},
+		{statement: `set(log.attributes["a"], "x") where`, context: contextTypeLog, expected: ""},
+	}
+
+	for _, test := range tests {
+		err := applyTransformation(test.statement, test.context, emptyData(test.context))
+		require.Error(t, err, test.statement)
+		assert.Equal(t, test.expected, parseErrorSuggestion(test.context, err), test.statement)
+	}
+}
+
+func TestClosestName(t *testing.T) {
+	candidates := []string{"set", "delete_key", "IsMatch", "Concat"}
+
+	name, ok := closestName("isMatch", candidates)
+	require.True(t, ok)
+	assert.Equal(t, "IsMatch", name)
+
+	name, ok = closestName("delete_kye", candidates)
+	require.True(t, ok)
+	assert.Equal(t, "delete_key", name)
+
+	_, ok = closestName("truncate_all", candidates)
+	assert.False(t, ok)
+
+	assert.Equal(t, 3, editDistance("kitten", "sitting"))
+	assert.Equal(t, 0, editDistance("", ""))
+}
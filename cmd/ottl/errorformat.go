@@ -0,0 +1,124 @@
+// Copyright 2025 Dose de Telemetria GmbH
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+)
+
+// errorStage is the step of the transform command an error happened in
+type errorStage string
+
+const (
+	stageRead     errorStage = "read"
+	stageDetect   errorStage = "detect"
+	stageParse    errorStage = "parse"
+	stageExecute  errorStage = "execute"
+	stageMarshal  errorStage = "marshal"
+	stageValidate errorStage = "validate"
+)
+
+// stageExitCodes are the exit codes of the transform command for each stage; other errors,
+// such as invalid flags, exit with 1
+var stageExitCodes = map[errorStage]int{
+	stageRead:     2,
+	stageDetect:   3,
+	stageParse:    4,
+	stageExecute:  5,
+	stageMarshal:  6,
+	stageValidate: 7,
+}
+
+// stageError records the stage an error of the transform command happened in
+type stageError struct {
+	stage     errorStage
+	statement int // zero-based index of the failing statement, or -1
+	err       error
+}
+
+func (e *stageError) Error() string {
+	return e.err.Error()
+}
+
+func (e *stageError) Unwrap() error {
+	return e.err
+}
+
+// exitCode returns the process exit code for an error returned by a command
+func exitCode(err error) int {
+	var se *stageError
+	if errors.As(err, &se) {
+		return stageExitCodes[se.stage]
+	}
+	return 1
+}
+
+// jsonError is an error of the transform command written with --error-format json
+type jsonError struct {
+	Stage      string         `json:"stage"`
+	Statement  *int           `json:"statement_index,omitempty"`
+	Source     string         `json:"source,omitempty"`
+	Line       int            `json:"line,omitempty"`
+	Column     int            `json:"column,omitempty"`
+	Record     *recordLocator `json:"record,omitempty"`
+	Message    string         `json:"message"`
+	Suggestion string         `json:"suggestion,omitempty"`
+	Issues     []schemaIssue  `json:"issues,omitempty"`
+}
+
+// newJSONError collects what is known about an error of the transform command
+func newJSONError(err error) jsonError {
+	out := jsonError{Stage: "command", Message: err.Error()}
+
+	var se *stageError
+	if errors.As(err, &se) {
+		out.Stage = string(se.stage)
+		if se.statement >= 0 {
+			out.Statement = &se.statement
+		}
+	}
+	var diagnostic *statementDiagnostic
+	if errors.As(err, &diagnostic) {
+		out.Source = diagnostic.source
+		out.Line = diagnostic.line
+		out.Column = diagnostic.column
+		out.Message = diagnostic.message
+		out.Suggestion = diagnostic.suggestion
+	}
+	var execErr *statementExecutionError
+	if errors.As(err, &execErr) {
+		out.Record = &execErr.record
+	}
+	var validationErr *schemaValidationError
+	if errors.As(err, &validationErr) {
+		out.Message = validationErr.summary()
+		out.Issues = validationErr.issues
+	}
+	return out
+}
+
+// writeTransformError reports an error of the transform command in the format of --error-format
+func writeTransformError(w io.Writer, err error) {
+	if errorFormat == "json" {
+		encoder := json.NewEncoder(w)
+		encoder.SetEscapeHTML(false)
+		_ = encoder.Encode(newJSONError(err))
+		return
+	}
+	_, _ = fmt.Fprintf(w, "error: %v\n", err)
+}
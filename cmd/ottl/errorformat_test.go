@@ -0,0 +1,133 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/plog"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+)
+
+func TestNewJSONError(t *testing.T) {
+	traces, err := (&ptrace.JSONUnmarshaler{}).UnmarshalTraces(readTestData(t, "traces.json"))
+	require.NoError(t, err)
+
+	parseErr := applyStatements("set(span.name, \"a\")\nsets(span.name, \"b\")", "rules.ottl", contextTypeSpan, traces)
+	out := newJSONError(parseErr)
+	assert.Equal(t, "parse", out.Stage)
+	require.NotNil(t, out.Statement)
+	assert.Equal(t, 1, *out.Statement)
+	assert.Equal(t, "rules.ottl", out.Source)
+	assert.Equal(t, 2, out.Line)
+	assert.Equal(t, 1, out.Column)
+	assert.Equal(t, `undefined function "sets"`, out.Message)
+	assert.Equal(t, `did you mean "set"?`, out.Suggestion)
+	assert.Nil(t, out.Record)
+	assert.Equal(t, 4, exitCode(parseErr))
+
+	execErr := applyStatements(`set(span.attributes["n"], 1 / 0)`, "rules.ottl", contextTypeSpan, traces)
+	out = newJSONError(execErr)
+	assert.Equal(t, "execute", out.Stage)
+	assert.Equal(t, 0, *out.Statement)
+	require.NotNil(t, out.Record)
+	assert.Equal(t, 0, out.Record.Record)
+	assert.Equal(t, "0123456789abcdef", out.Record.SpanID)
+	assert.Equal(t, 5, exitCode(execErr))
+
+	readErr := &stageError{stage: stageRead, statement: -1, err: errors.New("cannot open file")}
+	out = newJSONError(readErr)
+	assert.Equal(t, jsonError{Stage: "read", Message: "cannot open file"}, out)
+	assert.Equal(t, 2, exitCode(readErr))
+
+	validateErr := &stageError{stage: stageValidate, statement: -1, err: &schemaValidationError{issues: []schemaIssue{
+		{Path: "$.resourceLogs[0].scopeLogs[0].logRecords[0].severity", Message: "unknown field of log record"},
+	}}}
+	out = newJSONError(validateErr)
+	assert.Equal(t, "validate", out.Stage)
+	assert.Equal(t, "input does not match the OTLP schema (1 issue)", out.Message)
+	assert.Len(t, out.Issues, 1)
+	assert.Equal(t, 7, exitCode(validateErr))
+
+	assert.Equal(t, "command", newJSONError(errors.New("invalid flag")).Stage)
+	assert.Equal(t, 1, exitCode(errors.New("invalid flag")))
+}
+
+func TestDataPointRecordLocator(t *testing.T) {
+	metrics, err := (&pmetric.JSONUnmarshaler{}).UnmarshalMetrics(readTestData(t, "metrics.json"))
+	require.NoError(t, err)
+
+	err = applyTransformation(`set(datapoint.attributes["n"], 1 / 0)`, contextTypeDatapoint, metrics)
+	var execErr *statementExecutionError
+	require.True(t, errors.As(err, &execErr))
+	require.NotNil(t, execErr.record.DataPoint)
+	assert.Equal(t, 0, *execErr.record.DataPoint)
+}
+
+func TestWriteTransformError(t *testing.T) {
+	defer func(format string) { errorFormat = format }(errorFormat)
+	err := &stageError{stage: stageDetect, statement: -1, err: errors.New("unable to detect data type from input")}
+
+	var buf bytes.Buffer
+	errorFormat = "text"
+	writeTransformError(&buf, err)
+	assert.Equal(t, "error: unable to detect data type from input\n", buf.String())
+
+	buf.Reset()
+	errorFormat = "json"
+	writeTransformError(&buf, err)
+	var out map[string]any
+	require.NoError(t, json.Unmarshal(buf.Bytes(), &out))
+	assert.Equal(t, map[string]any{"stage": "detect", "message": "unable to detect data type from input"}, out)
+}
+
+func TestColumnsErrorStage(t *testing.T) {
+	defer func() { outputFormat, columnsFlag, errorFormat = "json", "", "text" }()
+	outputFormat, errorFormat = "table", "json"
+
+	// Columns that no context has are reported before the input is read
+	columnsFlag = "bogus"
+	err := runTransform(nil, nil)
+	var buf bytes.Buffer
+	writeTransformError(&buf, err)
+	var out map[string]any
+	require.NoError(t, json.Unmarshal(buf.Bytes(), &out))
+	assert.Equal(t, map[string]any{"stage": "detect", "message": `unknown column "bogus": it is not a field or an attribute map of any context`}, out)
+	assert.Equal(t, 3, exitCode(err))
+
+	// Columns of another context are reported once the context is detected
+	columnsFlag = "log.body"
+	err = transformFile(`set(span.name, "x")`, "rules.ottl", "../../testdata/traces.json")
+	assert.Equal(t, "detect", newJSONError(err).Stage)
+	assert.Contains(t, newJSONError(err).Message, `unknown span column "log.body"`)
+	assert.Equal(t, 3, exitCode(err))
+}
+
+func TestRecordLocatorDescribe(t *testing.T) {
+	span := ptrace.NewSpan()
+	span.SetTraceID([16]byte{0x5b, 0x8e})
+	span.SetSpanID([8]byte{0xee, 0xe1})
+	assert.Equal(t, "resource 1, scope 0, span 2 (trace_id 5b8e0000000000000000000000000000, span_id eee1000000000000)",
+		spanLocator(1, 0, 2, span).describe("span"))
+
+	logRecord := plog.NewLogRecord()
+	logRecord.Body().SetStr(strings.Repeat("x", 70))
+	locator := logLocator(0, 0, 4, logRecord)
+	assert.Equal(t, strings.Repeat("x", 60)+"…", locator.Body)
+	assert.Empty(t, locator.TraceID)
+	assert.Equal(t, "resource 0, scope 0, log 4 (body \""+locator.Body+"\")", locator.describe("log"))
+
+	metric := pmetric.NewMetric()
+	metric.SetName("http.server.duration")
+	attributes := pcommon.NewMap()
+	attributes.PutStr("method", "GET")
+	attributes.PutInt("status", 500)
+	assert.Equal(t, `resource 0, scope 1, metric 3 (name "http.server.duration"), datapoint 7 (method="GET", status="500")`,
+		dataPointLocator(0, 1, 3, metric, 7, attributes).describe("histogram datapoint"))
+}
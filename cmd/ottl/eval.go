@@ -0,0 +1,370 @@
+// Copyright 2025 Dose de Telemetria GmbH
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"context"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl/contexts/ottldatapoint"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl/contexts/ottllog"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl/contexts/ottlmetric"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl/contexts/ottlprofile"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl/contexts/ottlspan"
+	"github.com/spf13/cobra"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/plog"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.opentelemetry.io/collector/pdata/pprofile"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+
+	"github.com/telemetrydrops/ottl-cli/pkg/functions"
+)
+
+var evalCmd = &cobra.Command{
+	Use:   "eval <expression>",
+	Short: "Evaluate an OTTL value expression on every record and print the results",
+	Long: `Evaluates an OTTL value expression, such as a path or a converter call, on every
+record of the OTLP JSON data in the specified input file and prints the value it
+returns for each record. The data is not modified.
+
+With --where, only the records matching the condition are evaluated. With --count,
+the distinct values are printed with the number of records they were returned for,
+most frequent first.`,
+	Example: `  # Print the route of every span
+  ottl eval 'span.attributes["http.route"]' --input-file traces.json
+
+  # Check what a converter returns before using it in a set statement
+  ottl eval 'ExtractPatterns(log.body, "user=(?P<user>\\w+)")' -i logs.json --where 'log.severity_number >= 17'
+
+  # Count the distinct values of a resource attribute
+  ottl eval 'resource.attributes["service.name"]' -i traces.json --count`,
+	Args: cobra.ExactArgs(1),
+	RunE: runEval,
+}
+
+var evalInputFile string
+var evalContext string
+var evalSignal string
+var evalWhere string
+var evalCount bool
+
+func init() {
+	evalCmd.Flags().StringVarP(&evalInputFile, "input-file", "i", "", "Path to OTLP JSON input file (required)")
+	evalCmd.Flags().StringVar(&evalContext, "context", "", "Force specific OTTL context ("+validContexts()+")")
+	evalCmd.Flags().StringVar(&evalSignal, "signal", "", "Signal of the input, instead of detecting it (traces, logs, metrics, profiles)")
+	evalCmd.Flags().StringVar(&evalWhere, "where", "", "Only evaluate the records matching this OTTL condition")
+	evalCmd.Flags().BoolVar(&evalCount, "count", false, "Print the distinct values and how many records returned each")
+	_ = evalCmd.MarkFlagRequired("input-file")
+	rootCmd.AddCommand(evalCmd)
+}
+
+// evalResult is the value an expression returned for a record
+type evalResult struct {
+	record string
+	value  string
+}
+
+// evaluator evaluates a value expression on the records matching a condition
+type evaluator[K any] struct {
+	expression *ottl.ValueExpression[K]
+	where      *ottl.Condition[K]
+	kind       string
+	results    []evalResult
+}
+
+// newEvaluator parses the expression and the condition, which may be empty
+func newEvaluator[K any](parser ottl.Parser[K], ctx contextType, expression, where string) (*evaluator[K], error) {
+	parsed, err := parser.ParseValueExpression(expression)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse %s expression '%s': %w", ctx, expression, err)
+	}
+	e := &evaluator[K]{expression: parsed, kind: ctx.String()}
+	if where != "" {
+		e.where, err = parser.ParseCondition(where)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse %s condition '%s': %w", ctx, where, err)
+		}
+	}
+	return e, nil
+}
+
+// eval evaluates the expression on a record when it matches the condition
+func (e *evaluator[K]) eval(tCtx K, record recordLocator) error {
+	if e.where != nil {
+		matched, err := e.where.Eval(context.Background(), tCtx)
+		if err != nil {
+			return fmt.Errorf("failed to evaluate condition on %s: %w", record.describe(e.kind), err)
+		}
+		if !matched {
+			return nil
+		}
+	}
+	value, err := e.expression.Eval(context.Background(), tCtx)
+	if err != nil {
+		return fmt.Errorf("failed to evaluate expression on %s: %w", record.describe(e.kind), err)
+	}
+	e.results = append(e.results, evalResult{record: record.describe(e.kind), value: formatEvalValue(value)})
+	return nil
+}
+
+// runEval executes the eval command
+func runEval(cmd *cobra.Command, args []string) error {
+	data, err := readInputFile(evalInputFile)
+	if err != nil {
+		return fmt.Errorf("failed to read input file: %w", err)
+	}
+
+	ctx, parsedData, err := parseInput(data, evalContext, evalSignal)
+	if err != nil {
+		return err
+	}
+
+	results, err := evaluate(ctx, parsedData, args[0], evalWhere)
+	if err != nil {
+		return err
+	}
+
+	if evalCount {
+		writeEvalCounts(os.Stdout, results)
+	} else {
+		writeEvalResults(os.Stdout, results)
+	}
+	return nil
+}
+
+// evaluate evaluates an expression on the records of a context matching a condition
+func evaluate(ctx contextType, data interface{}, expression, where string) ([]evalResult, error) {
+	settings := componenttest.NewNopTelemetrySettings()
+	switch d := data.(type) {
+	case ptrace.Traces:
+		parser, err := ottlspan.NewParser(functions.Span, settings)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create span parser: %w", err)
+		}
+		e, err := newEvaluator(parser, contextTypeSpan, expression, where)
+		if err != nil {
+			return nil, err
+		}
+		err = evaluateSpans(e, d)
+		return e.results, err
+	case plog.Logs:
+		parser, err := ottllog.NewParser(functions.Log, settings)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create log parser: %w", err)
+		}
+		e, err := newEvaluator(parser, contextTypeLog, expression, where)
+		if err != nil {
+			return nil, err
+		}
+		err = evaluateLogs(e, d)
+		return e.results, err
+	case pmetric.Metrics:
+		if ctx == contextTypeDatapoint {
+			parser, err := ottldatapoint.NewParser(functions.DataPoint, settings)
+			if err != nil {
+				return nil, fmt.Errorf("failed to create datapoint parser: %w", err)
+			}
+			e, err := newEvaluator(parser, contextTypeDatapoint, expression, where)
+			if err != nil {
+				return nil, err
+			}
+			err = evaluateDataPoints(e, d)
+			return e.results, err
+		}
+		parser, err := ottlmetric.NewParser(functions.Metric, settings)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create metric parser: %w", err)
+		}
+		e, err := newEvaluator(parser, contextTypeMetric, expression, where)
+		if err != nil {
+			return nil, err
+		}
+		err = evaluateMetrics(e, d)
+		return e.results, err
+	case pprofile.Profiles:
+		parser, err := ottlprofile.NewParser(functions.Profile, settings)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create profile parser: %w", err)
+		}
+		e, err := newEvaluator(parser, contextTypeProfile, expression, where)
+		if err != nil {
+			return nil, err
+		}
+		err = evaluateProfiles(e, d)
+		return e.results, err
+	default:
+		return nil, fmt.Errorf("unsupported data type %T", data)
+	}
+}
+
+func evaluateSpans(e *evaluator[ottlspan.TransformContext], traces ptrace.Traces) error {
+	for i := 0; i < traces.ResourceSpans().Len(); i++ {
+		rs := traces.ResourceSpans().At(i)
+		for j := 0; j < rs.ScopeSpans().Len(); j++ {
+			ss := rs.ScopeSpans().At(j)
+			for k := 0; k < ss.Spans().Len(); k++ {
+				span := ss.Spans().At(k)
+				tCtx := ottlspan.NewTransformContext(span, ss.Scope(), rs.Resource(), ss, rs)
+				if err := e.eval(tCtx, spanLocator(i, j, k, span)); err != nil {
+					return err
+				}
+			}
+		}
+	}
+	return nil
+}
+
+func evaluateLogs(e *evaluator[ottllog.TransformContext], logs plog.Logs) error {
+	for i := 0; i < logs.ResourceLogs().Len(); i++ {
+		rl := logs.ResourceLogs().At(i)
+		for j := 0; j < rl.ScopeLogs().Len(); j++ {
+			sl := rl.ScopeLogs().At(j)
+			for k := 0; k < sl.LogRecords().Len(); k++ {
+				logRecord := sl.LogRecords().At(k)
+				tCtx := ottllog.NewTransformContext(logRecord, sl.Scope(), rl.Resource(), sl, rl)
+				if err := e.eval(tCtx, logLocator(i, j, k, logRecord)); err != nil {
+					return err
+				}
+			}
+		}
+	}
+	return nil
+}
+
+func evaluateMetrics(e *evaluator[ottlmetric.TransformContext], metrics pmetric.Metrics) error {
+	for i := 0; i < metrics.ResourceMetrics().Len(); i++ {
+		rm := metrics.ResourceMetrics().At(i)
+		for j := 0; j < rm.ScopeMetrics().Len(); j++ {
+			sm := rm.ScopeMetrics().At(j)
+			for k := 0; k < sm.Metrics().Len(); k++ {
+				metric := sm.Metrics().At(k)
+				tCtx := ottlmetric.NewTransformContext(metric, sm.Metrics(), sm.Scope(), rm.Resource(), sm, rm)
+				if err := e.eval(tCtx, metricLocator(i, j, k, metric)); err != nil {
+					return err
+				}
+			}
+		}
+	}
+	return nil
+}
+
+func evaluateDataPoints(e *evaluator[ottldatapoint.TransformContext], metrics pmetric.Metrics) error {
+	for i := 0; i < metrics.ResourceMetrics().Len(); i++ {
+		rm := metrics.ResourceMetrics().At(i)
+		for j := 0; j < rm.ScopeMetrics().Len(); j++ {
+			sm := rm.ScopeMetrics().At(j)
+			for k := 0; k < sm.Metrics().Len(); k++ {
+				metric := sm.Metrics().At(k)
+				err := forEachDataPoint(metric, func(l int, dp any) error {
+					tCtx := ottldatapoint.NewTransformContext(dp, metric, sm.Metrics(), sm.Scope(), rm.Resource(), sm, rm)
+					attributes, _ := dataPointAttributes(dp)
+					return e.eval(tCtx, dataPointLocator(i, j, k, metric, l, attributes))
+				})
+				if err != nil {
+					return err
+				}
+			}
+		}
+	}
+	return nil
+}
+
+func evaluateProfiles(e *evaluator[ottlprofile.TransformContext], profiles pprofile.Profiles) error {
+	dictionary := profiles.ProfilesDictionary()
+	for i := 0; i < profiles.ResourceProfiles().Len(); i++ {
+		rp := profiles.ResourceProfiles().At(i)
+		for j := 0; j < rp.ScopeProfiles().Len(); j++ {
+			sp := rp.ScopeProfiles().At(j)
+			for k := 0; k < sp.Profiles().Len(); k++ {
+				profile := sp.Profiles().At(k)
+				tCtx := ottlprofile.NewTransformContext(profile, dictionary, sp.Scope(), rp.Resource(), sp, rp)
+				if err := e.eval(tCtx, profileLocator(i, j, k, profile)); err != nil {
+					return err
+				}
+			}
+		}
+	}
+	return nil
+}
+
+// formatEvalValue prints a value as it would be written in OTTL: strings quoted, bytes and
+// IDs in hex, and maps and slices as JSON
+func formatEvalValue(value any) string {
+	switch v := value.(type) {
+	case nil:
+		return "nil"
+	case string:
+		return strconv.Quote(v)
+	case []byte:
+		return "0x" + hex.EncodeToString(v)
+	case pcommon.TraceID:
+		return "0x" + hex.EncodeToString(v[:])
+	case pcommon.SpanID:
+		return "0x" + hex.EncodeToString(v[:])
+	case pprofile.ProfileID:
+		return "0x" + hex.EncodeToString(v[:])
+	case time.Time:
+		return v.UTC().Format(time.RFC3339Nano)
+	case pcommon.Value:
+		return formatEvalValue(v.AsRaw())
+	case pcommon.Map:
+		return formatEvalJSON(v.AsRaw())
+	case pcommon.Slice:
+		return formatEvalJSON(v.AsRaw())
+	case map[string]any, []any:
+		return formatEvalJSON(v)
+	default:
+		return fmt.Sprint(v)
+	}
+}
+
+func formatEvalJSON(value any) string {
+	out, err := json.Marshal(value)
+	if err != nil {
+		return fmt.Sprint(value)
+	}
+	return string(out)
+}
+
+// writeEvalResults writes the value of each record on a line of its own
+func writeEvalResults(w io.Writer, results []evalResult) {
+	for _, result := range results {
+		fmt.Fprintf(w, "%s: %s\n", result.record, result.value)
+	}
+}
+
+// writeEvalCounts writes each distinct value with the number of records that returned it,
+// most frequent first
+func writeEvalCounts(w io.Writer, results []evalResult) {
+	counts := map[string]int{}
+	for _, result := range results {
+		counts[result.value]++
+	}
+	values := sortedKeys(counts)
+	sort.SliceStable(values, func(i, j int) bool { return counts[values[i]] > counts[values[j]] })
+	for _, value := range values {
+		fmt.Fprintf(w, "%7d  %s\n", counts[value], value)
+	}
+}
@@ -0,0 +1,146 @@
+package main
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+)
+
+func TestEvaluate(t *testing.T) {
+	tests := []struct {
+		name       string
+		file       string
+		ctx        contextType
+		expression string
+		where      string
+		expected   []evalResult
+	}{
+		{
+			name:       "span attribute",
+			file:       "traces.json",
+			ctx:        contextTypeSpan,
+			expression: `span.attributes["http.method"]`,
+			expected: []evalResult{
+				{record: "resource 0, scope 0, span 0 (trace_id 0123456789abcdef0123456789abcdef, span_id 0123456789abcdef)", value: `"GET"`},
+			},
+		},
+		{
+			name:       "converter with where",
+			file:       "logs.json",
+			ctx:        contextTypeLog,
+			expression: `ExtractPatterns(log.body, "^(?P<first>\\w+)")`,
+			where:      "log.severity_number >= 17",
+			expected: []evalResult{
+				{record: `resource 0, scope 0, log 1 (body "An error occurred")`, value: `{"first":"An"}`},
+			},
+		},
+		{
+			name:       "metric",
+			file:       "metrics.json",
+			ctx:        contextTypeMetric,
+			expression: "metric.unit",
+			where:      `metric.name == "cpu_usage_percent"`,
+			expected: []evalResult{
+				{record: `resource 0, scope 0, metric 1 (name "cpu_usage_percent")`, value: `"%"`},
+			},
+		},
+		{
+			name:       "datapoint",
+			file:       "metrics.json",
+			ctx:        contextTypeDatapoint,
+			expression: "datapoint.value_double",
+			where:      `metric.type == METRIC_DATA_TYPE_GAUGE`,
+			expected: []evalResult{
+				{record: `resource 0, scope 0, metric 1 (name "cpu_usage_percent"), datapoint 0`, value: "75.5"},
+			},
+		},
+		{
+			name:       "profile attribute",
+			file:       "profiles.json",
+			ctx:        contextTypeProfile,
+			expression: `profile.attributes["thread.name"]`,
+			expected: []evalResult{
+				{record: "resource 0, scope 0, profile 0 (profile_id 0102030405060708090a0b0c0d0e0f10)", value: `"main"`},
+			},
+		},
+		{
+			name:       "no record matches",
+			file:       "traces.json",
+			ctx:        contextTypeSpan,
+			expression: "span.name",
+			where:      `span.name == "missing"`,
+			expected:   nil,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, data, err := parseInput(readTestData(t, tt.file), tt.ctx.String(), "")
+			require.NoError(t, err)
+
+			results, err := evaluate(tt.ctx, data, tt.expression, tt.where)
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, results)
+		})
+	}
+}
+
+func TestEvaluateErrors(t *testing.T) {
+	_, data, err := parseInput(readTestData(t, "traces.json"), "span", "")
+	require.NoError(t, err)
+
+	_, err = evaluate(contextTypeSpan, data, `set(span.name, "x")`, "")
+	assert.ErrorContains(t, err, "failed to parse span expression")
+
+	_, err = evaluate(contextTypeSpan, data, "span.name", "span.name ==")
+	assert.ErrorContains(t, err, "failed to parse span condition")
+}
+
+func TestFormatEvalValue(t *testing.T) {
+	m := pcommon.NewMap()
+	m.PutStr("a", "b")
+	s := pcommon.NewSlice()
+	s.AppendEmpty().SetInt(1)
+
+	tests := []struct {
+		name     string
+		value    any
+		expected string
+	}{
+		{name: "nil", value: nil, expected: "nil"},
+		{name: "string", value: "a \"b\"", expected: `"a \"b\""`},
+		{name: "int", value: int64(42), expected: "42"},
+		{name: "double", value: 1.5, expected: "1.5"},
+		{name: "bool", value: true, expected: "true"},
+		{name: "bytes", value: []byte{0x01, 0xab}, expected: "0x01ab"},
+		{name: "span id", value: pcommon.SpanID([8]byte{1, 2, 3, 4, 5, 6, 7, 8}), expected: "0x0102030405060708"},
+		{name: "time", value: time.Unix(1, 500).In(time.FixedZone("CET", 3600)), expected: "1970-01-01T00:00:01.0000005Z"},
+		{name: "map", value: m, expected: `{"a":"b"}`},
+		{name: "slice", value: s, expected: "[1]"},
+		{name: "value", value: pcommon.NewValueStr("x"), expected: `"x"`},
+		{name: "raw map", value: map[string]any{"n": 1}, expected: `{"n":1}`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, formatEvalValue(tt.value))
+		})
+	}
+}
+
+func TestWriteEvalCounts(t *testing.T) {
+	results := []evalResult{
+		{record: "span 0", value: `"b"`},
+		{record: "span 1", value: `"a"`},
+		{record: "span 2", value: `"b"`},
+		{record: "span 3", value: "nil"},
+	}
+
+	var out bytes.Buffer
+	writeEvalCounts(&out, results)
+	assert.Equal(t, "      2  \"b\"\n      1  \"a\"\n      1  nil\n", out.String())
+}
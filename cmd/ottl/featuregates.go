@@ -0,0 +1,31 @@
+// Copyright 2025 Dose de Telemetria GmbH
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"flag"
+
+	"go.opentelemetry.io/collector/featuregate"
+)
+
+func init() {
+	// Register --feature-gates exactly like the collector does, so the same
+	// value (e.g. "+gate.one,-gate.two") can be copied from a collector command line.
+	// Gates are applied to the global registry while flags are parsed, before any
+	// OTTL statement is parsed.
+	goFlags := flag.NewFlagSet("feature-gates", flag.ContinueOnError)
+	featuregate.GlobalRegistry().RegisterFlags(goFlags)
+	rootCmd.PersistentFlags().AddGoFlagSet(goFlags)
+}
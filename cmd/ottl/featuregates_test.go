@@ -0,0 +1,31 @@
+package main
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/featuregate"
+)
+
+var testFeatureGate = featuregate.GlobalRegistry().MustRegister(
+	"ottl.cli.test",
+	featuregate.StageAlpha,
+	featuregate.WithRegisterDescription("Feature gate used by ottl CLI tests"),
+)
+
+func TestFeatureGatesFlag(t *testing.T) {
+	flag := rootCmd.PersistentFlags().Lookup("feature-gates")
+	require.NotNil(t, flag, "expected --feature-gates to be registered on the root command")
+
+	defer func() { _ = featuregate.GlobalRegistry().Set(testFeatureGate.ID(), false) }()
+
+	require.NoError(t, flag.Value.Set("+ottl.cli.test"))
+	assert.True(t, testFeatureGate.IsEnabled())
+	assert.Contains(t, enabledFeatureGates(featuregate.GlobalRegistry()), "ottl.cli.test")
+
+	require.NoError(t, flag.Value.Set("-ottl.cli.test"))
+	assert.False(t, testFeatureGate.IsEnabled())
+
+	assert.Error(t, flag.Value.Set("no.such.gate"))
+}
@@ -0,0 +1,767 @@
+// Copyright 2025 Dose de Telemetria GmbH
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"sort"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"gopkg.in/yaml.v3"
+)
+
+var fmtCmd = &cobra.Command{
+	Use:   "fmt [path...]",
+	Short: "Format OTTL statements in a canonical style",
+	Long: `Re-prints OTTL statements in a canonical style: single spaces around operators
+and keywords, no spaces inside brackets, and long statements wrapped before
+"where" and before the "and" and "or" of their condition.
+
+Paths are .ottl files, collector config YAML files, or directories containing
+them. Files are rewritten in place; in YAML files, only the statements and
+conditions of transform and filter processors change. Without paths, statements
+are read from stdin and the formatted result is written to stdout.`,
+	Example: `  # Format files in place
+  ottl fmt rules.ottl collector.yaml
+
+  # Fail in CI when a file is not formatted
+  ottl fmt --check configs/
+
+  # Format a statement
+  echo 'set(attributes["env"],"dev")where name=="a"' | ottl fmt`,
+	RunE: runFmt,
+}
+
+var fmtCheck bool
+
+func init() {
+	fmtCmd.Flags().BoolVar(&fmtCheck, "check", false, "List files that are not formatted and fail instead of rewriting them")
+	rootCmd.AddCommand(fmtCmd)
+}
+
+// fmtLineWidth is the length above which statements are wrapped
+const fmtLineWidth = 100
+
+// fmtIndent indents the continuation lines of wrapped statements
+const fmtIndent = "    "
+
+// runFmt executes the fmt command
+func runFmt(cmd *cobra.Command, args []string) error {
+	if len(args) == 0 {
+		source, err := io.ReadAll(os.Stdin)
+		if err != nil {
+			return fmt.Errorf("failed to read stdin: %w", err)
+		}
+		formatted, err := formatOTTLSource(string(source))
+		if err != nil {
+			return err
+		}
+		if fmtCheck && formatted != string(source) {
+			cmd.SilenceUsage = true
+			return errors.New("stdin is not formatted")
+		}
+		_, err = fmt.Print(formatted)
+		return err
+	}
+
+	files, err := collectStatementFiles(args)
+	if err != nil {
+		return err
+	}
+
+	var unformatted []string
+	for _, filename := range files {
+		changed, err := formatFile(filename, !fmtCheck)
+		if err != nil {
+			return fmt.Errorf("%s: %w", filename, err)
+		}
+		if changed {
+			unformatted = append(unformatted, filename)
+			fmt.Println(filename)
+		}
+	}
+
+	if fmtCheck && len(unformatted) > 0 {
+		cmd.SilenceUsage = true
+		return fmt.Errorf("%d file(s) are not formatted", len(unformatted))
+	}
+	return nil
+}
+
+// collectStatementFiles expands directories into the .ottl and YAML files they contain
+func collectStatementFiles(paths []string) ([]string, error) {
+	var files []string
+	for _, path := range paths {
+		info, err := os.Stat(path)
+		if err != nil {
+			return nil, err
+		}
+		if !info.IsDir() {
+			files = append(files, path)
+			continue
+		}
+		err = filepath.WalkDir(path, func(p string, d os.DirEntry, err error) error {
+			if err != nil {
+				return err
+			}
+			if !d.IsDir() && isStatementFile(p) {
+				files = append(files, p)
+			}
+			return nil
+		})
+		if err != nil {
+			return nil, err
+		}
+	}
+	return files, nil
+}
+
+// isStatementFile reports whether a file found in a directory may hold statements
+func isStatementFile(filename string) bool {
+	switch filepath.Ext(filename) {
+	case ".ottl", ".yaml", ".yml":
+		return true
+	default:
+		return false
+	}
+}
+
+// formatFile formats a .ottl or YAML file, rewriting it when write is set, and reports whether it changed
+func formatFile(filename string, write bool) (bool, error) {
+	source, err := readInputFile(filename)
+	if err != nil {
+		return false, err
+	}
+
+	var formatted []byte
+	switch filepath.Ext(filename) {
+	case ".yaml", ".yml":
+		formatted, err = formatCollectorConfig(source)
+	default:
+		var text string
+		text, err = formatOTTLSource(string(source))
+		formatted = []byte(text)
+	}
+	if err != nil {
+		return false, err
+	}
+
+	if bytes.Equal(source, formatted) {
+		return false, nil
+	}
+	if write {
+		info, err := os.Stat(filename)
+		if err != nil {
+			return false, err
+		}
+		if err := os.WriteFile(filename, formatted, info.Mode().Perm()); err != nil {
+			return false, fmt.Errorf("failed to write file: %w", err)
+		}
+	}
+	return true, nil
+}
+
+// formatOTTLSource formats the statements of a .ottl file, keeping comments and
+// collapsing runs of blank lines
+func formatOTTLSource(source string) (string, error) {
+	var out strings.Builder
+	var pending []string  // lines of the statement being read
+	var comments []string // comments between the lines of that statement
+	pendingLine := 0
+	blank := false
+
+	flush := func() error {
+		if len(pending) == 0 {
+			return nil
+		}
+		statement := strings.Join(pending, "\n")
+		formatted, err := formatStatement(statement, true)
+		if err == nil {
+			err = checkParses(statement, statementContexts(statement), false)
+		}
+		if err != nil {
+			return fmt.Errorf("line %d: %w", pendingLine+1, err)
+		}
+		for _, comment := range comments {
+			out.WriteString(comment + "\n")
+		}
+		out.WriteString(formatted + "\n")
+		pending, comments = nil, nil
+		return nil
+	}
+
+	for i, line := range strings.Split(source, "\n") {
+		line = strings.TrimRight(line, " \t\r")
+		trimmed := strings.TrimSpace(line)
+		switch {
+		case len(pending) > 0 && statementOpen(strings.Join(pending, "\n")):
+			pending = append(pending, line)
+		case trimmed == "":
+			if err := flush(); err != nil {
+				return "", err
+			}
+			blank = out.Len() > 0
+		case len(pending) > 0 && (line[0] == ' ' || line[0] == '\t'):
+			if strings.HasPrefix(trimmed, "#") {
+				comments = append(comments, trimmed)
+			} else {
+				pending = append(pending, line)
+			}
+		default:
+			if err := flush(); err != nil {
+				return "", err
+			}
+			if blank {
+				out.WriteString("\n")
+				blank = false
+			}
+			if strings.HasPrefix(trimmed, "#") {
+				out.WriteString(trimmed + "\n")
+			} else {
+				pending, pendingLine = []string{line}, i
+			}
+		}
+	}
+	if err := flush(); err != nil {
+		return "", err
+	}
+	return out.String(), nil
+}
+
+// ottlToken is a lexical token of a statement
+type ottlToken struct {
+	kind   string
+	text   string
+	offset int // byte offset in the statement
+}
+
+// Token kinds, following the lexer of the OTTL grammar. Words join the uppercase
+// and lowercase pieces the OTTL lexer splits identifiers such as IsMatch into.
+const (
+	tokenWord    = "word"
+	tokenString  = "string"
+	tokenNumber  = "number"
+	tokenBytes   = "bytes"
+	tokenOp      = "op"
+	tokenKeyword = "keyword"
+	tokenPunct   = "punct"
+)
+
+var ottlTokenRules = []struct {
+	kind    string
+	pattern *regexp.Regexp
+}{
+	{kind: tokenBytes, pattern: regexp.MustCompile(`^0x[a-fA-F0-9]+`)},
+	{kind: tokenNumber, pattern: regexp.MustCompile(`^[-+]?\d*\.\d+([eE][-+]?\d+)?`)},
+	{kind: tokenNumber, pattern: regexp.MustCompile(`^[-+]?\d+`)},
+	{kind: tokenString, pattern: regexp.MustCompile(`^"(\\.|[^\\"])*"`)},
+	{kind: tokenOp, pattern: regexp.MustCompile(`^(==|!=|>=|<=|>|<|\+|-|/|\*)`)},
+	{kind: tokenPunct, pattern: regexp.MustCompile(`^[=(){}:,.\[\]]`)},
+	{kind: tokenWord, pattern: regexp.MustCompile(`^[A-Za-z][A-Za-z0-9_]*`)},
+}
+
+var ottlKeywords = map[string]bool{"where": true, "and": true, "or": true, "not": true}
+
+// tokenizeStatement splits a statement into tokens, dropping whitespace
+func tokenizeStatement(statement string) ([]ottlToken, error) {
+	var tokens []ottlToken
+	rest := statement
+	for {
+		rest = strings.TrimLeft(rest, " \t\r\n")
+		if rest == "" {
+			return tokens, nil
+		}
+		matched := false
+		for _, rule := range ottlTokenRules {
+			if text := rule.pattern.FindString(rest); text != "" {
+				kind := rule.kind
+				if kind == tokenWord && ottlKeywords[text] {
+					kind = tokenKeyword
+				}
+				tokens = append(tokens, ottlToken{kind: kind, text: text, offset: len(statement) - len(rest)})
+				rest = rest[len(text):]
+				matched = true
+				break
+			}
+		}
+		if !matched {
+			if strings.HasPrefix(rest, `"`) {
+				return nil, errors.New("unterminated string")
+			}
+			return nil, fmt.Errorf("unexpected character %q", []rune(rest)[0])
+		}
+	}
+}
+
+// formatStatement returns the canonical form of a statement or condition, wrapped
+// when it is too long for one line and wrap is set
+func formatStatement(statement string, wrap bool) (string, error) {
+	tokens, err := tokenizeStatement(statement)
+	if err != nil {
+		return "", err
+	}
+	if len(tokens) == 0 {
+		return "", errors.New("empty statement")
+	}
+	if err := checkBrackets(tokens); err != nil {
+		return "", err
+	}
+
+	// Tokens at bracket depth 0 where a wrapped statement may break
+	var where int
+	var breaks []int
+	depth := 0
+	for i, token := range tokens {
+		switch token.text {
+		case "(", "[", "{":
+			depth++
+		case ")", "]", "}":
+			depth--
+		}
+		if depth == 0 && token.kind == tokenKeyword && i > 0 {
+			switch token.text {
+			case "where":
+				where = i
+				breaks = nil
+			case "and", "or":
+				breaks = append(breaks, i)
+			}
+		}
+	}
+
+	line := joinTokens(tokens)
+	if relexed, err := tokenizeStatement(line); err != nil || !slices.EqualFunc(relexed, tokens, sameToken) {
+		return "", errors.New("cannot format the statement without changing its tokens")
+	}
+	if !wrap || len(line) <= fmtLineWidth {
+		return line, nil
+	}
+
+	// Wrap before where, then before the and/or of the condition when it is still too long
+	var lines []string
+	start := 0
+	if where > 0 {
+		lines = append(lines, joinTokens(tokens[:where]))
+		start = where
+	}
+	rest := joinTokens(tokens[start:])
+	if len(fmtIndent)+len(rest) <= fmtLineWidth || len(breaks) == 0 {
+		lines = append(lines, rest)
+	} else {
+		for _, b := range breaks {
+			lines = append(lines, joinTokens(tokens[start:b]))
+			start = b
+		}
+		lines = append(lines, joinTokens(tokens[start:]))
+	}
+	for i := 1; i < len(lines); i++ {
+		lines[i] = fmtIndent + lines[i]
+	}
+	return strings.Join(lines, "\n"), nil
+}
+
+// sameToken reports whether two tokens are equal wherever they appear
+func sameToken(a, b ottlToken) bool {
+	return a.kind == b.kind && a.text == b.text
+}
+
+// checkBrackets reports unbalanced parentheses, brackets and braces
+func checkBrackets(tokens []ottlToken) error {
+	closing := map[string]string{")": "(", "]": "[", "}": "{"}
+	var stack []string
+	for _, token := range tokens {
+		if token.kind != tokenPunct {
+			continue
+		}
+		switch token.text {
+		case "(", "[", "{":
+			stack = append(stack, token.text)
+		case ")", "]", "}":
+			if len(stack) == 0 || stack[len(stack)-1] != closing[token.text] {
+				return fmt.Errorf("unexpected %q", token.text)
+			}
+			stack = stack[:len(stack)-1]
+		}
+	}
+	if len(stack) > 0 {
+		return fmt.Errorf("unclosed %q", stack[len(stack)-1])
+	}
+	return nil
+}
+
+// joinTokens prints tokens on one line with canonical spacing
+func joinTokens(tokens []ottlToken) string {
+	var b strings.Builder
+	for i, token := range tokens {
+		if i > 0 && spaceBetween(tokens[i-1], token) {
+			b.WriteByte(' ')
+		}
+		b.WriteString(token.text)
+	}
+	return b.String()
+}
+
+// spaceBetween reports whether canonical style separates two adjacent tokens
+func spaceBetween(prev, next ottlToken) bool {
+	switch {
+	case prev.text == "(" || prev.text == "[" || prev.text == "{" || prev.text == ".":
+		return false
+	case next.text == ")" || next.text == "]" || next.text == "}" || next.text == "," || next.text == "." || next.text == ":":
+		return false
+	case prev.text == "=" || next.text == "=": // named arguments
+		return false
+	case next.text == "(":
+		return prev.kind != tokenWord
+	case next.text == "[":
+		return prev.kind != tokenWord && prev.text != ")" && prev.text != "]"
+	default:
+		return true
+	}
+}
+
+// statementListKeys are the transform processor settings holding statement groups
+var statementListKeys = map[string]bool{"trace_statements": true, "metric_statements": true, "log_statements": true}
+
+// statementScalar is a YAML scalar holding a statement or condition
+type statementScalar struct {
+	node *yaml.Node
+	flow bool // item of a flow sequence, which cannot hold block scalars
+}
+
+// formatCollectorConfig formats the statements and conditions of the transform and filter
+// processors of a collector config, or of a standalone transform processor config. Only
+// the scalars holding statements are rewritten; the rest of the file is left as it is.
+func formatCollectorConfig(source []byte) ([]byte, error) {
+	var root yaml.Node
+	if err := yaml.Unmarshal(source, &root); err != nil {
+		return nil, fmt.Errorf("invalid YAML: %w", err)
+	}
+	if len(root.Content) == 0 {
+		return source, nil
+	}
+
+	lines := strings.SplitAfter(string(source), "\n")
+	var edits []textEdit
+	for _, group := range collectorStatementGroups(root.Content[0]) {
+		// Conditions first, then statements
+		for i, scalars := range [][]statementScalar{group.conditions, group.statements} {
+			for _, scalar := range scalars {
+				formatted, err := formatStatement(scalar.node.Value, !scalar.flow)
+				if err == nil {
+					err = checkParses(scalar.node.Value, groupContexts(group, scalar.node.Value), i == 0)
+				}
+				if err != nil {
+					return nil, fmt.Errorf("line %d: %w", scalar.node.Line, err)
+				}
+				if edit, ok := scalarEdit(lines, scalar, formatted); ok {
+					edits = append(edits, edit)
+				}
+			}
+		}
+	}
+	return applyTextEdits(source, edits), nil
+}
+
+// groupContexts returns the contexts a statement or condition of a collector config group is
+// parsed for. Groups of contexts the CLI cannot transform, such as spanevent, are not parsed.
+func groupContexts(group statementGroup, text string) []contextType {
+	if group.context == "" {
+		return statementContexts(text)
+	}
+	if ctx := parseContextFlag(group.context); ctx != contextTypeUnknown {
+		return []contextType{ctx}
+	}
+	return nil
+}
+
+// checkParses parses a statement or condition for its possible contexts, so fmt does not
+// rewrite text the OTTL parser rejects. The error is the one of the most likely context.
+func checkParses(text string, contexts []contextType, condition bool) error {
+	var firstErr error
+	for _, ctx := range contexts {
+		var err error
+		if condition {
+			_, err = evaluate(ctx, emptyData(ctx), "true", text)
+		} else {
+			err = applyTransformation(text, ctx, emptyData(ctx))
+		}
+		if err == nil {
+			return nil
+		}
+		if firstErr == nil {
+			firstErr = errors.New(parseErrorMessage(ctx, text, err))
+		}
+	}
+	return firstErr
+}
+
+// mappingValue returns the value of a key in a mapping node
+func mappingValue(node *yaml.Node, key string) *yaml.Node {
+	if node == nil || node.Kind != yaml.MappingNode {
+		return nil
+	}
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		if node.Content[i].Value == key {
+			return node.Content[i+1]
+		}
+	}
+	return nil
+}
+
+// sequenceScalars returns the scalar items of a sequence node
+func sequenceScalars(node *yaml.Node) []statementScalar {
+	if node == nil || node.Kind != yaml.SequenceNode {
+		return nil
+	}
+	var scalars []statementScalar
+	for _, item := range node.Content {
+		if item.Kind == yaml.ScalarNode {
+			scalars = append(scalars, statementScalar{node: item, flow: node.Style&yaml.FlowStyle != 0})
+		}
+	}
+	return scalars
+}
+
+// statementGroup holds the statements and conditions of a collector config that run together
+type statementGroup struct {
+	processor  string
+	node       *yaml.Node // sequence item or setting holding the group, whose comments apply to it
+	context    string     // empty when the context is inferred from the paths
+	conditions []statementScalar
+	statements []statementScalar
+}
+
+// filterContexts maps the settings of a filter processor section to the context of their conditions
+var filterContexts = map[string]string{
+	"span":       "span",
+	"spanevent":  "spanevent",
+	"metric":     "metric",
+	"datapoint":  "datapoint",
+	"log_record": "log",
+}
+
+// collectorStatementGroups returns the statement groups of the transform and filter processors
+// of a collector config, or of a standalone transform processor config
+func collectorStatementGroups(document *yaml.Node) []statementGroup {
+	processors := mappingValue(document, "processors")
+	if processors == nil {
+		return transformConfigGroups("transform", document)
+	}
+	var groups []statementGroup
+	for i := 0; i+1 < len(processors.Content); i += 2 {
+		id := processors.Content[i].Value
+		switch componentType(id) {
+		case "transform":
+			groups = append(groups, transformConfigGroups(id, processors.Content[i+1])...)
+		case "filter":
+			groups = append(groups, filterConfigGroups(id, processors.Content[i+1])...)
+		}
+	}
+	return groups
+}
+
+// transformConfigGroups returns the statement groups of a transform processor config. The
+// statements of a basic config form one group per list, since they run in order.
+func transformConfigGroups(id string, config *yaml.Node) []statementGroup {
+	if config == nil || config.Kind != yaml.MappingNode {
+		return nil
+	}
+	var groups []statementGroup
+	for i := 0; i+1 < len(config.Content); i += 2 {
+		list := config.Content[i+1]
+		if !statementListKeys[config.Content[i].Value] || list.Kind != yaml.SequenceNode {
+			continue
+		}
+		if scalars := sequenceScalars(list); len(scalars) > 0 {
+			groups = append(groups, statementGroup{processor: id, statements: scalars})
+		}
+		for _, item := range list.Content {
+			if item.Kind != yaml.MappingNode {
+				continue
+			}
+			group := statementGroup{
+				processor:  id,
+				node:       item,
+				conditions: sequenceScalars(mappingValue(item, "conditions")),
+				statements: sequenceScalars(mappingValue(item, "statements")),
+			}
+			if context := mappingValue(item, "context"); context != nil {
+				group.context = context.Value
+			}
+			groups = append(groups, group)
+		}
+	}
+	return groups
+}
+
+// filterConfigGroups returns the conditions of a filter processor config, one group per setting
+func filterConfigGroups(id string, config *yaml.Node) []statementGroup {
+	var groups []statementGroup
+	for _, signal := range []string{"traces", "metrics", "logs"} {
+		section := mappingValue(config, signal)
+		if section == nil || section.Kind != yaml.MappingNode {
+			continue
+		}
+		for i := 0; i+1 < len(section.Content); i += 2 {
+			groups = append(groups, statementGroup{
+				processor:  id,
+				node:       section.Content[i],
+				context:    filterContexts[section.Content[i].Value],
+				conditions: sequenceScalars(section.Content[i+1]),
+			})
+		}
+	}
+	return groups
+}
+
+// textEdit replaces the source between two byte offsets
+type textEdit struct {
+	start, end int
+	text       string
+}
+
+// scalarEdit returns the edit replacing a statement scalar with its formatted form. Short
+// statements are written as plain scalars when YAML allows it and single-quoted otherwise;
+// wrapped statements become literal block scalars. Quoted and plain scalars spanning
+// several lines are left unchanged.
+func scalarEdit(lines []string, scalar statementScalar, formatted string) (textEdit, bool) {
+	node := scalar.node
+	lineIndex := node.Line - 1
+	if lineIndex < 0 || lineIndex >= len(lines) {
+		return textEdit{}, false
+	}
+	offset := 0
+	for _, l := range lines[:lineIndex] {
+		offset += len(l)
+	}
+	line := strings.TrimRight(lines[lineIndex], "\r\n")
+	runes := []rune(line)
+	if node.Column-1 > len(runes) {
+		return textEdit{}, false
+	}
+	start := len(string(runes[:node.Column-1]))
+	indent := len(line) - len(strings.TrimLeft(line, " "))
+
+	// The end of the scalar on its line, or after its last line for block scalars
+	end := -1
+	comment := "" // comment after a block scalar header
+	switch {
+	case node.Style&yaml.DoubleQuotedStyle != 0:
+		end = quotedScalarEnd(line, start, '"')
+	case node.Style&yaml.SingleQuotedStyle != 0:
+		end = quotedScalarEnd(line, start, '\'')
+	case node.Style&(yaml.LiteralStyle|yaml.FoldedStyle) != 0:
+		last := lineIndex
+		for i := lineIndex + 1; i < len(lines); i++ {
+			content := strings.TrimRight(lines[i], "\r\n")
+			if strings.TrimSpace(content) == "" {
+				continue
+			}
+			if len(content)-len(strings.TrimLeft(content, " ")) <= indent {
+				break
+			}
+			last = i
+		}
+		if last == lineIndex {
+			return textEdit{}, false
+		}
+		blockEnd := 0
+		for _, l := range lines[lineIndex:last] {
+			blockEnd += len(l)
+		}
+		end = blockEnd + len(strings.TrimRight(lines[last], "\r\n"))
+		if i := strings.Index(line[start:], "#"); i >= 0 {
+			comment = " " + line[start+i:]
+		}
+	case strings.HasPrefix(line[start:], node.Value) && !strings.Contains(node.Value, "\n"):
+		end = start + len(node.Value)
+	}
+	if end < 0 {
+		return textEdit{}, false
+	}
+
+	text := inlineScalar(formatted, scalar.flow)
+	if strings.Contains(formatted, "\n") {
+		header := "|-"
+		if node.Style&(yaml.LiteralStyle|yaml.FoldedStyle) == 0 {
+			// Comments after a single-line scalar move to the block header
+			if rest := strings.TrimSpace(line[end:]); rest != "" {
+				header += " " + rest
+				end = len(line)
+			}
+		}
+		header += comment
+		comment = ""
+		var b strings.Builder
+		b.WriteString(header)
+		for _, l := range strings.Split(formatted, "\n") {
+			b.WriteString("\n" + strings.Repeat(" ", indent+2) + l)
+		}
+		text = b.String()
+	}
+	return textEdit{start: offset + start, end: offset + end, text: text + comment}, true
+}
+
+// quotedScalarEnd returns the offset after the closing quote of a scalar starting at start,
+// or -1 when the scalar continues on the next line
+func quotedScalarEnd(line string, start int, quote byte) int {
+	if start >= len(line) || line[start] != quote {
+		return -1
+	}
+	for i := start + 1; i < len(line); i++ {
+		switch {
+		case quote == '"' && line[i] == '\\':
+			i++
+		case line[i] == quote && quote == '\'' && i+1 < len(line) && line[i+1] == '\'':
+			i++
+		case line[i] == quote:
+			return i + 1
+		}
+	}
+	return -1
+}
+
+// inlineScalar writes a single-line statement as a plain YAML scalar when that is
+// unambiguous, and as a single-quoted scalar otherwise
+func inlineScalar(statement string, flow bool) string {
+	sequence := &yaml.Node{Kind: yaml.SequenceNode, Content: []*yaml.Node{{Kind: yaml.ScalarNode, Value: statement}}}
+	expected := "- " + statement + "\n"
+	if flow {
+		sequence.Style = yaml.FlowStyle
+		expected = "[" + statement + "]\n"
+	}
+	if encoded, err := yaml.Marshal(sequence); err == nil && string(encoded) == expected {
+		return statement
+	}
+	return "'" + strings.ReplaceAll(statement, "'", "''") + "'"
+}
+
+// applyTextEdits applies non-overlapping edits to the source
+func applyTextEdits(source []byte, edits []textEdit) []byte {
+	sort.Slice(edits, func(i, j int) bool { return edits[i].start > edits[j].start })
+	result := string(source)
+	for _, edit := range edits {
+		result = result[:edit.start] + edit.text + result[edit.end:]
+	}
+	return []byte(result)
+}
@@ -0,0 +1,233 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFormatStatement(t *testing.T) {
+	tests := []struct {
+		name        string
+		statement   string
+		expected    string
+		shouldError bool
+	}{
+		{
+			name:      "spacing",
+			statement: `set( attributes[ "env" ],"dev" )where name=="a"and not(kind>1)`,
+			expected:  `set(attributes["env"], "dev") where name == "a" and not (kind > 1)`,
+		},
+		{
+			name:      "literals and named arguments",
+			statement: `set(cache["m"],{"a":[1,-2.5],"b":0x0a}) where Split(name,",")[0]!=nil`,
+			expected:  `set(cache["m"], {"a": [1, -2.5], "b": 0x0a}) where Split(name, ",")[0] != nil`,
+		},
+		{
+			name:      "operators",
+			statement: `set(attributes["n"], -1*2 - -3+x/4)`,
+			expected:  `set(attributes["n"], -1 * 2 - -3 + x / 4)`,
+		},
+		{
+			name:      "named arguments",
+			statement: `replace_pattern(name, "a", "b", replacementFormat = "x-%s")`,
+			expected:  `replace_pattern(name, "a", "b", replacementFormat="x-%s")`,
+		},
+		{
+			name:      "strings are kept",
+			statement: `set(name, "a  ,b\"(")`,
+			expected:  `set(name, "a  ,b\"(")`,
+		},
+		{
+			name:      "long where clause",
+			statement: `set(span.attributes["http.route"], "unknown") where span.attributes["http.route"] == nil and span.kind == 2`,
+			expected: "set(span.attributes[\"http.route\"], \"unknown\")\n" +
+				"    where span.attributes[\"http.route\"] == nil and span.kind == 2",
+		},
+		{
+			name: "long condition",
+			statement: `set(span.attributes["http.route"], "unknown") where span.attributes["http.route"] == nil and span.kind == SPAN_KIND_SERVER ` +
+				`or (span.name == "a" and span.name == "b")`,
+			expected: "set(span.attributes[\"http.route\"], \"unknown\")\n" +
+				"    where span.attributes[\"http.route\"] == nil\n" +
+				"    and span.kind == SPAN_KIND_SERVER\n" +
+				"    or (span.name == \"a\" and span.name == \"b\")",
+		},
+		{name: "unterminated string", statement: `set(name, "a)`, shouldError: true},
+		{name: "unbalanced brackets", statement: `set(attributes["a"), 1)`, shouldError: true},
+		{name: "unexpected character", statement: `set(name, 'a')`, shouldError: true},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			formatted, err := formatStatement(test.statement, true)
+			if test.shouldError {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, test.expected, formatted)
+
+			again, err := formatStatement(formatted, true)
+			require.NoError(t, err)
+			assert.Equal(t, formatted, again, "formatting is idempotent")
+		})
+	}
+
+	long := `set(span.attributes["http.route"], "unknown") where span.attributes["http.route"] == nil and span.kind == 2`
+	formatted, err := formatStatement(long, false)
+	require.NoError(t, err)
+	assert.Equal(t, long, formatted)
+}
+
+func TestFormatOTTLSource(t *testing.T) {
+	source := "# routes\n" +
+		"set(span.name,\"a\")\n" +
+		"set(span.attributes[\"http.route\"], \"unknown\")\n" +
+		"  # only servers\n" +
+		"  where span.kind==SPAN_KIND_SERVER  \n" +
+		"\n\n\n" +
+		"# drop\n" +
+		"delete_key(span.attributes,\"x\")"
+
+	formatted, err := formatOTTLSource(source)
+	require.NoError(t, err)
+	assert.Equal(t, "# routes\n"+
+		"set(span.name, \"a\")\n"+
+		"# only servers\n"+
+		"set(span.attributes[\"http.route\"], \"unknown\") where span.kind == SPAN_KIND_SERVER\n"+
+		"\n"+
+		"# drop\n"+
+		"delete_key(span.attributes, \"x\")\n", formatted)
+
+	again, err := formatOTTLSource(formatted)
+	require.NoError(t, err)
+	assert.Equal(t, formatted, again)
+
+	_, err = formatOTTLSource("set(span.name, \"a\")\n\nset(span.name, \"a)\n")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "line 3")
+
+	wrapped, err := formatOTTLSource("set(span.attributes[\"a\"],\n\"b\")\n")
+	require.NoError(t, err)
+	assert.Equal(t, "set(span.attributes[\"a\"], \"b\")\n", wrapped)
+
+	// Statements the OTTL parser rejects are reported rather than rewritten
+	_, err = formatOTTLSource("foo bar baz\n")
+	assert.ErrorContains(t, err, "line 1: ")
+	_, err = formatOTTLSource("set(span.name, \"a\")\nset(span.nme,\"b\")\n")
+	assert.ErrorContains(t, err, `line 2: `)
+	assert.ErrorContains(t, err, `did you mean "span.name"?`)
+}
+
+const fmtTestConfig = `processors:
+  transform:
+    trace_statements:
+      - context: span
+        conditions: ['span.name=="x"', 'IsMatch(span.name,"a")']
+        statements:
+          - set(span.attributes["env"],"dev")   # keep
+          - "set(span.attributes[\"x\"], \"y\")"
+          - 'set(span.attributes["http.route"], "unknown") where span.attributes["http.route"] == nil and span.kind == SPAN_KIND_SERVER' # long
+          - |-
+            set(span.name, "a")
+                where span.name ==   "b"
+    log_statements:
+      - set(log.attributes["a"],1)
+  filter/x:
+    logs:
+      log_record:
+        - 'severity_number<SEVERITY_NUMBER_WARN'
+  attributes:
+    actions:
+      - key: "a"
+        action: delete
+`
+
+func TestFormatCollectorConfig(t *testing.T) {
+	formatted, err := formatCollectorConfig([]byte(fmtTestConfig))
+	require.NoError(t, err)
+	assert.Equal(t, `processors:
+  transform:
+    trace_statements:
+      - context: span
+        conditions: [span.name == "x", 'IsMatch(span.name, "a")']
+        statements:
+          - set(span.attributes["env"], "dev")   # keep
+          - set(span.attributes["x"], "y")
+          - |- # long
+            set(span.attributes["http.route"], "unknown")
+                where span.attributes["http.route"] == nil and span.kind == SPAN_KIND_SERVER
+          - set(span.name, "a") where span.name == "b"
+    log_statements:
+      - set(log.attributes["a"], 1)
+  filter/x:
+    logs:
+      log_record:
+        - severity_number < SEVERITY_NUMBER_WARN
+  attributes:
+    actions:
+      - key: "a"
+        action: delete
+`, string(formatted))
+
+	again, err := formatCollectorConfig(formatted)
+	require.NoError(t, err)
+	assert.Equal(t, string(formatted), string(again))
+
+	standalone, err := formatCollectorConfig([]byte("log_statements:\n  - set(log.body,\"x\")\n"))
+	require.NoError(t, err)
+	assert.Equal(t, "log_statements:\n  - set(log.body, \"x\")\n", string(standalone))
+
+	_, err = formatCollectorConfig([]byte("processors:\n  transform:\n    log_statements:\n      - set(log.body, 'x')\n"))
+	assert.Error(t, err)
+
+	// Statements and conditions are parsed in the context of their group
+	_, err = formatCollectorConfig([]byte("processors:\n  transform:\n    trace_statements:\n      - context: log\n        statements:\n          - set(span.name,\"x\")\n"))
+	assert.ErrorContains(t, err, "line 6: ")
+	_, err = formatCollectorConfig([]byte("processors:\n  filter:\n    logs:\n      log_record:\n        - severity_nmber < 9\n"))
+	assert.ErrorContains(t, err, "line 5: ")
+}
+
+func TestFormatFiles(t *testing.T) {
+	dir := t.TempDir()
+	rules := filepath.Join(dir, "rules.ottl")
+	config := filepath.Join(dir, "nested", "collector.yaml")
+	require.NoError(t, os.WriteFile(rules, []byte("set(span.name,\"a\")\n"), 0o600))
+	require.NoError(t, os.MkdirAll(filepath.Dir(config), 0o750))
+	require.NoError(t, os.WriteFile(config, []byte(fmtTestConfig), 0o600))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "notes.txt"), []byte("x"), 0o600))
+
+	files, err := collectStatementFiles([]string{dir})
+	require.NoError(t, err)
+	assert.ElementsMatch(t, []string{rules, config}, files)
+
+	// Checking does not write
+	changed, err := formatFile(rules, false)
+	require.NoError(t, err)
+	assert.True(t, changed)
+	data, err := os.ReadFile(rules)
+	require.NoError(t, err)
+	assert.Equal(t, "set(span.name,\"a\")\n", string(data))
+
+	changed, err = formatFile(rules, true)
+	require.NoError(t, err)
+	assert.True(t, changed)
+	data, err = os.ReadFile(rules)
+	require.NoError(t, err)
+	assert.Equal(t, "set(span.name, \"a\")\n", string(data))
+
+	changed, err = formatFile(rules, true)
+	require.NoError(t, err)
+	assert.False(t, changed)
+
+	changed, err = formatFile(config, true)
+	require.NoError(t, err)
+	assert.True(t, changed)
+	data, err = os.ReadFile(config)
+	require.NoError(t, err)
+	assert.Contains(t, string(data), "- severity_number < SEVERITY_NUMBER_WARN")
+}
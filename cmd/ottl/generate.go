@@ -0,0 +1,514 @@
+// Copyright 2025 Dose de Telemetria GmbH
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"fmt"
+	"math/rand/v2"
+	"os"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/plog"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+)
+
+var generateCmd = &cobra.Command{
+	Use:   "generate <traces|logs|metrics>",
+	Short: "Generate synthetic OTLP JSON data",
+	Long: `Writes synthetic OTLP JSON to stdout, for testing statements and benchmarking.
+
+Records carry attributes from the OpenTelemetry semantic conventions: spans are
+HTTP servers calling databases and other services, grouped into traces, logs
+are application logs with trace context, and metrics cover the gauge, sum,
+histogram, exponential histogram and summary types.
+
+--cardinality bounds the number of distinct values of the attributes that vary
+between records, such as url.path and user.id. The same --seed and --start
+produce the same data; without --seed, a random seed is used and printed on
+stderr.`,
+	Example: `  # 1000 spans from 3 services
+  ottl generate traces --count 1000 --resources 3 > spans.json
+
+  # Reproducible logs with few distinct attribute values
+  ottl generate logs --seed 42 --cardinality 5
+
+  # Only histograms and summaries, 20 data points each
+  ottl generate metrics --metric-types histogram,summary --count 20
+
+  # Try a statement on generated data
+  ottl generate traces --seed 1 > spans.json && echo 'set(span.name, "x")' | ottl transform -i spans.json`,
+	Args:      cobra.MatchAll(cobra.ExactArgs(1), cobra.OnlyValidArgs),
+	ValidArgs: []string{"traces", "logs", "metrics"},
+	RunE:      runGenerate,
+}
+
+var generateCount int
+var generateResources int
+var generateCardinality int
+var generateSeed uint64
+var generateStart string
+var generateMetricTypes []string
+
+func init() {
+	generateCmd.Flags().IntVarP(&generateCount, "count", "n", 10, "Spans or log records per resource, or data points per metric")
+	generateCmd.Flags().IntVar(&generateResources, "resources", 1, "Number of resources, one service instance each")
+	generateCmd.Flags().IntVar(&generateCardinality, "cardinality", 10, "Distinct values of the attributes that vary between records")
+	generateCmd.Flags().Uint64Var(&generateSeed, "seed", 0, "Seed of the random generator, for reproducible data")
+	generateCmd.Flags().StringVar(&generateStart, "start", "2025-01-01T00:00:00Z", "Timestamp of the first record (RFC 3339)")
+	generateCmd.Flags().StringSliceVar(&generateMetricTypes, "metric-types", generateMetricTypeNames, "Metric types to generate")
+	rootCmd.AddCommand(generateCmd)
+}
+
+// generateMetricTypeNames are the values of --metric-types
+var generateMetricTypeNames = []string{"gauge", "sum", "histogram", "exponential_histogram", "summary"}
+
+// runGenerate executes the generate command
+func runGenerate(cmd *cobra.Command, args []string) error {
+	if generateCount < 0 || generateResources < 1 || generateCardinality < 1 {
+		return fmt.Errorf("--count must not be negative, and --resources and --cardinality must be at least 1")
+	}
+	start, err := time.Parse(time.RFC3339, generateStart)
+	if err != nil {
+		return fmt.Errorf("invalid --start: %w", err)
+	}
+	for _, name := range generateMetricTypes {
+		if !slices.Contains(generateMetricTypeNames, name) {
+			return fmt.Errorf("invalid metric type %q (valid: %s)", name, strings.Join(generateMetricTypeNames, ", "))
+		}
+	}
+
+	seed := generateSeed
+	if !cmd.Flags().Changed("seed") {
+		seed = rand.Uint64()
+		_, _ = fmt.Fprintf(os.Stderr, "seed: %d\n", seed)
+	}
+	g := newGenerator(seed, start, generateResources, generateCount, generateCardinality)
+
+	switch args[0] {
+	case "traces":
+		return outputTransformedData(contextTypeSpan, g.traces())
+	case "logs":
+		return outputTransformedData(contextTypeLog, g.logs())
+	default:
+		return outputTransformedData(contextTypeMetric, g.metrics(generateMetricTypes))
+	}
+}
+
+// generator builds synthetic telemetry from a seeded random source
+type generator struct {
+	rng         *rand.Rand
+	start       time.Time
+	resources   int
+	count       int
+	cardinality int
+}
+
+// newGenerator returns a generator; the same arguments always produce the same data
+func newGenerator(seed uint64, start time.Time, resources, count, cardinality int) *generator {
+	return &generator{
+		rng:         rand.New(rand.NewPCG(seed, seed)),
+		start:       start,
+		resources:   resources,
+		count:       count,
+		cardinality: cardinality,
+	}
+}
+
+var (
+	generatedServices = []string{"frontend", "checkout", "cart", "payment", "inventory", "shipping", "recommendation", "ad"}
+	generatedRoutes   = []string{"/api/products/{id}", "/api/cart", "/api/checkout", "/api/orders/{id}", "/api/users/{id}", "/health", "/api/search", "/api/recommendations"}
+	generatedMethods  = []string{"GET", "GET", "GET", "POST", "PUT", "DELETE"}
+	generatedStatuses = []int64{200, 200, 200, 200, 200, 201, 204, 400, 404, 500, 503}
+	generatedQueries  = []struct{ operation, table string }{
+		{"SELECT", "products"}, {"SELECT", "users"}, {"INSERT", "orders"}, {"UPDATE", "inventory"}, {"DELETE", "sessions"},
+	}
+	generatedRegions = []string{"us-east-1", "eu-west-1", "ap-southeast-2"}
+)
+
+// pick returns a random element of a slice
+func pick[T any](g *generator, values []T) T {
+	return values[g.rng.IntN(len(values))]
+}
+
+// variant returns one of --cardinality distinct values built from a format with one %d verb
+func (g *generator) variant(format string) string {
+	return fmt.Sprintf(format, g.rng.IntN(g.cardinality))
+}
+
+// route returns an HTTP route and a matching URL path, both within the cardinality
+func (g *generator) route() (string, string) {
+	route := generatedRoutes[g.rng.IntN(min(g.cardinality, len(generatedRoutes)))]
+	return route, strings.ReplaceAll(route, "{id}", g.variant("%d"))
+}
+
+// traceID returns a random trace ID
+func (g *generator) traceID() pcommon.TraceID {
+	var id pcommon.TraceID
+	for i := range id {
+		id[i] = byte(g.rng.UintN(256))
+	}
+	return id
+}
+
+// spanID returns a random span ID
+func (g *generator) spanID() pcommon.SpanID {
+	var id pcommon.SpanID
+	for i := range id {
+		id[i] = byte(g.rng.UintN(256))
+	}
+	return id
+}
+
+// timestamp returns the start time plus an offset
+func (g *generator) timestamp(offset time.Duration) pcommon.Timestamp {
+	return pcommon.NewTimestampFromTime(g.start.Add(offset))
+}
+
+// fillResource sets the resource attributes of a service instance
+func (g *generator) fillResource(resource pcommon.Resource, index int) {
+	attrs := resource.Attributes()
+	service := generatedServices[index%len(generatedServices)]
+	attrs.PutStr("service.name", service)
+	attrs.PutStr("service.namespace", "shop")
+	attrs.PutStr("service.version", fmt.Sprintf("1.%d.%d", g.rng.IntN(5), g.rng.IntN(10)))
+	attrs.PutStr("service.instance.id", fmt.Sprintf("%s-%08x", service, g.rng.Uint32()))
+	attrs.PutStr("deployment.environment.name", pick(g, []string{"production", "production", "staging"}))
+	attrs.PutStr("host.name", fmt.Sprintf("ip-10-0-%d-%d", g.rng.IntN(4), g.rng.IntN(256)))
+	attrs.PutStr("cloud.provider", "aws")
+	attrs.PutStr("cloud.region", pick(g, generatedRegions))
+	attrs.PutStr("telemetry.sdk.name", "opentelemetry")
+	attrs.PutStr("telemetry.sdk.language", "go")
+	attrs.PutStr("telemetry.sdk.version", "1.37.0")
+}
+
+// traces generates --count spans per resource, grouped into traces of a server span and its children
+func (g *generator) traces() ptrace.Traces {
+	traces := ptrace.NewTraces()
+	for r := 0; r < g.resources; r++ {
+		rs := traces.ResourceSpans().AppendEmpty()
+		g.fillResource(rs.Resource(), r)
+		ss := rs.ScopeSpans().AppendEmpty()
+		ss.Scope().SetName("go.opentelemetry.io/contrib/instrumentation/net/http/otelhttp")
+		ss.Scope().SetVersion("0.62.0")
+
+		for n, trace := 0, 0; n < g.count; trace++ {
+			size := min(1+g.rng.IntN(5), g.count-n)
+			g.appendTrace(ss.Spans(), trace, size)
+			n += size
+		}
+	}
+	return traces
+}
+
+// appendTrace adds a server span and size-1 client or internal child spans
+func (g *generator) appendTrace(spans ptrace.SpanSlice, index, size int) {
+	traceID := g.traceID()
+	begin := time.Duration(index)*time.Second + time.Duration(g.rng.IntN(1000))*time.Millisecond
+	duration := time.Duration(10+g.rng.IntN(490)) * time.Millisecond
+
+	root := spans.AppendEmpty()
+	root.SetTraceID(traceID)
+	root.SetSpanID(g.spanID())
+	root.SetKind(ptrace.SpanKindServer)
+	root.SetStartTimestamp(g.timestamp(begin))
+	root.SetEndTimestamp(g.timestamp(begin + duration))
+	method := pick(g, generatedMethods)
+	route, path := g.route()
+	status := pick(g, generatedStatuses)
+	root.SetName(method + " " + route)
+	attrs := root.Attributes()
+	attrs.PutStr("http.request.method", method)
+	attrs.PutStr("http.route", route)
+	attrs.PutStr("url.path", path)
+	attrs.PutStr("url.scheme", "https")
+	attrs.PutInt("http.response.status_code", status)
+	attrs.PutStr("server.address", "shop.example.com")
+	attrs.PutStr("client.address", fmt.Sprintf("203.0.113.%d", g.rng.IntN(g.cardinality)%256))
+	attrs.PutStr("user_agent.original", pick(g, []string{"Mozilla/5.0 (X11; Linux x86_64)", "curl/8.5.0", "okhttp/4.12.0"}))
+	attrs.PutStr("user.id", g.variant("user-%04d"))
+	if status >= 500 {
+		root.Status().SetCode(ptrace.StatusCodeError)
+		event := root.Events().AppendEmpty()
+		event.SetName("exception")
+		event.SetTimestamp(root.EndTimestamp())
+		event.Attributes().PutStr("exception.type", "*errors.errorString")
+		event.Attributes().PutStr("exception.message", "upstream request failed")
+	}
+
+	for i := 1; i < size; i++ {
+		child := spans.AppendEmpty()
+		child.SetTraceID(traceID)
+		child.SetSpanID(g.spanID())
+		child.SetParentSpanID(root.SpanID())
+		offset := time.Duration(g.rng.Int64N(int64(duration) / 2))
+		child.SetStartTimestamp(g.timestamp(begin + offset))
+		child.SetEndTimestamp(g.timestamp(begin + offset + time.Duration(g.rng.Int64N(int64(duration)/2)+1)))
+		attrs := child.Attributes()
+		switch g.rng.IntN(3) {
+		case 0:
+			query := pick(g, generatedQueries)
+			child.SetKind(ptrace.SpanKindClient)
+			child.SetName(query.operation + " " + query.table)
+			attrs.PutStr("db.system.name", "postgresql")
+			attrs.PutStr("db.operation.name", query.operation)
+			attrs.PutStr("db.collection.name", query.table)
+			attrs.PutStr("db.query.text", fmt.Sprintf("%s ... %s WHERE id = $1", query.operation, query.table))
+			attrs.PutStr("server.address", "postgres.internal")
+			attrs.PutInt("server.port", 5432)
+		case 1:
+			peer := pick(g, generatedServices)
+			child.SetKind(ptrace.SpanKindClient)
+			child.SetName("GET")
+			attrs.PutStr("http.request.method", "GET")
+			attrs.PutStr("url.full", fmt.Sprintf("http://%s:8080/api/%s/%d", peer, peer, g.rng.IntN(g.cardinality)))
+			attrs.PutStr("server.address", peer)
+			attrs.PutInt("server.port", 8080)
+			attrs.PutInt("http.response.status_code", 200)
+		default:
+			child.SetKind(ptrace.SpanKindInternal)
+			child.SetName(pick(g, []string{"render", "validate", "serialize", "cache.lookup"}))
+			attrs.PutStr("code.function.name", g.variant("handler%d"))
+		}
+	}
+}
+
+// generatedLogMessages are log bodies by severity
+var generatedLogMessages = map[plog.SeverityNumber][]string{
+	plog.SeverityNumberDebug: {"cache miss for %s", "handling request for %s"},
+	plog.SeverityNumberInfo:  {"request completed for %s", "served %s"},
+	plog.SeverityNumberWarn:  {"slow response for %s", "retrying upstream call for %s"},
+	plog.SeverityNumberError: {"request failed for %s", "connection refused while handling %s"},
+}
+
+// logs generates --count log records per resource, most of them with trace context
+func (g *generator) logs() plog.Logs {
+	logs := plog.NewLogs()
+	severities := []plog.SeverityNumber{
+		plog.SeverityNumberDebug, plog.SeverityNumberInfo, plog.SeverityNumberInfo, plog.SeverityNumberInfo,
+		plog.SeverityNumberInfo, plog.SeverityNumberWarn, plog.SeverityNumberError,
+	}
+	for r := 0; r < g.resources; r++ {
+		rl := logs.ResourceLogs().AppendEmpty()
+		g.fillResource(rl.Resource(), r)
+		sl := rl.ScopeLogs().AppendEmpty()
+		sl.Scope().SetName("go.opentelemetry.io/contrib/bridges/otelslog")
+		sl.Scope().SetVersion("0.12.0")
+
+		for i := 0; i < g.count; i++ {
+			record := sl.LogRecords().AppendEmpty()
+			severity := pick(g, severities)
+			at := g.timestamp(time.Duration(i)*100*time.Millisecond + time.Duration(g.rng.IntN(100))*time.Millisecond)
+			record.SetTimestamp(at)
+			record.SetObservedTimestamp(at)
+			record.SetSeverityNumber(severity)
+			record.SetSeverityText(strings.ToUpper(severity.String()))
+			route, path := g.route()
+			record.Body().SetStr(fmt.Sprintf(pick(g, generatedLogMessages[severity]), path))
+			attrs := record.Attributes()
+			attrs.PutStr("http.route", route)
+			attrs.PutStr("user.id", g.variant("user-%04d"))
+			attrs.PutStr("code.function.name", g.variant("handler%d"))
+			if severity >= plog.SeverityNumberError {
+				attrs.PutStr("exception.type", "*net.OpError")
+			}
+			if g.rng.IntN(4) > 0 {
+				record.SetTraceID(g.traceID())
+				record.SetSpanID(g.spanID())
+				record.SetFlags(plog.DefaultLogRecordFlags.WithIsSampled(true))
+			}
+		}
+	}
+	return logs
+}
+
+// histogramBounds are the bucket boundaries semantic conventions recommend for durations in seconds
+var histogramBounds = []float64{0.005, 0.01, 0.025, 0.05, 0.075, 0.1, 0.25, 0.5, 0.75, 1, 2.5, 5, 7.5, 10}
+
+// metrics generates one metric of each type per resource, with --count data points each
+func (g *generator) metrics(types []string) pmetric.Metrics {
+	metrics := pmetric.NewMetrics()
+	for r := 0; r < g.resources; r++ {
+		rm := metrics.ResourceMetrics().AppendEmpty()
+		g.fillResource(rm.Resource(), r)
+		sm := rm.ScopeMetrics().AppendEmpty()
+		sm.Scope().SetName("go.opentelemetry.io/contrib/instrumentation/runtime")
+		sm.Scope().SetVersion("0.62.0")
+
+		for _, name := range generateMetricTypeNames {
+			if !slices.Contains(types, name) {
+				continue
+			}
+			metric := sm.Metrics().AppendEmpty()
+			switch name {
+			case "gauge":
+				g.gauge(metric)
+			case "sum":
+				g.sum(metric)
+			case "histogram":
+				g.histogram(metric)
+			case "exponential_histogram":
+				g.exponentialHistogram(metric)
+			case "summary":
+				g.summary(metric)
+			}
+		}
+	}
+	return metrics
+}
+
+// dataPointTimes returns the start and time of the i-th data point, one per 10 seconds
+func (g *generator) dataPointTimes(i int) (pcommon.Timestamp, pcommon.Timestamp) {
+	return g.timestamp(0), g.timestamp(time.Duration(i+1) * 10 * time.Second)
+}
+
+// putRequestAttributes sets the HTTP attributes of a request metric data point
+func (g *generator) putRequestAttributes(attrs pcommon.Map) {
+	route, _ := g.route()
+	attrs.PutStr("http.request.method", pick(g, generatedMethods))
+	attrs.PutStr("http.route", route)
+	attrs.PutInt("http.response.status_code", pick(g, generatedStatuses))
+}
+
+// gauge fills a CPU utilization gauge
+func (g *generator) gauge(metric pmetric.Metric) {
+	metric.SetName("system.cpu.utilization")
+	metric.SetDescription("Difference in system.cpu.time since the last measurement, divided by the elapsed time and number of CPUs")
+	metric.SetUnit("1")
+	points := metric.SetEmptyGauge().DataPoints()
+	for i := 0; i < g.count; i++ {
+		dp := points.AppendEmpty()
+		_, at := g.dataPointTimes(i)
+		dp.SetTimestamp(at)
+		dp.SetDoubleValue(g.rng.Float64())
+		dp.Attributes().PutInt("cpu.logical_number", int64(g.rng.IntN(min(g.cardinality, 64))))
+		dp.Attributes().PutStr("cpu.mode", pick(g, []string{"user", "system", "idle", "iowait"}))
+	}
+}
+
+// sum fills a cumulative, monotonic request counter
+func (g *generator) sum(metric pmetric.Metric) {
+	metric.SetName("http.server.request.count")
+	metric.SetDescription("Number of HTTP server requests")
+	metric.SetUnit("{request}")
+	sum := metric.SetEmptySum()
+	sum.SetIsMonotonic(true)
+	sum.SetAggregationTemporality(pmetric.AggregationTemporalityCumulative)
+	total := int64(0)
+	for i := 0; i < g.count; i++ {
+		dp := sum.DataPoints().AppendEmpty()
+		start, at := g.dataPointTimes(i)
+		dp.SetStartTimestamp(start)
+		dp.SetTimestamp(at)
+		total += int64(g.rng.IntN(100))
+		dp.SetIntValue(total)
+		g.putRequestAttributes(dp.Attributes())
+	}
+}
+
+// histogram fills a request duration histogram with the recommended bucket boundaries
+func (g *generator) histogram(metric pmetric.Metric) {
+	metric.SetName("http.server.request.duration")
+	metric.SetDescription("Duration of HTTP server requests")
+	metric.SetUnit("s")
+	histogram := metric.SetEmptyHistogram()
+	histogram.SetAggregationTemporality(pmetric.AggregationTemporalityCumulative)
+	for i := 0; i < g.count; i++ {
+		dp := histogram.DataPoints().AppendEmpty()
+		start, at := g.dataPointTimes(i)
+		dp.SetStartTimestamp(start)
+		dp.SetTimestamp(at)
+		dp.ExplicitBounds().FromRaw(histogramBounds)
+		counts := make([]uint64, len(histogramBounds)+1)
+		samples := 1 + g.rng.IntN(200)
+		minimum, maximum, total := 0.0, 0.0, 0.0
+		for s := 0; s < samples; s++ {
+			value := g.rng.ExpFloat64() * 0.2
+			bucket := 0
+			for bucket < len(histogramBounds) && value > histogramBounds[bucket] {
+				bucket++
+			}
+			counts[bucket]++
+			total += value
+			if s == 0 || value < minimum {
+				minimum = value
+			}
+			maximum = max(maximum, value)
+		}
+		dp.BucketCounts().FromRaw(counts)
+		dp.SetCount(uint64(samples))
+		dp.SetSum(total)
+		dp.SetMin(minimum)
+		dp.SetMax(maximum)
+		g.putRequestAttributes(dp.Attributes())
+	}
+}
+
+// exponentialHistogram fills a client request duration exponential histogram
+func (g *generator) exponentialHistogram(metric pmetric.Metric) {
+	metric.SetName("http.client.request.duration")
+	metric.SetDescription("Duration of HTTP client requests")
+	metric.SetUnit("s")
+	histogram := metric.SetEmptyExponentialHistogram()
+	histogram.SetAggregationTemporality(pmetric.AggregationTemporalityDelta)
+	for i := 0; i < g.count; i++ {
+		dp := histogram.DataPoints().AppendEmpty()
+		start, at := g.dataPointTimes(i)
+		dp.SetStartTimestamp(start)
+		dp.SetTimestamp(at)
+		dp.SetScale(3)
+		dp.Positive().SetOffset(-40)
+		counts := make([]uint64, 8+g.rng.IntN(8))
+		count := uint64(0)
+		for b := range counts {
+			counts[b] = uint64(g.rng.IntN(50))
+			count += counts[b]
+		}
+		dp.Positive().BucketCounts().FromRaw(counts)
+		dp.SetZeroCount(uint64(g.rng.IntN(3)))
+		dp.SetCount(count + dp.ZeroCount())
+		dp.SetSum(float64(count) * (0.01 + g.rng.Float64()*0.1))
+		dp.Attributes().PutStr("server.address", pick(g, generatedServices))
+		dp.Attributes().PutStr("http.request.method", pick(g, generatedMethods))
+	}
+}
+
+// summary fills a garbage collection pause summary with quantiles
+func (g *generator) summary(metric pmetric.Metric) {
+	metric.SetName("go.gc.pause.duration")
+	metric.SetDescription("Distribution of stop-the-world garbage collection pauses")
+	metric.SetUnit("s")
+	points := metric.SetEmptySummary().DataPoints()
+	for i := 0; i < g.count; i++ {
+		dp := points.AppendEmpty()
+		start, at := g.dataPointTimes(i)
+		dp.SetStartTimestamp(start)
+		dp.SetTimestamp(at)
+		count := uint64(1 + g.rng.IntN(100))
+		median := 0.0001 + g.rng.Float64()*0.001
+		dp.SetCount(count)
+		dp.SetSum(float64(count) * median)
+		for _, q := range []struct{ quantile, factor float64 }{{0, 0.2}, {0.5, 1}, {0.9, 2.5}, {0.99, 6}, {1, 10}} {
+			value := dp.QuantileValues().AppendEmpty()
+			value.SetQuantile(q.quantile)
+			value.SetValue(median * q.factor)
+		}
+		dp.Attributes().PutStr("go.gc.generation", g.variant("gen%d"))
+	}
+}
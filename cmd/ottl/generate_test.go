@@ -0,0 +1,123 @@
+package main
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+)
+
+var generateTestStart = time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+
+func TestGeneratorDeterministic(t *testing.T) {
+	tests := []struct {
+		name     string
+		generate func(g *generator) interface{}
+	}{
+		{name: "traces", generate: func(g *generator) interface{} { return g.traces() }},
+		{name: "logs", generate: func(g *generator) interface{} { return g.logs() }},
+		{name: "metrics", generate: func(g *generator) interface{} { return g.metrics(generateMetricTypeNames) }},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			first, err := marshalOTLPJSON(tt.generate(newGenerator(7, generateTestStart, 2, 5, 3)))
+			require.NoError(t, err)
+			second, err := marshalOTLPJSON(tt.generate(newGenerator(7, generateTestStart, 2, 5, 3)))
+			require.NoError(t, err)
+			other, err := marshalOTLPJSON(tt.generate(newGenerator(8, generateTestStart, 2, 5, 3)))
+			require.NoError(t, err)
+
+			assert.Equal(t, string(first), string(second))
+			assert.NotEqual(t, string(first), string(other))
+		})
+	}
+}
+
+func TestGeneratorCounts(t *testing.T) {
+	g := newGenerator(1, generateTestStart, 3, 12, 4)
+
+	traces := g.traces()
+	require.Equal(t, 3, traces.ResourceSpans().Len())
+	users := map[string]bool{}
+	for i := 0; i < traces.ResourceSpans().Len(); i++ {
+		spans := traces.ResourceSpans().At(i).ScopeSpans().At(0).Spans()
+		assert.Equal(t, 12, spans.Len())
+		for j := 0; j < spans.Len(); j++ {
+			if user, ok := spans.At(j).Attributes().Get("user.id"); ok {
+				users[user.Str()] = true
+			}
+		}
+	}
+	assert.NotEmpty(t, users)
+	assert.LessOrEqual(t, len(users), 4)
+
+	logs := g.logs()
+	require.Equal(t, 3, logs.ResourceLogs().Len())
+	assert.Equal(t, 12, logs.ResourceLogs().At(0).ScopeLogs().At(0).LogRecords().Len())
+	assert.Equal(t, 36, logs.LogRecordCount())
+}
+
+func TestGeneratorMetricTypes(t *testing.T) {
+	tests := []struct {
+		name  string
+		types []string
+		want  []pmetric.MetricType
+	}{
+		{
+			name:  "all",
+			types: generateMetricTypeNames,
+			want: []pmetric.MetricType{
+				pmetric.MetricTypeGauge,
+				pmetric.MetricTypeSum,
+				pmetric.MetricTypeHistogram,
+				pmetric.MetricTypeExponentialHistogram,
+				pmetric.MetricTypeSummary,
+			},
+		},
+		{
+			name:  "filtered",
+			types: []string{"summary", "histogram"},
+			want:  []pmetric.MetricType{pmetric.MetricTypeHistogram, pmetric.MetricTypeSummary},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			metrics := newGenerator(1, generateTestStart, 1, 6, 3).metrics(tt.types)
+			list := metrics.ResourceMetrics().At(0).ScopeMetrics().At(0).Metrics()
+
+			var got []pmetric.MetricType
+			for i := 0; i < list.Len(); i++ {
+				got = append(got, list.At(i).Type())
+			}
+			assert.Equal(t, tt.want, got)
+			assert.Equal(t, 6*len(tt.want), metrics.DataPointCount())
+		})
+	}
+}
+
+func TestGeneratedDataDetected(t *testing.T) {
+	g := newGenerator(3, generateTestStart, 1, 4, 2)
+	tests := []struct {
+		name string
+		data interface{}
+		want contextType
+	}{
+		{name: "traces", data: g.traces(), want: contextTypeSpan},
+		{name: "logs", data: g.logs(), want: contextTypeLog},
+		{name: "metrics", data: g.metrics(generateMetricTypeNames), want: contextTypeMetric},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			data, err := marshalOTLPJSON(tt.data)
+			require.NoError(t, err)
+			ctx, _, err := detectContextType(data)
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, ctx)
+		})
+	}
+}
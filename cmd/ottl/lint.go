@@ -0,0 +1,848 @@
+// Copyright 2025 Dose de Telemetria GmbH
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+	"unicode"
+
+	"github.com/spf13/cobra"
+	"gopkg.in/yaml.v3"
+)
+
+var lintCmd = &cobra.Command{
+	Use:   "lint [path...]",
+	Short: "Report likely mistakes in OTTL statements",
+	Long: `Checks OTTL statements for mistakes that parse but rarely do what was meant.
+
+Paths are .ottl files, collector config YAML files, or directories containing
+them; in YAML files, the statements and conditions of transform and filter
+processors are checked. Without paths, statements are read from stdin.
+
+Rules:
+  OTTL001 invalid-statement   (error)    the statement does not parse
+  OTTL002 set-then-delete     (warning)  a key is set and then removed by a later statement
+  OTTL003 constant-condition  (warning)  a condition is always true or always false
+  OTTL004 deprecated-path     (warning)  the path has a replacement
+  OTTL005 invalid-regex       (error)    a pattern argument is not a valid regular expression
+  OTTL006 read-only-path      (error)    setting the path has no effect
+  OTTL007 ambiguous-path      (warning)  a path without context name, when the context is inferred
+
+A comment containing "ottl-lint-ignore" followed by rule IDs or names, separated
+by commas, disables those rules for the statement below it, or for all rules
+when none are given. In YAML, the comment can also precede a statement group or
+a filter setting. "ottl-lint-ignore-file" disables rules for the whole file.`,
+	Example: `  # Lint files and directories
+  ottl lint rules.ottl collector.yaml configs/
+
+  # Only fail on errors
+  ottl lint --fail-on error collector.yaml
+
+  # Suppress a rule for one statement
+  # ottl-lint-ignore OTTL003 kept while the attribute is rolled out
+  set(span.attributes["v"], 2) where true`,
+	RunE: runLint,
+}
+
+var lintFailOn string
+
+func init() {
+	lintCmd.Flags().StringVar(&lintFailOn, "fail-on", "warning", "Lowest severity that makes the command fail (warning, error)")
+	rootCmd.AddCommand(lintCmd)
+}
+
+// lintSeverity is how serious a lint finding is
+type lintSeverity int
+
+const (
+	severityWarning lintSeverity = iota
+	severityError
+)
+
+// String returns the name of a severity
+func (s lintSeverity) String() string {
+	if s == severityError {
+		return "error"
+	}
+	return "warning"
+}
+
+// lintRule identifies a check of the lint command
+type lintRule struct {
+	id       string
+	name     string
+	severity lintSeverity
+}
+
+var (
+	ruleInvalidStatement  = lintRule{id: "OTTL001", name: "invalid-statement", severity: severityError}
+	ruleSetThenDelete     = lintRule{id: "OTTL002", name: "set-then-delete", severity: severityWarning}
+	ruleConstantCondition = lintRule{id: "OTTL003", name: "constant-condition", severity: severityWarning}
+	ruleDeprecatedPath    = lintRule{id: "OTTL004", name: "deprecated-path", severity: severityWarning}
+	ruleInvalidRegex      = lintRule{id: "OTTL005", name: "invalid-regex", severity: severityError}
+	ruleReadOnlyPath      = lintRule{id: "OTTL006", name: "read-only-path", severity: severityError}
+	ruleAmbiguousPath     = lintRule{id: "OTTL007", name: "ambiguous-path", severity: severityWarning}
+)
+
+// lintRules lists the rules of the lint command
+var lintRules = []lintRule{
+	ruleInvalidStatement, ruleSetThenDelete, ruleConstantCondition, ruleDeprecatedPath,
+	ruleInvalidRegex, ruleReadOnlyPath, ruleAmbiguousPath,
+}
+
+// lintFinding is a problem reported at a one-based line and column of a file
+type lintFinding struct {
+	rule    lintRule
+	line    int
+	column  int
+	message string
+}
+
+// lintIssue is a problem found in a statement, at a byte offset of the statement
+type lintIssue struct {
+	rule    lintRule
+	offset  int
+	message string
+}
+
+// lintStatement is a statement or condition to check, with what is known about where it runs
+type lintStatement struct {
+	text      string
+	condition bool
+	contexts  []contextType // nil for contexts the CLI does not support
+	inferred  bool          // the context is inferred from the paths of the statement
+	ignored   lintIgnores
+	position  func(line, column int) (int, int) // maps a zero-based statement position to the file
+}
+
+// runLint executes the lint command
+func runLint(cmd *cobra.Command, args []string) error {
+	var failOn lintSeverity
+	switch lintFailOn {
+	case "warning":
+		failOn = severityWarning
+	case "error":
+		failOn = severityError
+	default:
+		return fmt.Errorf("invalid --fail-on %q (valid: warning, error)", lintFailOn)
+	}
+
+	sources := map[string][]byte{}
+	var files []string
+	if len(args) == 0 {
+		source, err := io.ReadAll(os.Stdin)
+		if err != nil {
+			return fmt.Errorf("failed to read stdin: %w", err)
+		}
+		files = []string{stdinSource}
+		sources[stdinSource] = source
+	} else {
+		var err error
+		if files, err = collectStatementFiles(args); err != nil {
+			return err
+		}
+		for _, filename := range files {
+			if sources[filename], err = readInputFile(filename); err != nil {
+				return err
+			}
+		}
+	}
+
+	failures := 0
+	for _, filename := range files {
+		findings, err := lintSource(filename, sources[filename])
+		if err != nil {
+			return fmt.Errorf("%s: %w", filename, err)
+		}
+		for _, f := range findings {
+			fmt.Printf("%s:%d:%d: %s: %s (%s %s)\n", filename, f.line, f.column, f.rule.severity, f.message, f.rule.id, f.rule.name)
+			if f.rule.severity >= failOn {
+				failures++
+			}
+		}
+	}
+
+	if failures > 0 {
+		cmd.SilenceUsage = true
+		return fmt.Errorf("%d problem(s) found", failures)
+	}
+	return nil
+}
+
+// lintSource checks the statements of a .ottl or YAML file and returns its findings in order
+func lintSource(filename string, source []byte) ([]lintFinding, error) {
+	var findings []lintFinding
+	switch filepath.Ext(filename) {
+	case ".yaml", ".yml":
+		var root yaml.Node
+		if err := yaml.Unmarshal(source, &root); err != nil {
+			return nil, fmt.Errorf("invalid YAML: %w", err)
+		}
+		if len(root.Content) == 0 {
+			return nil, nil
+		}
+		for _, group := range collectorStatementGroups(root.Content[0]) {
+			findings = append(findings, lintStatements(yamlLintStatements(group, true))...)
+			findings = append(findings, lintStatements(yamlLintStatements(group, false))...)
+		}
+	default:
+		var statements []lintStatement
+		for _, s := range splitStatements(string(source)) {
+			statements = append(statements, lintStatement{
+				text:     s.text,
+				contexts: statementContexts(s.text),
+				inferred: true,
+				ignored:  parseLintIgnores(s.comments, false),
+				position: func(line, column int) (int, int) { return s.line + line + 1, column + 1 },
+			})
+		}
+		findings = lintStatements(statements)
+	}
+
+	fileIgnored := parseLintIgnores(strings.Split(string(source), "\n"), true)
+	findings = slices.DeleteFunc(findings, func(f lintFinding) bool { return fileIgnored.has(f.rule) })
+	sort.SliceStable(findings, func(i, j int) bool {
+		if findings[i].line != findings[j].line {
+			return findings[i].line < findings[j].line
+		}
+		return findings[i].column < findings[j].column
+	})
+	return findings, nil
+}
+
+// yamlLintStatements returns the conditions or the statements of a collector config group
+func yamlLintStatements(group statementGroup, conditions bool) []lintStatement {
+	scalars := group.statements
+	if conditions {
+		scalars = group.conditions
+	}
+	var groupComments []string
+	if group.node != nil {
+		groupComments = []string{group.node.HeadComment, group.node.LineComment}
+	}
+
+	var statements []lintStatement
+	for _, scalar := range scalars {
+		node := scalar.node
+		s := lintStatement{
+			text:      node.Value,
+			condition: conditions,
+			inferred:  group.context == "",
+			ignored:   parseLintIgnores(append([]string{node.HeadComment, node.LineComment}, groupComments...), false),
+			position:  yamlScalarPosition(node),
+		}
+		if s.inferred {
+			s.contexts = statementContexts(node.Value)
+		} else if ctx := parseContextFlag(group.context); ctx != contextTypeUnknown {
+			s.contexts = []contextType{ctx}
+		}
+		statements = append(statements, s)
+	}
+	return statements
+}
+
+// yamlScalarPosition maps statement positions to the file for scalars on a single line, and
+// to the start of the scalar otherwise, since escapes and folding move the text around
+func yamlScalarPosition(node *yaml.Node) func(line, column int) (int, int) {
+	return func(line, column int) (int, int) {
+		if line > 0 || strings.Contains(node.Value, "\n") {
+			return node.Line, node.Column
+		}
+		switch node.Style {
+		case 0:
+			return node.Line, node.Column + column
+		case yaml.SingleQuotedStyle, yaml.DoubleQuotedStyle:
+			return node.Line, node.Column + 1 + column
+		default:
+			return node.Line, node.Column
+		}
+	}
+}
+
+// lintStatements runs the rules on statements that run in order, such as a .ottl file or a group
+func lintStatements(statements []lintStatement) []lintFinding {
+	var findings []lintFinding
+	sets := map[string][]setRecord{}
+	for _, s := range statements {
+		var issues []lintIssue
+		tokens, err := tokenizeStatement(s.text)
+		if err == nil {
+			err = checkBrackets(tokens)
+		}
+		if err != nil {
+			issues = append(issues, lintIssue{rule: ruleInvalidStatement, message: err.Error()})
+		} else {
+			paths := statementPaths(tokens)
+			issues = append(issues, regexIssues(tokens)...)
+			issues = append(issues, pathIssues(s, tokens, paths)...)
+			issues = append(issues, constantConditionIssues(s, tokens)...)
+			if !s.condition {
+				issues = append(issues, setThenDeleteIssues(s, tokens, sets)...)
+			}
+			if issue, ok := parseIssue(s, issues); ok {
+				issues = append(issues, issue)
+			}
+		}
+
+		for _, issue := range issues {
+			if s.ignored.has(issue.rule) {
+				continue
+			}
+			span := statementPosition(s.text, issue.offset)
+			line, column := s.position(span.line, span.column)
+			findings = append(findings, lintFinding{rule: issue.rule, line: line, column: column, message: issue.message})
+		}
+	}
+	return findings
+}
+
+// statementPosition converts a byte offset of a statement to a zero-based line and rune column
+func statementPosition(statement string, offset int) errorSpan {
+	before := statement[:offset]
+	lineStart := strings.LastIndex(before, "\n") + 1
+	return errorSpan{line: strings.Count(before, "\n"), column: len([]rune(before[lineStart:]))}
+}
+
+// parseIssue reports statements the OTTL parser rejects, unless a more specific error was found.
+// Conditions and statements of unsupported contexts are not parsed.
+func parseIssue(s lintStatement, issues []lintIssue) (lintIssue, bool) {
+	if s.condition || len(s.contexts) == 0 {
+		return lintIssue{}, false
+	}
+	for _, issue := range issues {
+		if issue.rule.severity == severityError {
+			return lintIssue{}, false
+		}
+	}
+
+	ctx := s.contexts[0]
+	var err error
+	if s.inferred {
+		ctx, err = checkStatement(s.text)
+	} else {
+		err = applyTransformation(s.text, ctx, emptyData(ctx))
+	}
+	if err == nil {
+		return lintIssue{}, false
+	}
+	span := locateError(s.text, err)
+	lines := strings.SplitAfter(s.text, "\n")
+	offset := 0
+	for _, line := range lines[:span.line] {
+		offset += len(line)
+	}
+	offset += len(string([]rune(lines[span.line])[:span.column]))
+	return lintIssue{rule: ruleInvalidStatement, offset: offset, message: parseErrorMessage(ctx, s.text, err)}, true
+}
+
+// pathRef is a path read or written by a statement
+type pathRef struct {
+	path   string   // names joined by dots, without keys
+	keys   []string // literal keys and indexes that follow the path, string keys unquoted
+	offset int
+	end    int // index of the token after the path
+}
+
+// contextNames are the names qualified paths start with
+var contextNames = map[string]bool{
+	"span": true, "spanevent": true, "log": true, "metric": true, "datapoint": true,
+	"resource": true, "instrumentation_scope": true, "scope": true, "profile": true,
+}
+
+// statementPaths returns the paths of a statement. Words followed by "(" or "=" are
+// functions and argument names; uppercase words are converters and enums.
+func statementPaths(tokens []ottlToken) []pathRef {
+	var paths []pathRef
+	for i := 0; i < len(tokens); i++ {
+		t := tokens[i]
+		if t.kind != tokenWord || !unicode.IsLower(rune(t.text[0])) || t.text == "true" || t.text == "false" || t.text == "nil" {
+			continue
+		}
+		if i+1 < len(tokens) && (tokens[i+1].text == "(" || tokens[i+1].text == "=") {
+			continue
+		}
+		ref := pathRef{path: t.text, offset: t.offset}
+		j := i + 1
+		for j+1 < len(tokens) && tokens[j].text == "." && tokens[j+1].kind == tokenWord {
+			ref.path += "." + tokens[j+1].text
+			j += 2
+		}
+		for j+2 < len(tokens) && tokens[j].text == "[" && tokens[j+2].text == "]" {
+			key := tokens[j+1]
+			if key.kind == tokenString {
+				key.text, _ = strconv.Unquote(key.text)
+			} else if key.kind != tokenNumber {
+				break
+			}
+			ref.keys = append(ref.keys, key.text)
+			j += 3
+		}
+		ref.end = j
+		paths = append(paths, ref)
+		i = j - 1
+	}
+	return paths
+}
+
+// callArgs returns the tokens of each argument of the call whose name is at index i
+func callArgs(tokens []ottlToken, i int) [][]ottlToken {
+	if i+1 >= len(tokens) || tokens[i+1].text != "(" {
+		return nil
+	}
+	var args [][]ottlToken
+	start := i + 2
+	depth := 0
+	for j := i + 2; j < len(tokens); j++ {
+		switch tokens[j].text {
+		case "(", "[", "{":
+			depth++
+		case ")", "]", "}":
+			if depth == 0 {
+				if j > start {
+					args = append(args, tokens[start:j])
+				}
+				return args
+			}
+			depth--
+		case ",":
+			if depth == 0 {
+				args = append(args, tokens[start:j])
+				start = j + 1
+			}
+		}
+	}
+	return args
+}
+
+// whereIndex returns the index of the where keyword of a statement, or -1
+func whereIndex(tokens []ottlToken) int {
+	depth := 0
+	for i, t := range tokens {
+		switch t.text {
+		case "(", "[", "{":
+			depth++
+		case ")", "]", "}":
+			depth--
+		}
+		if depth == 0 && t.kind == tokenKeyword && t.text == "where" {
+			return i
+		}
+	}
+	return -1
+}
+
+// regexArguments are the positions of the arguments that functions compile as regular expressions
+var regexArguments = map[string]int{
+	"IsMatch":              1,
+	"ExtractPatterns":      1,
+	"replace_pattern":      1,
+	"replace_all_patterns": 2,
+	"delete_matching_keys": 1,
+	"keep_matching_keys":   1,
+}
+
+// regexIssues reports literal patterns that Go cannot compile
+func regexIssues(tokens []ottlToken) []lintIssue {
+	var issues []lintIssue
+	for i, t := range tokens {
+		position, ok := regexArguments[t.text]
+		if t.kind != tokenWord || !ok {
+			continue
+		}
+		args := callArgs(tokens, i)
+		if position >= len(args) || len(args[position]) != 1 || args[position][0].kind != tokenString {
+			continue
+		}
+		pattern, err := strconv.Unquote(args[position][0].text)
+		if err != nil {
+			continue
+		}
+		if _, err := regexp.Compile(pattern); err != nil {
+			issues = append(issues, lintIssue{rule: ruleInvalidRegex, offset: args[position][0].offset, message: fmt.Sprintf("invalid pattern for %s: %v", t.text, err)})
+		}
+	}
+	return issues
+}
+
+// pathIssues reports deprecated paths, editors writing read-only paths and unqualified paths
+// whose context is inferred
+func pathIssues(s lintStatement, tokens []ottlToken, paths []pathRef) []lintIssue {
+	var issues []lintIssue
+	for _, ref := range paths {
+		if p, ok := resolvePath(ref.path, s.contexts); ok && p.deprecated != "" {
+			issues = append(issues, lintIssue{rule: ruleDeprecatedPath, offset: ref.offset, message: fmt.Sprintf("%s is deprecated, use %s", ref.path, p.deprecated)})
+		}
+	}
+
+	if target, ok := editorTarget(s, tokens, paths); ok {
+		if p, ok := resolvePath(target.path, s.contexts); ok && p.readOnly && len(target.keys) == 0 {
+			issues = append(issues, lintIssue{rule: ruleReadOnlyPath, offset: target.offset, message: fmt.Sprintf("%s is read-only, setting it has no effect", target.path)})
+		}
+	}
+
+	if s.inferred && len(s.contexts) > 1 {
+		for _, ref := range paths {
+			name, _, _ := strings.Cut(ref.path, ".")
+			if !contextNames[name] {
+				issues = append(issues, lintIssue{rule: ruleAmbiguousPath, offset: ref.offset, message: fmt.Sprintf("path %s does not name its context, so the statement runs for every context that has it; qualify it, e.g. %s.%s", ref.path, s.contexts[0], ref.path)})
+				break
+			}
+		}
+	}
+	return issues
+}
+
+// editorTarget returns the path an editor statement changes, when its first argument is a path
+func editorTarget(s lintStatement, tokens []ottlToken, paths []pathRef) (pathRef, bool) {
+	if s.condition || len(tokens) < 2 || tokens[0].kind != tokenWord || tokens[1].text != "(" {
+		return pathRef{}, false
+	}
+	args := callArgs(tokens, 0)
+	if len(args) == 0 {
+		return pathRef{}, false
+	}
+	for _, ref := range paths {
+		if ref.offset == args[0][0].offset && tokens[ref.end-1].offset == args[0][len(args[0])-1].offset {
+			return ref, true
+		}
+	}
+	return pathRef{}, false
+}
+
+// comparisonOps are the operators of comparisons
+var comparisonOps = map[string]bool{"==": true, "!=": true, "<": true, "<=": true, ">": true, ">=": true}
+
+// constantConditionIssues reports the terms of a condition that are always true or false
+func constantConditionIssues(s lintStatement, tokens []ottlToken) []lintIssue {
+	condition := tokens
+	if !s.condition {
+		where := whereIndex(tokens)
+		if where < 0 {
+			return nil
+		}
+		condition = tokens[where+1:]
+	}
+	return constantTerms(condition)
+}
+
+// constantTerms checks the terms joined by and/or, looking into parenthesized terms
+func constantTerms(tokens []ottlToken) []lintIssue {
+	var issues []lintIssue
+	for _, term := range splitTopLevel(tokens, func(t ottlToken) bool {
+		return t.kind == tokenKeyword && (t.text == "and" || t.text == "or")
+	}) {
+		negated := false
+		expr := term
+		for len(expr) > 0 && expr[0].kind == tokenKeyword && expr[0].text == "not" {
+			negated = !negated
+			expr = expr[1:]
+		}
+		if len(expr) >= 2 && expr[0].text == "(" && closingIndex(expr, 0) == len(expr)-1 {
+			issues = append(issues, constantTerms(expr[1:len(expr)-1])...)
+			continue
+		}
+		value, ok := constantValue(expr)
+		if ok && len(term) > 0 {
+			issues = append(issues, lintIssue{rule: ruleConstantCondition, offset: term[0].offset, message: fmt.Sprintf("condition %s is always %t", joinTokens(term), value != negated)})
+		}
+	}
+	return issues
+}
+
+// splitTopLevel splits tokens at the separators outside brackets
+func splitTopLevel(tokens []ottlToken, separator func(ottlToken) bool) [][]ottlToken {
+	var parts [][]ottlToken
+	start, depth := 0, 0
+	for i, t := range tokens {
+		switch t.text {
+		case "(", "[", "{":
+			depth++
+		case ")", "]", "}":
+			depth--
+		}
+		if depth == 0 && separator(t) {
+			parts = append(parts, tokens[start:i])
+			start = i + 1
+		}
+	}
+	return append(parts, tokens[start:])
+}
+
+// closingIndex returns the index of the bracket closing the one at index i
+func closingIndex(tokens []ottlToken, i int) int {
+	depth := 0
+	for j := i; j < len(tokens); j++ {
+		switch tokens[j].text {
+		case "(", "[", "{":
+			depth++
+		case ")", "]", "}":
+			depth--
+			if depth == 0 {
+				return j
+			}
+		}
+	}
+	return -1
+}
+
+// constantValue evaluates a boolean literal, a comparison of two literals, or a comparison of
+// an expression with itself. Expressions calling converters are not constant.
+func constantValue(expr []ottlToken) (bool, bool) {
+	if len(expr) == 1 && expr[0].kind == tokenWord && (expr[0].text == "true" || expr[0].text == "false") {
+		return expr[0].text == "true", true
+	}
+
+	parts := splitTopLevel(expr, func(t ottlToken) bool { return t.kind == tokenOp && comparisonOps[t.text] })
+	if len(parts) != 2 || len(parts[0]) == 0 || len(parts[1]) == 0 {
+		return false, false
+	}
+	left, right := parts[0], parts[1]
+	op := expr[len(left)].text
+
+	if isLiteral(left) && isLiteral(right) {
+		return compareLiterals(left[0], right[0], op)
+	}
+	if slices.EqualFunc(left, right, sameToken) && !slices.ContainsFunc(left, func(t ottlToken) bool { return t.text == "(" }) {
+		return op == "==" || op == "<=" || op == ">=", true
+	}
+	return false, false
+}
+
+// isLiteral reports whether an expression is a single literal value
+func isLiteral(expr []ottlToken) bool {
+	if len(expr) != 1 {
+		return false
+	}
+	switch expr[0].kind {
+	case tokenString, tokenNumber, tokenBytes:
+		return true
+	case tokenWord:
+		return expr[0].text == "true" || expr[0].text == "false" || expr[0].text == "nil"
+	default:
+		return false
+	}
+}
+
+// compareLiterals evaluates a comparison of two literals the way OTTL does, where values of
+// different types are never equal
+func compareLiterals(left, right ottlToken, op string) (bool, bool) {
+	cmp := 0
+	switch {
+	case left.kind == tokenNumber && right.kind == tokenNumber:
+		l, errL := strconv.ParseFloat(left.text, 64)
+		r, errR := strconv.ParseFloat(right.text, 64)
+		if errL != nil || errR != nil {
+			return false, false
+		}
+		cmp = compareValues(l, r)
+	case left.kind == tokenString && right.kind == tokenString:
+		l, errL := strconv.Unquote(left.text)
+		r, errR := strconv.Unquote(right.text)
+		if errL != nil || errR != nil {
+			return false, false
+		}
+		cmp = strings.Compare(l, r)
+	case op == "==" || op == "!=":
+		equal := left.kind == right.kind && strings.EqualFold(left.text, right.text)
+		return equal == (op == "=="), true
+	default:
+		return false, false
+	}
+
+	switch op {
+	case "==":
+		return cmp == 0, true
+	case "!=":
+		return cmp != 0, true
+	case "<":
+		return cmp < 0, true
+	case "<=":
+		return cmp <= 0, true
+	case ">":
+		return cmp > 0, true
+	default:
+		return cmp >= 0, true
+	}
+}
+
+// compareValues returns -1, 0 or 1 as a is less than, equal to or greater than b
+func compareValues(a, b float64) int {
+	switch {
+	case a < b:
+		return -1
+	case a > b:
+		return 1
+	default:
+		return 0
+	}
+}
+
+// setRecord is a key set by an earlier statement
+type setRecord struct {
+	key  string
+	line int
+}
+
+// keyRemovers are the editors that remove keys from a map, with the way they select keys
+var keyRemovers = map[string]func(arg []ottlToken) (func(key string) bool, bool){
+	"delete_key": func(arg []ottlToken) (func(string) bool, bool) {
+		key, ok := stringLiteral(arg)
+		return func(k string) bool { return k == key }, ok
+	},
+	"delete_matching_keys": func(arg []ottlToken) (func(string) bool, bool) {
+		re, ok := literalRegexp(arg)
+		return func(k string) bool { return ok && re.MatchString(k) }, ok
+	},
+	"keep_keys": func(arg []ottlToken) (func(string) bool, bool) {
+		keys, ok := stringListLiteral(arg)
+		return func(k string) bool { return !slices.Contains(keys, k) }, ok
+	},
+	"keep_matching_keys": func(arg []ottlToken) (func(string) bool, bool) {
+		re, ok := literalRegexp(arg)
+		return func(k string) bool { return ok && !re.MatchString(k) }, ok
+	},
+}
+
+// setThenDeleteIssues records the keys set by a statement and reports those an unconditional
+// key removal drops again, which is usually a leftover of an edit
+func setThenDeleteIssues(s lintStatement, tokens []ottlToken, sets map[string][]setRecord) []lintIssue {
+	paths := statementPaths(tokens)
+	target, ok := editorTarget(s, tokens, paths)
+	if !ok {
+		return nil
+	}
+	args := callArgs(tokens, 0)
+	line, _ := s.position(0, 0)
+
+	if tokens[0].text == "set" && len(target.keys) > 0 {
+		mapPath := qualifiedMapPath(s, target.path, target.keys[:len(target.keys)-1])
+		sets[mapPath] = append(sets[mapPath], setRecord{key: target.keys[len(target.keys)-1], line: line})
+		return nil
+	}
+
+	selector, ok := keyRemovers[tokens[0].text]
+	if !ok || len(args) < 2 || whereIndex(tokens) >= 0 {
+		return nil
+	}
+	removes, ok := selector(args[1])
+	if !ok {
+		return nil
+	}
+	mapPath := qualifiedMapPath(s, target.path, target.keys)
+	var issues []lintIssue
+	var kept []setRecord
+	for _, record := range sets[mapPath] {
+		if removes(record.key) {
+			issues = append(issues, lintIssue{rule: ruleSetThenDelete, offset: tokens[0].offset, message: fmt.Sprintf("%s[%q] is set on line %d and removed here", target.path, record.key, record.line)})
+		} else {
+			kept = append(kept, record)
+		}
+	}
+	sets[mapPath] = kept
+	return issues
+}
+
+// qualifiedMapPath names a map the same way whether or not its path names the context
+func qualifiedMapPath(s lintStatement, path string, keys []string) string {
+	if name, _, _ := strings.Cut(path, "."); !contextNames[name] && len(s.contexts) == 1 {
+		path = s.contexts[0].String() + "." + path
+	}
+	for _, key := range keys {
+		path += fmt.Sprintf("[%q]", key)
+	}
+	return path
+}
+
+// stringLiteral returns the value of an argument that is a string literal
+func stringLiteral(arg []ottlToken) (string, bool) {
+	if len(arg) != 1 || arg[0].kind != tokenString {
+		return "", false
+	}
+	value, err := strconv.Unquote(arg[0].text)
+	return value, err == nil
+}
+
+// literalRegexp compiles an argument that is a string literal
+func literalRegexp(arg []ottlToken) (*regexp.Regexp, bool) {
+	pattern, ok := stringLiteral(arg)
+	if !ok {
+		return nil, false
+	}
+	re, err := regexp.Compile(pattern)
+	return re, err == nil
+}
+
+// stringListLiteral returns the values of an argument that is a list of string literals
+func stringListLiteral(arg []ottlToken) ([]string, bool) {
+	if len(arg) < 2 || arg[0].text != "[" || arg[len(arg)-1].text != "]" {
+		return nil, false
+	}
+	var values []string
+	for _, item := range splitTopLevel(arg[1:len(arg)-1], func(t ottlToken) bool { return t.text == "," }) {
+		if len(item) == 0 {
+			continue
+		}
+		value, ok := stringLiteral(item)
+		if !ok {
+			return nil, false
+		}
+		values = append(values, value)
+	}
+	return values, true
+}
+
+// lintIgnores holds the rules disabled by suppression comments; the empty string disables all
+type lintIgnores map[string]bool
+
+// has reports whether a rule is disabled
+func (ignores lintIgnores) has(rule lintRule) bool {
+	return ignores[""] || ignores[rule.id] || ignores[rule.name]
+}
+
+var lintIgnorePattern = regexp.MustCompile(`#\s*ottl-lint-ignore(-file)?\b([^#\n]*)`)
+
+// parseLintIgnores reads the rules disabled by the ottl-lint-ignore comments, or by the
+// ottl-lint-ignore-file comments when file is set. Comment text may span several lines.
+// Only rule IDs and names count, so the reason may follow them; a comment without any
+// disables all rules.
+func parseLintIgnores(comments []string, file bool) lintIgnores {
+	ignores := lintIgnores{}
+	for _, comment := range comments {
+		for _, match := range lintIgnorePattern.FindAllStringSubmatch(comment, -1) {
+			if (match[1] != "") != file {
+				continue
+			}
+			found := false
+			for _, token := range strings.FieldsFunc(match[2], func(r rune) bool { return r == ',' || unicode.IsSpace(r) }) {
+				if slices.ContainsFunc(lintRules, func(rule lintRule) bool { return token == rule.id || token == rule.name }) {
+					ignores[token] = true
+					found = true
+				}
+			}
+			if !found {
+				ignores[""] = true
+			}
+		}
+	}
+	return ignores
+}
@@ -0,0 +1,176 @@
+package main
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// findingIDs summarizes findings as line:column:rule
+func findingIDs(findings []lintFinding) []string {
+	ids := []string{}
+	for _, f := range findings {
+		ids = append(ids, fmt.Sprintf("%d:%d:%s", f.line, f.column, f.rule.id))
+	}
+	return ids
+}
+
+func TestLintOTTLSource(t *testing.T) {
+	tests := []struct {
+		name     string
+		source   string
+		expected []string
+	}{
+		{
+			name:     "clean statements",
+			source:   "set(span.attributes[\"env\"], \"dev\") where span.name != \"\"\ndelete_key(span.attributes, \"tmp\")\n",
+			expected: []string{},
+		},
+		{
+			name:     "invalid statement",
+			source:   `set(span.nme, "x")`,
+			expected: []string{"1:5:OTTL001"},
+		},
+		{
+			name:     "set then delete",
+			source:   "set(span.attributes[\"tmp\"], 1)\nset(span.attributes[\"keep\"], 1)\nkeep_keys(span.attributes, [\"keep\"])\n",
+			expected: []string{"3:1:OTTL002"},
+		},
+		{
+			name:     "conditional delete",
+			source:   "set(span.attributes[\"tmp\"], 1)\ndelete_key(span.attributes, \"tmp\") where span.name == \"a\"\n",
+			expected: []string{},
+		},
+		{
+			name:     "set then delete matching keys",
+			source:   "set(span.attributes[\"tmp.a\"], 1)\ndelete_matching_keys(span.attributes, \"^tmp\\\\.\")\n",
+			expected: []string{"2:1:OTTL002"},
+		},
+		{
+			name:     "constant conditions",
+			source:   "set(span.name, \"a\") where true\nset(span.name, \"a\") where span.name == \"x\" or (1 > 2)\nset(span.name, \"a\") where not span.name == span.name\n",
+			expected: []string{"1:27:OTTL003", "2:48:OTTL003", "3:27:OTTL003"},
+		},
+		{
+			name:     "deprecated path",
+			source:   `set(span.attributes["kind"], span.kind.deprecated_string)`,
+			expected: []string{"1:30:OTTL004"},
+		},
+		{
+			name:     "invalid regex",
+			source:   "set(span.name, \"a\")\n    where IsMatch(span.name, \"(a\")",
+			expected: []string{"2:30:OTTL005"},
+		},
+		{
+			name:     "read-only path",
+			source:   `set(metric.type, 1)`,
+			expected: []string{"1:5:OTTL006"},
+		},
+		{
+			name:     "ambiguous path",
+			source:   `set(attributes["env"], "dev")`,
+			expected: []string{"1:5:OTTL007"},
+		},
+		{
+			name:     "suppressed by ID and name",
+			source:   "# ottl-lint-ignore OTTL003,ambiguous-path\nset(attributes[\"env\"], \"dev\") where true\n\nset(attributes[\"env\"], \"dev\") where true\n",
+			expected: []string{"4:5:OTTL007", "4:37:OTTL003"},
+		},
+		{
+			name:     "suppressed with spaces after commas",
+			source:   "# ottl-lint-ignore OTTL004, OTTL006\nset(metric.type, 1) where true\n",
+			expected: []string{"2:27:OTTL003"},
+		},
+		{
+			name:     "suppressed without a blank line before the comment",
+			source:   "set(span.name, \"a\")\n# ottl-lint-ignore ambiguous-path\nset(attributes[\"env\"], \"dev\")\nset(attributes[\"env\"], \"dev\")\n",
+			expected: []string{"4:5:OTTL007"},
+		},
+		{
+			name:     "suppressed for the file",
+			source:   "# ottl-lint-ignore-file OTTL007\nset(attributes[\"env\"], \"dev\")\n",
+			expected: []string{},
+		},
+		{
+			name:     "suppressed with a reason",
+			source:   "# ottl-lint-ignore OTTL003 kept while the attribute is rolled out\nset(attributes[\"env\"], \"dev\") where true\n",
+			expected: []string{"2:5:OTTL007"},
+		},
+		{
+			name:     "suppressed entirely by a comment without rules",
+			source:   "# ottl-lint-ignore legacy rule, remove later\nset(attributes[\"env\"], \"dev\") where true\n",
+			expected: []string{},
+		},
+		{
+			name:     "suppressed entirely",
+			source:   "# ottl-lint-ignore\nset(metric.type, 1) where true\n",
+			expected: []string{},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			findings, err := lintSource("rules.ottl", []byte(test.source))
+			require.NoError(t, err)
+			assert.Equal(t, test.expected, findingIDs(findings))
+		})
+	}
+}
+
+func TestLintCollectorConfig(t *testing.T) {
+	config := `processors:
+  transform:
+    trace_statements:
+      - context: span
+        statements:
+          - set(attributes["a"], "b") where "x" == "x"
+          - keep_keys(attributes, ["c"])
+      - context: resource
+        statements:
+          - set(attributes["a"], "b")
+    log_statements:
+      - set(log.attributes["a"], "b")
+  filter:
+    logs:
+      # ottl-lint-ignore constant-condition
+      log_record:
+        - 'true'
+    traces:
+      span:
+        - IsMatch(name, "[")
+`
+	findings, err := lintSource("collector.yaml", []byte(config))
+	require.NoError(t, err)
+	assert.Equal(t, []string{"6:45:OTTL003", "7:13:OTTL002", "20:25:OTTL005"}, findingIDs(findings))
+
+	_, err = lintSource("collector.yaml", []byte("processors: ["))
+	assert.Error(t, err)
+}
+
+func TestConstantValue(t *testing.T) {
+	tests := []struct {
+		expr     string
+		value    bool
+		constant bool
+	}{
+		{expr: `false`, value: false, constant: true},
+		{expr: `"a" != "b"`, value: true, constant: true},
+		{expr: `"1" == 1`, value: false, constant: true},
+		{expr: `2.5 >= 3`, value: false, constant: true},
+		{expr: `nil == nil`, value: true, constant: true},
+		{expr: `attributes["a"] < attributes["a"]`, value: false, constant: true},
+		{expr: `Now() == Now()`},
+		{expr: `name == "a"`},
+		{expr: `1 + 1 == 2`},
+	}
+
+	for _, test := range tests {
+		tokens, err := tokenizeStatement(test.expr)
+		require.NoError(t, err)
+		value, constant := constantValue(tokens)
+		assert.Equal(t, test.constant, constant, test.expr)
+		assert.Equal(t, test.value, value, test.expr)
+	}
+}
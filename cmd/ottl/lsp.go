@@ -0,0 +1,596 @@
+// Copyright 2025 Dose de Telemetria GmbH
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/textproto"
+	"os"
+	"reflect"
+	"regexp"
+	"strconv"
+	"strings"
+	"unicode"
+	"unicode/utf16"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl/contexts/ottllog"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl/ottlfuncs"
+	"github.com/spf13/cobra"
+	"github.com/telemetrydrops/ottl-cli/pkg/functions"
+)
+
+var lspCmd = &cobra.Command{
+	Use:   "lsp",
+	Short: "Run a language server for .ottl files",
+	Long: `Runs a Language Server Protocol server on stdin and stdout for editors.
+
+The server reports parse errors of every statement as diagnostics, completes
+function names and context paths, and shows signatures and path types on hover.
+
+In a .ottl file, each statement starts at the beginning of a line and may continue
+on the following indented lines, or on any line while a bracket is open. Lines
+starting with # are comments. The context of a statement is taken from its paths
+(span., log., metric. or datapoint.); statements with unqualified paths are
+accepted when any context can parse them.`,
+	Example: `  # Neovim
+  vim.lsp.start({ name = "ottl", cmd = { "ottl", "lsp" } })`,
+	Args: cobra.NoArgs,
+	RunE: runLSP,
+}
+
+func init() {
+	rootCmd.AddCommand(lspCmd)
+}
+
+// runLSP executes the lsp command
+func runLSP(cmd *cobra.Command, args []string) error {
+	return newLSPServer(os.Stdin, os.Stdout).run()
+}
+
+// JSON-RPC error codes used by the server
+const (
+	lspMethodNotFound = -32601
+	lspInvalidParams  = -32602
+)
+
+// LSP completion item kinds and diagnostic severities used by the server
+const (
+	completionKindFunction = 3
+	completionKindField    = 5
+	completionKindKeyword  = 14
+	diagnosticError        = 1
+)
+
+// lspRequest is an incoming request or notification
+type lspRequest struct {
+	ID     *json.RawMessage `json:"id"`
+	Method string           `json:"method"`
+	Params json.RawMessage  `json:"params"`
+}
+
+// lspResponseError is the error member of a response
+type lspResponseError struct {
+	Code    int    `json:"code"`
+	Message string `json:"message"`
+}
+
+type lspPosition struct {
+	Line      int `json:"line"`
+	Character int `json:"character"`
+}
+
+type lspRange struct {
+	Start lspPosition `json:"start"`
+	End   lspPosition `json:"end"`
+}
+
+type lspDiagnostic struct {
+	Range    lspRange `json:"range"`
+	Severity int      `json:"severity"`
+	Source   string   `json:"source"`
+	Message  string   `json:"message"`
+}
+
+type lspMarkup struct {
+	Kind  string `json:"kind"`
+	Value string `json:"value"`
+}
+
+type lspTextEdit struct {
+	Range   lspRange `json:"range"`
+	NewText string   `json:"newText"`
+}
+
+type lspCompletionItem struct {
+	Label         string       `json:"label"`
+	Kind          int          `json:"kind"`
+	Detail        string       `json:"detail,omitempty"`
+	Documentation *lspMarkup   `json:"documentation,omitempty"`
+	TextEdit      *lspTextEdit `json:"textEdit,omitempty"`
+}
+
+type lspHover struct {
+	Contents lspMarkup `json:"contents"`
+	Range    lspRange  `json:"range"`
+}
+
+type lspTextDocument struct {
+	URI  string `json:"uri"`
+	Text string `json:"text"`
+}
+
+type lspDocumentParams struct {
+	TextDocument   lspTextDocument `json:"textDocument"`
+	ContentChanges []struct {
+		Text string `json:"text"`
+	} `json:"contentChanges"`
+	Position lspPosition `json:"position"`
+}
+
+// lspServer serves one editor session, keeping the text of every open document
+type lspServer struct {
+	in        *bufio.Reader
+	out       io.Writer
+	documents map[string]string
+	functions map[contextType]map[string]functionDoc
+	shutdown  bool
+}
+
+// newLSPServer creates a server reading requests from in and writing responses to out
+func newLSPServer(in io.Reader, out io.Writer) *lspServer {
+	return &lspServer{
+		in:        bufio.NewReader(in),
+		out:       out,
+		documents: make(map[string]string),
+		functions: map[contextType]map[string]functionDoc{
+			contextTypeSpan:      describeFunctions(functions.Span),
+			contextTypeLog:       describeFunctions(functions.Log),
+			contextTypeMetric:    describeFunctions(functions.Metric),
+			contextTypeDatapoint: describeFunctions(functions.DataPoint),
+			contextTypeProfile:   describeFunctions(functions.Profile),
+		},
+	}
+}
+
+// run handles messages until the client sends exit or closes the input
+func (s *lspServer) run() error {
+	for {
+		payload, err := readLSPMessage(s.in)
+		if errors.Is(err, io.EOF) {
+			return nil
+		}
+		if err != nil {
+			return fmt.Errorf("failed to read message: %w", err)
+		}
+
+		var req lspRequest
+		if err := json.Unmarshal(payload, &req); err != nil {
+			return fmt.Errorf("invalid message: %w", err)
+		}
+		if req.Method == "exit" {
+			if !s.shutdown {
+				return errors.New("exit without shutdown")
+			}
+			return nil
+		}
+		if err := s.handle(req); err != nil {
+			return err
+		}
+	}
+}
+
+// handle dispatches one request or notification
+func (s *lspServer) handle(req lspRequest) error {
+	var params lspDocumentParams
+	if len(req.Params) > 0 && req.Method != "initialize" {
+		if err := json.Unmarshal(req.Params, &params); err != nil {
+			return s.respondError(req.ID, lspInvalidParams, err.Error())
+		}
+	}
+
+	switch req.Method {
+	case "initialize":
+		return s.respond(req.ID, map[string]interface{}{
+			"capabilities": map[string]interface{}{
+				"textDocumentSync":   1, // full document on every change
+				"completionProvider": map[string]interface{}{"triggerCharacters": []string{"."}},
+				"hoverProvider":      true,
+			},
+			"serverInfo": map[string]string{"name": "ottl", "version": version},
+		})
+	case "shutdown":
+		s.shutdown = true
+		return s.respond(req.ID, nil)
+	case "textDocument/didOpen":
+		s.documents[params.TextDocument.URI] = params.TextDocument.Text
+		return s.publishDiagnostics(params.TextDocument.URI)
+	case "textDocument/didChange":
+		if n := len(params.ContentChanges); n > 0 {
+			s.documents[params.TextDocument.URI] = params.ContentChanges[n-1].Text
+		}
+		return s.publishDiagnostics(params.TextDocument.URI)
+	case "textDocument/didClose":
+		delete(s.documents, params.TextDocument.URI)
+		return s.notify("textDocument/publishDiagnostics", map[string]interface{}{
+			"uri":         params.TextDocument.URI,
+			"diagnostics": []lspDiagnostic{},
+		})
+	case "textDocument/completion":
+		return s.respond(req.ID, s.complete(s.documents[params.TextDocument.URI], params.Position))
+	case "textDocument/hover":
+		if hover := s.hover(s.documents[params.TextDocument.URI], params.Position); hover != nil {
+			return s.respond(req.ID, hover)
+		}
+		return s.respond(req.ID, nil)
+	default:
+		if req.ID != nil {
+			return s.respondError(req.ID, lspMethodNotFound, "method not supported: "+req.Method)
+		}
+		return nil // notifications such as initialized need no answer
+	}
+}
+
+// publishDiagnostics sends the parse errors of a document to the client
+func (s *lspServer) publishDiagnostics(uri string) error {
+	return s.notify("textDocument/publishDiagnostics", map[string]interface{}{
+		"uri":         uri,
+		"diagnostics": diagnoseDocument(s.documents[uri]),
+	})
+}
+
+// respond sends the result of a request
+func (s *lspServer) respond(id *json.RawMessage, result interface{}) error {
+	return writeLSPMessage(s.out, map[string]interface{}{"jsonrpc": "2.0", "id": id, "result": result})
+}
+
+// respondError sends an error answer to a request
+func (s *lspServer) respondError(id *json.RawMessage, code int, message string) error {
+	if id == nil {
+		return nil
+	}
+	return writeLSPMessage(s.out, map[string]interface{}{
+		"jsonrpc": "2.0",
+		"id":      id,
+		"error":   lspResponseError{Code: code, Message: message},
+	})
+}
+
+// notify sends a notification to the client
+func (s *lspServer) notify(method string, params interface{}) error {
+	return writeLSPMessage(s.out, map[string]interface{}{"jsonrpc": "2.0", "method": method, "params": params})
+}
+
+// readLSPMessage reads one message framed with a Content-Length header
+func readLSPMessage(r *bufio.Reader) ([]byte, error) {
+	header, err := textproto.NewReader(r).ReadMIMEHeader()
+	if err != nil {
+		if errors.Is(err, io.EOF) && len(header) == 0 {
+			return nil, io.EOF
+		}
+		return nil, err
+	}
+	length, err := strconv.Atoi(header.Get("Content-Length"))
+	if err != nil || length < 0 {
+		return nil, fmt.Errorf("invalid Content-Length %q", header.Get("Content-Length"))
+	}
+	payload := make([]byte, length)
+	if _, err := io.ReadFull(r, payload); err != nil {
+		return nil, err
+	}
+	return payload, nil
+}
+
+// writeLSPMessage writes one message framed with a Content-Length header
+func writeLSPMessage(w io.Writer, message interface{}) error {
+	payload, err := json.Marshal(message)
+	if err != nil {
+		return fmt.Errorf("failed to marshal message: %w", err)
+	}
+	if _, err := fmt.Fprintf(w, "Content-Length: %d\r\n\r\n", len(payload)); err != nil {
+		return err
+	}
+	_, err = w.Write(payload)
+	return err
+}
+
+// diagnoseDocument parses every statement of a document and reports the failures
+func diagnoseDocument(text string) []lspDiagnostic {
+	lines := strings.Split(text, "\n")
+	diagnostics := []lspDiagnostic{}
+	for _, statement := range splitStatements(text) {
+		ctx, err := checkStatement(statement.text)
+		if err == nil {
+			continue
+		}
+		span := locateError(statement.text, err)
+		line := lines[statement.line+span.line]
+		start := utf16Column(line, span.column)
+		end := utf16Column(line, span.column+span.length)
+		diagnostics = append(diagnostics, lspDiagnostic{
+			Range: lspRange{
+				Start: lspPosition{Line: statement.line + span.line, Character: start},
+				End:   lspPosition{Line: statement.line + span.line, Character: end},
+			},
+			Severity: diagnosticError,
+			Source:   "ottl",
+			Message:  parseErrorMessage(ctx, statement.text, err),
+		})
+	}
+	return diagnostics
+}
+
+var lspKeywords = []string{"where", "and", "or", "not", "true", "false", "nil"}
+
+// complete returns the functions, paths and keywords that can replace the word before the position
+func (s *lspServer) complete(text string, pos lspPosition) []lspCompletionItem {
+	lines := strings.Split(text, "\n")
+	items := []lspCompletionItem{}
+	if pos.Line >= len(lines) {
+		return items
+	}
+	line := lines[pos.Line]
+	cursor := byteOffset(line, pos.Character)
+	if strings.Count(line[:cursor], `"`)%2 == 1 {
+		return items // inside a string literal
+	}
+	start := cursor
+	for start > 0 && isPathByte(line[start-1]) {
+		start--
+	}
+	word := line[start:cursor]
+	replace := lspRange{Start: lspPosition{Line: pos.Line, Character: utf16Column(line, len([]rune(line[:start])))}, End: pos}
+	contexts := s.contextsAt(text, pos.Line)
+
+	for _, p := range pathsWithPrefix(word, contexts) {
+		items = append(items, lspCompletionItem{
+			Label:         p.path,
+			Kind:          completionKindField,
+			Detail:        p.typ,
+			Documentation: &lspMarkup{Kind: "markdown", Value: p.doc},
+			TextEdit:      &lspTextEdit{Range: replace, NewText: p.path},
+		})
+	}
+	if strings.Contains(word, ".") {
+		return items
+	}
+
+	for _, doc := range s.functionsFor(contexts) {
+		if strings.HasPrefix(strings.ToLower(doc.name), strings.ToLower(word)) {
+			items = append(items, lspCompletionItem{
+				Label:         doc.name,
+				Kind:          completionKindFunction,
+				Detail:        doc.signature,
+				Documentation: &lspMarkup{Kind: "markdown", Value: doc.markdown()},
+			})
+		}
+	}
+	for _, keyword := range lspKeywords {
+		if strings.HasPrefix(keyword, word) {
+			items = append(items, lspCompletionItem{Label: keyword, Kind: completionKindKeyword})
+		}
+	}
+	return items
+}
+
+// hover documents the path or function under the position
+func (s *lspServer) hover(text string, pos lspPosition) *lspHover {
+	lines := strings.Split(text, "\n")
+	if pos.Line >= len(lines) {
+		return nil
+	}
+	line := lines[pos.Line]
+	cursor := byteOffset(line, pos.Character)
+	start, end := cursor, cursor
+	for start > 0 && isPathByte(line[start-1]) {
+		start--
+	}
+	for end < len(line) && isPathByte(line[end]) {
+		end++
+	}
+	if start == end {
+		return nil
+	}
+
+	// Paths are documented up to the segment under the cursor
+	word := line[start:end]
+	if dot := strings.IndexByte(line[cursor:end], '.'); dot >= 0 {
+		word = line[start : cursor+dot]
+	}
+	hoverRange := lspRange{
+		Start: lspPosition{Line: pos.Line, Character: utf16Column(line, len([]rune(line[:start])))},
+		End:   lspPosition{Line: pos.Line, Character: utf16Column(line, len([]rune(line[:start+len(word)])))},
+	}
+
+	contexts := s.contextsAt(text, pos.Line)
+	if p, ok := lookupPath(word, contexts); ok {
+		value := fmt.Sprintf("```\n%s %s\n```\n%s", p.path, p.typ, p.doc)
+		if p.readOnly {
+			value += "\n\nRead-only: setting it has no effect."
+		}
+		if p.deprecated != "" {
+			value += fmt.Sprintf("\n\nDeprecated: use `%s` instead.", p.deprecated)
+		}
+		return &lspHover{Range: hoverRange, Contents: lspMarkup{Kind: "markdown", Value: value}}
+	}
+	for _, doc := range s.functionsFor(contexts) {
+		if doc.name == word {
+			return &lspHover{Range: hoverRange, Contents: lspMarkup{Kind: "markdown", Value: doc.markdown()}}
+		}
+	}
+	return nil
+}
+
+// contextsAt returns the possible contexts of the statement on a line
+func (s *lspServer) contextsAt(text string, line int) []contextType {
+	statements := splitStatements(text)
+	for i := len(statements) - 1; i >= 0; i-- {
+		if statements[i].line <= line {
+			if line <= statements[i].line+strings.Count(statements[i].text, "\n") {
+				return statementContexts(statements[i].text)
+			}
+			break
+		}
+	}
+	return allContexts
+}
+
+// functionsFor returns the distinct functions of the given contexts, sorted by name
+func (s *lspServer) functionsFor(contexts []contextType) []functionDoc {
+	docs := make(map[string]functionDoc)
+	for _, ctx := range contexts {
+		for name, doc := range s.functions[ctx] {
+			docs[name] = doc
+		}
+	}
+	result := make([]functionDoc, 0, len(docs))
+	for _, name := range sortedKeys(docs) {
+		result = append(result, docs[name])
+	}
+	return result
+}
+
+// isPathByte reports whether b can be part of a function name or path
+func isPathByte(b byte) bool {
+	return b == '_' || b == '.' || b >= '0' && b <= '9' || b >= 'a' && b <= 'z' || b >= 'A' && b <= 'Z'
+}
+
+// utf16Column converts a rune column of a line to the UTF-16 column LSP positions use
+func utf16Column(line string, runeColumn int) int {
+	column := 0
+	for i, r := range []rune(line) {
+		if i >= runeColumn {
+			break
+		}
+		column += len(utf16.Encode([]rune{r}))
+	}
+	return column
+}
+
+// byteOffset converts an LSP UTF-16 column to a byte offset in the line
+func byteOffset(line string, character int) int {
+	column := 0
+	for i, r := range line {
+		if column >= character {
+			return i
+		}
+		column += len(utf16.Encode([]rune{r}))
+	}
+	return len(line)
+}
+
+const (
+	ottlFuncsDocs        = "https://github.com/open-telemetry/opentelemetry-collector-contrib/blob/main/pkg/ottl/ottlfuncs/README.md#"
+	transformProcessDocs = "https://github.com/open-telemetry/opentelemetry-collector-contrib/blob/main/processor/transformprocessor/README.md#"
+)
+
+// functionDoc describes an OTTL function for completion and hover
+type functionDoc struct {
+	name      string
+	signature string
+	editor    bool
+	docsURL   string
+}
+
+// markdown renders the documentation of a function
+func (d functionDoc) markdown() string {
+	kind := "converter"
+	if d.editor {
+		kind = "editor"
+	}
+	doc := fmt.Sprintf("```\n%s\n```\nOTTL %s", d.signature, kind)
+	if d.docsURL != "" {
+		doc += fmt.Sprintf(" ([documentation](%s))", d.docsURL)
+	}
+	return doc
+}
+
+// describeFunctions documents the functions of a factory map from their argument structs
+func describeFunctions[K any](factories map[string]ottl.Factory[K]) map[string]functionDoc {
+	docs := make(map[string]functionDoc, len(factories))
+	for name, factory := range factories {
+		docs[name] = describeFunction(name, factory.CreateDefaultArguments())
+	}
+	return docs
+}
+
+// describeFunction builds the documentation of a function. Argument names follow the
+// snake_case names OTTL accepts for named arguments.
+func describeFunction(name string, args ottl.Arguments) functionDoc {
+	doc := functionDoc{name: name, editor: unicode.IsLower([]rune(name)[0])}
+	var params []string
+	var pkgPath string
+	if t := reflect.TypeOf(args); t != nil {
+		if t.Kind() == reflect.Pointer {
+			t = t.Elem()
+		}
+		pkgPath = t.PkgPath()
+		if t.Kind() == reflect.Struct {
+			for i := 0; i < t.NumField(); i++ {
+				field := t.Field(i)
+				params = append(params, snakeCase(field.Name)+" "+simplifyTypeName(field.Type.String()))
+			}
+		}
+	}
+	doc.signature = fmt.Sprintf("%s(%s)", name, strings.Join(params, ", "))
+
+	switch {
+	case standardFunctions[name] || strings.HasSuffix(pkgPath, "/pkg/ottl/ottlfuncs"):
+		doc.docsURL = ottlFuncsDocs + strings.ToLower(name)
+	case strings.HasSuffix(pkgPath, "/ottl-cli/pkg/functions"):
+		doc.docsURL = transformProcessDocs + strings.ToLower(name)
+	}
+	return doc
+}
+
+// standardFunctions holds the names of the functions documented in the ottlfuncs README,
+// for functions without arguments whose package cannot be told from their argument struct
+var standardFunctions = func() map[string]bool {
+	names := map[string]bool{ottlfuncs.NewIsRootSpanFactory().Name(): true}
+	for name := range ottlfuncs.StandardFuncs[ottllog.TransformContext]() {
+		names[name] = true
+	}
+	return names
+}()
+
+var (
+	typePackagePattern = regexp.MustCompile(`[\w.\-]+/`)
+	contextTypePattern = regexp.MustCompile(`ottl\w+\.TransformContext`)
+)
+
+// simplifyTypeName shortens argument types such as
+// ottl.Optional[ottl.StringGetter[github.com/.../ottlspan.TransformContext]] to Optional[StringGetter[K]]
+func simplifyTypeName(name string) string {
+	name = typePackagePattern.ReplaceAllString(name, "")
+	name = contextTypePattern.ReplaceAllString(name, "K")
+	return strings.ReplaceAll(name, "ottl.", "")
+}
+
+// snakeCase converts a Go field name such as RegexPattern to regex_pattern
+func snakeCase(name string) string {
+	runes := []rune(name)
+	var b strings.Builder
+	for i, r := range runes {
+		if unicode.IsUpper(r) && i > 0 && (unicode.IsLower(runes[i-1]) || unicode.IsDigit(runes[i-1]) || i+1 < len(runes) && unicode.IsLower(runes[i+1])) {
+			b.WriteByte('_')
+		}
+		b.WriteRune(unicode.ToLower(r))
+	}
+	return b.String()
+}
@@ -0,0 +1,137 @@
+package main
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/telemetrydrops/ottl-cli/pkg/functions"
+)
+
+const lspTestDocument = `# spans
+set(span.name, "x")
+set(span.attributes["é"], Sett(1))
+set(log.severity_text, "WARN")
+    where log.severity_number >
+`
+
+func TestDiagnoseDocument(t *testing.T) {
+	diagnostics := diagnoseDocument(lspTestDocument)
+	require.Len(t, diagnostics, 2)
+
+	assert.Equal(t, lspRange{Start: lspPosition{Line: 2, Character: 26}, End: lspPosition{Line: 2, Character: 30}}, diagnostics[0].Range)
+	assert.Equal(t, `error while parsing arguments for call to "set": invalid argument at position 1: undefined function "Sett"`, diagnostics[0].Message)
+	assert.Equal(t, diagnosticError, diagnostics[0].Severity)
+
+	assert.Equal(t, 4, diagnostics[1].Range.Start.Line)
+	assert.Contains(t, diagnostics[1].Message, "unexpected token")
+
+	assert.Empty(t, diagnoseDocument("set(attributes[\"env\"], \"dev\")\n"))
+}
+
+func TestLSPComplete(t *testing.T) {
+	s := newLSPServer(strings.NewReader(""), &bytes.Buffer{})
+	labels := func(items []lspCompletionItem) []string {
+		result := make([]string, 0, len(items))
+		for _, item := range items {
+			result = append(result, item.Label)
+		}
+		return result
+	}
+
+	// Paths of the statement's context after a dot
+	items := s.complete("set(span.st", lspPosition{Line: 0, Character: 11})
+	assert.ElementsMatch(t, []string{"span.status", "span.status.code", "span.status.message", "span.start_time_unix_nano", "span.start_time"}, labels(items))
+	require.NotNil(t, items[0].TextEdit)
+	assert.Equal(t, lspRange{Start: lspPosition{Character: 4}, End: lspPosition{Character: 11}}, items[0].TextEdit.Range)
+
+	// Functions and keywords
+	items = s.complete("set(log.body, Sub", lspPosition{Line: 0, Character: 17})
+	assert.Contains(t, labels(items), "Substring")
+	items = s.complete(`set(log.body, "x") wh`, lspPosition{Line: 0, Character: 21})
+	assert.Equal(t, []string{"where"}, labels(items))
+
+	// Metric-only functions are offered in metric statements only
+	assert.Contains(t, labels(s.complete("set(metric.name, \"x\")\nconvert_", lspPosition{Line: 1, Character: 8})), "convert_sum_to_gauge")
+	assert.NotContains(t, labels(s.complete("set(log.body, \"x\")\n  where convert_", lspPosition{Line: 1, Character: 16})), "convert_sum_to_gauge")
+
+	// Nothing inside string literals
+	assert.Empty(t, s.complete(`set(span.name, "Sub`, lspPosition{Line: 0, Character: 19}))
+}
+
+func TestLSPHover(t *testing.T) {
+	s := newLSPServer(strings.NewReader(""), &bytes.Buffer{})
+
+	hover := s.hover(`set(span.status.code, 1)`, lspPosition{Line: 0, Character: 11})
+	require.NotNil(t, hover)
+	assert.Contains(t, hover.Contents.Value, "span.status ptrace.Status")
+	assert.Equal(t, lspRange{Start: lspPosition{Character: 4}, End: lspPosition{Character: 15}}, hover.Range)
+
+	hover = s.hover(`replace_pattern(span.name, "a", "b")`, lspPosition{Line: 0, Character: 3})
+	require.NotNil(t, hover)
+	assert.Contains(t, hover.Contents.Value, "replace_pattern(target GetSetter[K], regex_pattern string, replacement StringGetter[K], function Optional[FunctionGetter[K]], replacement_format Optional[StringGetter[K]])")
+	assert.Contains(t, hover.Contents.Value, "OTTL editor")
+	assert.Contains(t, hover.Contents.Value, "ottlfuncs/README.md#replace_pattern")
+
+	assert.Nil(t, s.hover(`set(span.name, "x")`, lspPosition{Line: 0, Character: 14}))
+}
+
+func TestDescribeFunction(t *testing.T) {
+	docs := describeFunctions(functions.Metric)
+	assert.Equal(t, "convert_sum_to_gauge()", docs["convert_sum_to_gauge"].signature)
+	assert.Contains(t, docs["scale_metric"].docsURL, "transformprocessor/README.md#scale_metric")
+	assert.Contains(t, describeFunctions(functions.Span)["IsRootSpan"].docsURL, "ottlfuncs/README.md#isrootspan")
+	assert.False(t, docs["Concat"].editor)
+
+	assert.Equal(t, "regex_pattern", snakeCase("RegexPattern"))
+	assert.Equal(t, "id_url", snakeCase("IDUrl"))
+}
+
+func TestLSPSession(t *testing.T) {
+	var in bytes.Buffer
+	send := func(message string) {
+		_, _ = fmt.Fprintf(&in, "Content-Length: %d\r\n\r\n%s", len(message), message)
+	}
+	send(`{"jsonrpc":"2.0","id":1,"method":"initialize","params":{"capabilities":{}}}`)
+	send(`{"jsonrpc":"2.0","method":"initialized","params":{}}`)
+	send(`{"jsonrpc":"2.0","method":"textDocument/didOpen","params":{"textDocument":{"uri":"file:///a.ottl","text":"set(span.nme, \"x\")"}}}`)
+	send(`{"jsonrpc":"2.0","method":"textDocument/didChange","params":{"textDocument":{"uri":"file:///a.ottl"},"contentChanges":[{"text":"set(span.name, \"x\")"}]}}`)
+	send(`{"jsonrpc":"2.0","id":2,"method":"textDocument/hover","params":{"textDocument":{"uri":"file:///a.ottl"},"position":{"line":0,"character":10}}}`)
+	send(`{"jsonrpc":"2.0","id":3,"method":"textDocument/definition","params":{}}`)
+	send(`{"jsonrpc":"2.0","id":4,"method":"shutdown"}`)
+	send(`{"jsonrpc":"2.0","method":"exit"}`)
+
+	var out bytes.Buffer
+	require.NoError(t, newLSPServer(&in, &out).run())
+
+	reader := bufio.NewReader(&out)
+	var messages []map[string]interface{}
+	for {
+		payload, err := readLSPMessage(reader)
+		if err != nil {
+			break
+		}
+		var message map[string]interface{}
+		require.NoError(t, json.Unmarshal(payload, &message))
+		messages = append(messages, message)
+	}
+	require.Len(t, messages, 6)
+
+	capabilities := messages[0]["result"].(map[string]interface{})["capabilities"].(map[string]interface{})
+	assert.Equal(t, true, capabilities["hoverProvider"])
+	assert.Len(t, messages[1]["params"].(map[string]interface{})["diagnostics"], 1)
+	assert.Empty(t, messages[2]["params"].(map[string]interface{})["diagnostics"])
+	assert.Contains(t, messages[3]["result"].(map[string]interface{})["contents"].(map[string]interface{})["value"], "span.name string")
+	assert.Equal(t, float64(lspMethodNotFound), messages[4]["error"].(map[string]interface{})["code"])
+	assert.Nil(t, messages[5]["result"])
+
+	// Exiting without shutdown is an error
+	in.Reset()
+	send(`{"jsonrpc":"2.0","method":"exit"}`)
+	assert.Error(t, newLSPServer(&in, &out).run())
+}
@@ -16,7 +16,10 @@ package main
 
 import (
 	"bufio"
+	"bytes"
 	"context"
+	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"os"
@@ -25,12 +28,15 @@ import (
 	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl/contexts/ottldatapoint"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl/contexts/ottllog"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl/contexts/ottlmetric"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl/contexts/ottlprofile"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl/contexts/ottlspan"
-	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl/ottlfuncs"
 	"github.com/spf13/cobra"
+	"github.com/telemetrydrops/ottl-cli/pkg/functions"
 	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/pdata/pcommon"
 	"go.opentelemetry.io/collector/pdata/plog"
 	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.opentelemetry.io/collector/pdata/pprofile"
 	"go.opentelemetry.io/collector/pdata/ptrace"
 )
 
@@ -46,7 +52,10 @@ var transformCmd = &cobra.Command{
 	Short: "Apply OTTL transformation to OTLP data",
 	Long: `Reads OTTL statement from stdin and applies it to OTLP JSON data
 in the specified input file. Supports traces, logs, and metrics with automatic
-context detection. Outputs transformed OTLP JSON to stdout.`,
+context detection. Outputs transformed OTLP JSON to stdout.
+
+With --watch, the transformation is rerun and its result printed again every
+time the statement file or the input file changes on disk.`,
 	Example: `  # Transform spans (auto-detected)
   echo 'set(attributes["env"], "prod")' | ottl transform --input-file spans.json
 
@@ -59,8 +68,17 @@ context detection. Outputs transformed OTLP JSON to stdout.`,
   # Force specific context
   echo 'set(datapoint.value_double, 0)' | ottl transform --input-file metrics.json --context datapoint
 
+  # Transform profiles (auto-detected)
+  echo 'set(profile.attributes["env"], "prod")' | ottl transform --input-file profiles.json
+
   # From file
-  cat transform.ottl | ottl transform --input-file /path/to/data.json`,
+  cat transform.ottl | ottl transform --input-file /path/to/data.json
+
+  # Review the effect on spans as a table
+  echo 'set(span.attributes["env"], "prod")' | ottl transform -i spans.json --output-format table --columns 'span.name,span.attributes'
+
+  # Rerun whenever the statement or the input file changes
+  ottl transform --statement-file transform.ottl --input-file spans.json --watch`,
 	RunE: runTransform,
 }
 
@@ -73,6 +91,7 @@ const (
 	contextTypeLog
 	contextTypeMetric
 	contextTypeDatapoint
+	contextTypeProfile
 )
 
 func (c contextType) String() string {
@@ -85,6 +104,8 @@ func (c contextType) String() string {
 		return "metric"
 	case contextTypeDatapoint:
 		return "datapoint"
+	case contextTypeProfile:
+		return "profile"
 	default:
 		return "unknown"
 	}
@@ -92,66 +113,216 @@ func (c contextType) String() string {
 
 var inputFile string
 var contextFlag string
+var signalFlag string
+var statementFile string
+var watchFlag bool
+var errorFormat string
+var rotatedFlag bool
+var outputFormat string
+var columnsFlag string
+var strictFlag bool
 
 func init() {
 	transformCmd.Flags().StringVarP(&inputFile, "input-file", "i", "", "Path to OTLP JSON input file (required)")
-	transformCmd.Flags().StringVar(&contextFlag, "context", "", "Force specific OTTL context (span, log, metric, datapoint)")
+	transformCmd.Flags().StringVar(&contextFlag, "context", "", "Force specific OTTL context ("+validContexts()+")")
+	transformCmd.Flags().StringVar(&signalFlag, "signal", "", "Signal of the input, instead of detecting it (traces, logs, metrics, profiles)")
+	transformCmd.Flags().StringVar(&statementFile, "statement-file", "", "Read the OTTL statements from a file instead of stdin")
+	transformCmd.Flags().BoolVarP(&watchFlag, "watch", "w", false, "Rerun whenever the statement file or the input file changes")
+	transformCmd.Flags().BoolVar(&rotatedFlag, "rotated", false, "Also read the files the file exporter rotated out of the input file, oldest first")
+	transformCmd.Flags().StringVar(&errorFormat, "error-format", "text", "Format of errors on stderr (text, json)")
+	transformCmd.Flags().StringVar(&outputFormat, "output-format", "json", "Format of the output (json, table, csv)")
+	transformCmd.Flags().StringVar(&columnsFlag, "columns", "", `Columns of table and csv output, such as span.name,span.attributes["http.method"]; a map such as span.attributes selects all its entries`)
+	transformCmd.Flags().BoolVar(&strictFlag, "strict", false, "Validate the input against the OTLP schema before transforming, reporting unknown fields and invalid values")
 	_ = transformCmd.MarkFlagRequired("input-file")
 	rootCmd.AddCommand(transformCmd)
 }
 
 func main() {
 	if err := rootCmd.Execute(); err != nil {
-		os.Exit(1)
+		os.Exit(exitCode(err))
 	}
 }
 
 // runTransform executes the transform command
 func runTransform(cmd *cobra.Command, args []string) error {
-	// 1. Read OTTL statement from stdin
-	ottlStatement, err := readStdin()
+	if errorFormat != "text" && errorFormat != "json" {
+		return fmt.Errorf("invalid --error-format %q (valid: text, json)", errorFormat)
+	}
+	var err error
+	switch outputFormat {
+	case "json":
+		if columnsFlag != "" {
+			return errors.New("--columns requires --output-format table or csv")
+		}
+	case "table", "csv":
+		// Columns that no context has fail before the input is read
+		if columnsErr := checkColumns(parseColumns(columnsFlag), columnContexts(contextFlag, signalFlag)); columnsErr != nil {
+			err = &stageError{stage: stageDetect, statement: -1, err: columnsErr}
+		}
+	default:
+		return fmt.Errorf("invalid --output-format %q (valid: json, table, csv)", outputFormat)
+	}
+
+	if err == nil {
+		err = transformInput()
+	}
+	if err == nil || cmd == nil {
+		return err
+	}
+	var diagnostic *statementDiagnostic
+	var validationErr *schemaValidationError
+	switch {
+	case errorFormat == "json":
+		cmd.SilenceErrors = true
+		cmd.SilenceUsage = true
+		writeTransformError(os.Stderr, err)
+	case errors.As(err, &diagnostic), errors.As(err, &validationErr):
+		cmd.SilenceUsage = true // the diagnostic or the schema issues already show what to fix
+	}
+	return err
+}
+
+// transformInput transforms the input file with the statements, or keeps doing so with --watch
+func transformInput() error {
+	if watchFlag {
+		return watchTransform()
+	}
+
+	// 1. Read OTTL statement from stdin or the statement file
+	ottlStatement, err := readStatement(statementFile)
 	if err != nil {
-		return fmt.Errorf("failed to read OTTL statement from stdin: %w", err)
+		return &stageError{stage: stageRead, statement: -1, err: err}
+	}
+
+	// 2. Transform the input file and output the result
+	return transformFile(ottlStatement, statementSourceName(), inputFile)
+}
+
+// statementSourceName names where the statements come from in diagnostics
+func statementSourceName() string {
+	if statementFile == "" {
+		return stdinSource
 	}
+	return statementFile
+}
+
+// stdinSource names stdin in diagnostics
+const stdinSource = "<stdin>"
 
-	// 2. Read input file and detect context type
-	data, err := readInputFile(inputFile)
+// readStatement reads the OTTL statement from a file, or from stdin when filename is empty
+func readStatement(filename string) (string, error) {
+	if filename == "" {
+		statement, err := readStdin()
+		if err != nil {
+			return "", fmt.Errorf("failed to read OTTL statement from stdin: %w", err)
+		}
+		return statement, nil
+	}
+
+	data, err := readInputFile(filename)
 	if err != nil {
-		return fmt.Errorf("failed to read input file: %w", err)
+		return "", fmt.Errorf("failed to read statement file: %w", err)
+	}
+	// Leading whitespace is kept so diagnostics point at the lines of the file
+	statement := strings.TrimRight(string(data), " \t\r\n")
+	if strings.TrimSpace(statement) == "" {
+		return "", fmt.Errorf("empty OTTL statement in %s", filename)
 	}
+	return statement, nil
+}
 
-	ctx, parsedData, err := detectContextType(data)
+// transformFile applies statements to the data in an input file and outputs the result
+func transformFile(ottlStatement, statementSource, filename string) error {
+	// Read input file and detect context type
+	read := readInputFile
+	if rotatedFlag {
+		read = readRotatedInput
+	}
+	data, err := read(filename)
 	if err != nil {
-		return fmt.Errorf("failed to detect context type: %w", err)
+		return &stageError{stage: stageRead, statement: -1, err: fmt.Errorf("failed to read input file: %w", err)}
 	}
 
-	// Override context if specified via flag
-	if contextFlag != "" {
-		ctx = parseContextFlag(contextFlag)
-		if ctx == contextTypeUnknown {
-			return fmt.Errorf("invalid context flag: %s (valid: span, log, metric, datapoint)", contextFlag)
-		}
-		// Re-parse data with forced context
-		parsedData, err = parseDataWithContext(data, ctx)
-		if err != nil {
-			return fmt.Errorf("failed to parse data with context %s: %w", ctx, err)
+	if strictFlag {
+		if err := validateInput(data, signalFlag); err != nil {
+			return &stageError{stage: stageValidate, statement: -1, err: err}
 		}
 	}
 
-	// 3. Apply OTTL transformation based on context
-	err = applyTransformation(ottlStatement, ctx, parsedData)
+	ctx, parsedData, err := parseInput(data, contextFlag, signalFlag)
 	if err != nil {
-		return fmt.Errorf("transformation failed: %w", err)
+		return &stageError{stage: stageDetect, statement: -1, err: err}
+	}
+	if outputFormat == "table" || outputFormat == "csv" {
+		// Without --context, the context of the columns is only known now
+		if err := checkColumns(parseColumns(columnsFlag), []contextType{ctx}); err != nil {
+			return &stageError{stage: stageDetect, statement: -1, err: err}
+		}
 	}
 
-	// 4. Output transformed data
-	if err := outputTransformedData(ctx, parsedData); err != nil {
-		return fmt.Errorf("failed to output data: %w", err)
+	// Apply OTTL transformation based on context
+	if err := applyStatements(ottlStatement, statementSource, ctx, parsedData); err != nil {
+		return err
+	}
+
+	// Output transformed data
+	if err := outputData(ctx, parsedData); err != nil {
+		return &stageError{stage: stageMarshal, statement: -1, err: fmt.Errorf("failed to output data: %w", err)}
 	}
 
 	return nil
 }
 
+// outputData outputs data in the format of --output-format
+func outputData(ctx contextType, data interface{}) error {
+	if outputFormat == "" || outputFormat == "json" {
+		return outputTransformedData(ctx, data)
+	}
+
+	table, err := flattenRecords(ctx, data)
+	if err != nil {
+		return err
+	}
+	columns, err := table.selectColumns(parseColumns(columnsFlag))
+	if err != nil {
+		return err
+	}
+	if outputFormat == "csv" {
+		return writeCSV(os.Stdout, table, columns)
+	}
+	return writeTable(os.Stdout, table, columns)
+}
+
+// applyStatements applies the statements of a source in order, split as in .ottl files by
+// splitStatements. Stdin holds a single statement, which may span lines however it is
+// wrapped. Parse errors are reported as diagnostics pointing into the source.
+func applyStatements(source, sourceName string, ctx contextType, data interface{}) error {
+	statements := []sourceStatement{{text: source}}
+	if sourceName != stdinSource {
+		statements = splitStatements(source)
+	}
+	if len(statements) == 0 {
+		return &stageError{stage: stageRead, statement: -1, err: errors.New("no OTTL statement found")}
+	}
+	for i, statement := range statements {
+		err := applyTransformation(statement.text, ctx, data)
+		if err == nil {
+			continue
+		}
+		var parseErr *statementParseError
+		if errors.As(err, &parseErr) {
+			return &stageError{stage: stageParse, statement: i, err: newStatementDiagnostic(sourceName, source, statement, parseErr)}
+		}
+		if len(statements) > 1 {
+			err = fmt.Errorf("transformation failed: %s:%d: %w", sourceName, statement.line+1, err)
+		} else {
+			err = fmt.Errorf("transformation failed: %w", err)
+		}
+		return &stageError{stage: stageExecute, statement: i, err: err}
+	}
+	return nil
+}
+
 // readStdin reads OTTL statement from stdin
 func readStdin() (string, error) {
 	scanner := bufio.NewScanner(os.Stdin)
@@ -191,77 +362,380 @@ func readInputFile(filename string) ([]byte, error) {
 
 // detectContextType automatically detects the data type and returns parsed data
 func detectContextType(data []byte) (contextType, interface{}, error) {
-	// Try traces first (backward compatibility)
-	if traces, err := (&ptrace.JSONUnmarshaler{}).UnmarshalTraces(data); err == nil {
-		if traces.ResourceSpans().Len() > 0 {
-			return contextTypeSpan, traces, nil
+	// Captures of the file and debug exporters come first, as the JSON unmarshalers
+	// would read the first line of NDJSON only
+	if capture, ok, err := parseCapture(data); ok {
+		if err != nil {
+			return contextTypeUnknown, nil, err
+		}
+		return capture.detect()
+	}
+
+	signal, err := sniffSignal(data)
+	if err != nil {
+		return contextTypeUnknown, nil, err
+	}
+	parsedData, err := unmarshalSignal(data, signal)
+	if err != nil {
+		return contextTypeUnknown, nil, err
+	}
+	if resourceCount(parsedData) == 0 {
+		return contextTypeUnknown, nil, fmt.Errorf("unable to detect data type from input")
+	}
+	return contextForSignal(signal), parsedData, nil
+}
+
+// parseInput parses input data once, detecting its signal and context unless they are given with
+// signal and context flag values
+func parseInput(data []byte, contextFlag, signalFlag string) (contextType, interface{}, error) {
+	ctx := contextTypeUnknown
+	if contextFlag != "" {
+		ctx = parseContextFlag(contextFlag)
+		if ctx == contextTypeUnknown {
+			return contextTypeUnknown, nil, fmt.Errorf("invalid context flag: %s (valid: %s)", contextFlag, validContexts())
+		}
+	}
+	signal := strings.ToLower(signalFlag)
+	if signal != "" && contextForSignal(signal) == contextTypeUnknown {
+		return contextTypeUnknown, nil, fmt.Errorf("invalid signal flag: %s (valid: traces, logs, metrics, profiles)", signalFlag)
+	}
+	if signal != "" && ctx != contextTypeUnknown && signalForContext(ctx) != signal {
+		return contextTypeUnknown, nil, incompatibleContextError(ctx, signal)
+	}
+
+	if capture, ok, err := parseCapture(data); ok {
+		if err != nil {
+			return contextTypeUnknown, nil, err
+		}
+		if ctx == contextTypeUnknown && signal == "" {
+			ctx, parsedData, err := capture.detect()
+			if err != nil && len(capture.contexts()) > 1 {
+				err = fmt.Errorf("%w; pick one with --signal", err)
+			}
+			return ctx, parsedData, err
+		}
+		if ctx == contextTypeUnknown {
+			ctx = contextForSignal(signal)
 		}
+		parsedData, ok := capture.data(ctx)
+		if !ok {
+			return contextTypeUnknown, nil, fmt.Errorf("input has no %s", signalForContext(ctx))
+		}
+		return ctx, parsedData, nil
 	}
 
-	// Try logs
-	if logs, err := (&plog.JSONUnmarshaler{}).UnmarshalLogs(data); err == nil {
-		if logs.ResourceLogs().Len() > 0 {
-			return contextTypeLog, logs, nil
+	if signal == "" {
+		sniffed, err := sniffSignal(data)
+		if err != nil {
+			return contextTypeUnknown, nil, err
+		}
+		if ctx != contextTypeUnknown && signalForContext(ctx) != sniffed {
+			return contextTypeUnknown, nil, incompatibleContextError(ctx, sniffed)
 		}
+		signal = sniffed
+	}
+	if ctx == contextTypeUnknown {
+		ctx = contextForSignal(signal)
 	}
 
-	// Try metrics
-	if metrics, err := (&pmetric.JSONUnmarshaler{}).UnmarshalMetrics(data); err == nil {
-		if metrics.ResourceMetrics().Len() > 0 {
-			return contextTypeMetric, metrics, nil
+	parsedData, err := unmarshalSignal(data, signal)
+	if err != nil {
+		return contextTypeUnknown, nil, err
+	}
+	if resourceCount(parsedData) == 0 {
+		// The unmarshalers ignore the fields of other signals, so a wrong --signal reads nothing
+		if sniffed, err := sniffSignal(data); err == nil && sniffed != signal {
+			return contextTypeUnknown, nil, fmt.Errorf("input contains %s, not %s", sniffed, signal)
 		}
 	}
+	return ctx, parsedData, nil
+}
 
-	return contextTypeUnknown, nil, fmt.Errorf("unable to detect data type from input")
+// incompatibleContextError reports a context that cannot transform the records of a signal
+func incompatibleContextError(ctx contextType, signal string) error {
+	return fmt.Errorf("the %s context cannot be used with %s: it transforms %s (use --context %s)",
+		ctx, signal, signalForContext(ctx), contextForSignal(signal))
 }
 
-// parseContextFlag converts string flag to contextType
-func parseContextFlag(flag string) contextType {
-	switch strings.ToLower(flag) {
-	case "span":
+// signalForContext returns the pipeline signal carrying data of a context type
+func signalForContext(ctx contextType) string {
+	switch ctx {
+	case contextTypeSpan:
+		return "traces"
+	case contextTypeLog:
+		return "logs"
+	case contextTypeMetric, contextTypeDatapoint:
+		return "metrics"
+	case contextTypeProfile:
+		return "profiles"
+	default:
+		return "unknown"
+	}
+}
+
+// contextForSignal returns the context of the records of a signal, such as span for traces
+func contextForSignal(signal string) contextType {
+	switch signal {
+	case "traces":
 		return contextTypeSpan
-	case "log":
+	case "logs":
 		return contextTypeLog
-	case "metric":
+	case "metrics":
 		return contextTypeMetric
-	case "datapoint":
-		return contextTypeDatapoint
+	case "profiles":
+		return contextTypeProfile
 	default:
 		return contextTypeUnknown
 	}
 }
 
-// parseDataWithContext parses data with a specific context
-func parseDataWithContext(data []byte, ctx contextType) (interface{}, error) {
-	switch ctx {
-	case contextTypeSpan:
+// signalKeys maps the top-level fields of OTLP JSON requests, in both of the casings the
+// unmarshalers accept, to their signal
+var signalKeys = map[string]string{
+	"resourceSpans":     "traces",
+	"resource_spans":    "traces",
+	"resourceLogs":      "logs",
+	"resource_logs":     "logs",
+	"resourceMetrics":   "metrics",
+	"resource_metrics":  "metrics",
+	"resourceProfiles":  "profiles",
+	"resource_profiles": "profiles",
+}
+
+// sniffSignal finds the signal of an OTLP JSON request from its top-level fields, without
+// unmarshaling it
+func sniffSignal(data []byte) (string, error) {
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	if token, err := decoder.Token(); err != nil || token != json.Delim('{') {
+		return "", fmt.Errorf("unable to detect data type from input: not a JSON object")
+	}
+	for decoder.More() {
+		token, err := decoder.Token()
+		if err != nil {
+			return "", fmt.Errorf("unable to detect data type from input: %w", err)
+		}
+		if signal, ok := signalKeys[token.(string)]; ok {
+			return signal, nil
+		}
+		var skipped json.RawMessage
+		if err := decoder.Decode(&skipped); err != nil {
+			return "", fmt.Errorf("unable to detect data type from input: %w", err)
+		}
+	}
+	return "", fmt.Errorf("unable to detect data type from input")
+}
+
+// unmarshalSignal unmarshals an OTLP JSON request of a signal
+func unmarshalSignal(data []byte, signal string) (interface{}, error) {
+	switch signal {
+	case "traces":
 		traces, err := (&ptrace.JSONUnmarshaler{}).UnmarshalTraces(data)
 		if err != nil {
 			return nil, fmt.Errorf("invalid OTLP traces JSON: %w", err)
 		}
 		return traces, nil
-	case contextTypeLog:
+	case "logs":
 		logs, err := (&plog.JSONUnmarshaler{}).UnmarshalLogs(data)
 		if err != nil {
 			return nil, fmt.Errorf("invalid OTLP logs JSON: %w", err)
 		}
 		return logs, nil
-	case contextTypeMetric:
+	case "metrics":
 		metrics, err := (&pmetric.JSONUnmarshaler{}).UnmarshalMetrics(data)
 		if err != nil {
 			return nil, fmt.Errorf("invalid OTLP metrics JSON: %w", err)
 		}
 		return metrics, nil
-	case contextTypeDatapoint:
-		// For datapoint context, we need metrics data
-		metrics, err := (&pmetric.JSONUnmarshaler{}).UnmarshalMetrics(data)
+	case "profiles":
+		profiles, err := (&pprofile.JSONUnmarshaler{}).UnmarshalProfiles(data)
 		if err != nil {
-			return nil, fmt.Errorf("invalid OTLP metrics JSON for datapoint context: %w", err)
+			return nil, fmt.Errorf("invalid OTLP profiles JSON: %w", err)
 		}
-		return metrics, nil
+		return profiles, nil
 	default:
-		return nil, fmt.Errorf("unsupported context type: %s", ctx)
+		return nil, fmt.Errorf("unsupported signal: %s", signal)
+	}
+}
+
+// resourceCount returns the number of resources of traces, logs, metrics or profiles
+func resourceCount(data interface{}) int {
+	switch d := data.(type) {
+	case ptrace.Traces:
+		return d.ResourceSpans().Len()
+	case plog.Logs:
+		return d.ResourceLogs().Len()
+	case pmetric.Metrics:
+		return d.ResourceMetrics().Len()
+	case pprofile.Profiles:
+		return d.ResourceProfiles().Len()
+	default:
+		return 0
+	}
+}
+
+// parseContextFlag converts string flag to contextType
+// validContexts lists the names parseContextFlag accepts, for help texts and error messages
+func validContexts() string {
+	names := make([]string, len(allContexts))
+	for i, ctx := range allContexts {
+		names[i] = ctx.String()
 	}
+	return strings.Join(names, ", ")
+}
+
+func parseContextFlag(flag string) contextType {
+	switch strings.ToLower(flag) {
+	case "span":
+		return contextTypeSpan
+	case "log":
+		return contextTypeLog
+	case "metric":
+		return contextTypeMetric
+	case "datapoint":
+		return contextTypeDatapoint
+	case "profile":
+		return contextTypeProfile
+	default:
+		return contextTypeUnknown
+	}
+}
+
+// statementParseError is returned when the OTTL parser rejects a statement
+type statementParseError struct {
+	ctx       contextType
+	statement string
+	err       error
+}
+
+func (e *statementParseError) Error() string {
+	return fmt.Sprintf("failed to parse %s statement '%s': %v", e.ctx, e.statement, e.err)
+}
+
+func (e *statementParseError) Unwrap() error {
+	return e.err
+}
+
+// recordLocator identifies the record a statement failed on by its position in the data,
+// and by the fields that find it in a large sample
+type recordLocator struct {
+	Resource   int               `json:"resource"`
+	Scope      int               `json:"scope"`
+	Record     int               `json:"record"`
+	DataPoint  *int              `json:"datapoint,omitempty"`
+	TraceID    string            `json:"trace_id,omitempty"`
+	SpanID     string            `json:"span_id,omitempty"`
+	Body       string            `json:"body,omitempty"`
+	Metric     string            `json:"metric,omitempty"`
+	ProfileID  string            `json:"profile_id,omitempty"`
+	Attributes map[string]string `json:"attributes,omitempty"`
+}
+
+// locatorBodyLength is the length of the log body snippet of a record locator
+const locatorBodyLength = 60
+
+// spanLocator locates a span
+func spanLocator(resource, scope, record int, span ptrace.Span) recordLocator {
+	return recordLocator{Resource: resource, Scope: scope, Record: record, TraceID: span.TraceID().String(), SpanID: span.SpanID().String()}
+}
+
+// logLocator locates a log record, with a snippet of its body and its trace context when set
+func logLocator(resource, scope, record int, logRecord plog.LogRecord) recordLocator {
+	body := []rune(logRecord.Body().AsString())
+	if len(body) > locatorBodyLength {
+		body = append(body[:locatorBodyLength], '…')
+	}
+	locator := recordLocator{Resource: resource, Scope: scope, Record: record, Body: string(body)}
+	if !logRecord.TraceID().IsEmpty() {
+		locator.TraceID = logRecord.TraceID().String()
+	}
+	if !logRecord.SpanID().IsEmpty() {
+		locator.SpanID = logRecord.SpanID().String()
+	}
+	return locator
+}
+
+// metricLocator locates a metric
+func metricLocator(resource, scope, record int, metric pmetric.Metric) recordLocator {
+	return recordLocator{Resource: resource, Scope: scope, Record: record, Metric: metric.Name()}
+}
+
+// profileLocator locates a profile
+func profileLocator(resource, scope, record int, profile pprofile.Profile) recordLocator {
+	locator := recordLocator{Resource: resource, Scope: scope, Record: record}
+	if !profile.ProfileID().IsEmpty() {
+		locator.ProfileID = profile.ProfileID().String()
+	}
+	return locator
+}
+
+// dataPointLocator locates a data point of a metric, with the attributes that tell it apart
+func dataPointLocator(resource, scope, record int, metric pmetric.Metric, dataPoint int, attributes pcommon.Map) recordLocator {
+	locator := metricLocator(resource, scope, record, metric)
+	locator.DataPoint = &dataPoint
+	if attributes.Len() > 0 {
+		locator.Attributes = make(map[string]string, attributes.Len())
+		attributes.Range(func(k string, v pcommon.Value) bool {
+			locator.Attributes[k] = v.AsString()
+			return true
+		})
+	}
+	return locator
+}
+
+// describe prints a locator, naming the record after what was transformed
+func (l recordLocator) describe(kind string) string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "resource %d, scope %d, ", l.Resource, l.Scope)
+	var details []string
+	if l.DataPoint != nil {
+		fmt.Fprintf(&b, "metric %d", l.Record)
+	} else {
+		fmt.Fprintf(&b, "%s %d", kind, l.Record)
+	}
+	if l.TraceID != "" {
+		details = append(details, "trace_id "+l.TraceID)
+	}
+	if l.SpanID != "" {
+		details = append(details, "span_id "+l.SpanID)
+	}
+	if l.ProfileID != "" {
+		details = append(details, "profile_id "+l.ProfileID)
+	}
+	if l.Metric != "" {
+		details = append(details, fmt.Sprintf("name %q", l.Metric))
+	}
+	if l.Body != "" || kind == "log" {
+		details = append(details, fmt.Sprintf("body %q", l.Body))
+	}
+	if len(details) > 0 {
+		fmt.Fprintf(&b, " (%s)", strings.Join(details, ", "))
+	}
+	if l.DataPoint != nil {
+		fmt.Fprintf(&b, ", datapoint %d", *l.DataPoint)
+		if len(l.Attributes) > 0 {
+			attributes := make([]string, 0, len(l.Attributes))
+			for _, k := range sortedKeys(l.Attributes) {
+				attributes = append(attributes, fmt.Sprintf("%s=%q", k, l.Attributes[k]))
+			}
+			fmt.Fprintf(&b, " (%s)", strings.Join(attributes, ", "))
+		}
+	}
+	return b.String()
+}
+
+// statementExecutionError is returned when a statement fails on a record
+type statementExecutionError struct {
+	kind   string // what was transformed, such as "span" or "gauge datapoint"
+	record recordLocator
+	err    error
+}
+
+func (e *statementExecutionError) Error() string {
+	return fmt.Sprintf("failed to execute %s transformation on %s: %v", e.kind, e.record.describe(e.kind), e.err)
+}
+
+func (e *statementExecutionError) Unwrap() error {
+	return e.err
 }
 
 // applyTransformation applies OTTL statement based on context type
@@ -291,6 +765,12 @@ func applyTransformation(statement string, ctx contextType, data interface{}) er
 			return fmt.Errorf("expected pmetric.Metrics but got %T", data)
 		}
 		return applyDataPointTransformation(statement, metrics)
+	case contextTypeProfile:
+		profiles, ok := data.(pprofile.Profiles)
+		if !ok {
+			return fmt.Errorf("expected pprofile.Profiles but got %T", data)
+		}
+		return applyProfileTransformation(statement, profiles)
 	default:
 		return fmt.Errorf("unsupported context type: %s", ctx)
 	}
@@ -298,14 +778,14 @@ func applyTransformation(statement string, ctx contextType, data interface{}) er
 
 // applySpanTransformation applies OTTL statement to traces (spans)
 func applySpanTransformation(statement string, traces ptrace.Traces) error {
-	parser, err := ottlspan.NewParser(ottlfuncs.StandardFuncs[ottlspan.TransformContext](), componenttest.NewNopTelemetrySettings())
+	parser, err := ottlspan.NewParser(functions.Span, componenttest.NewNopTelemetrySettings())
 	if err != nil {
 		return fmt.Errorf("failed to create span parser: %w", err)
 	}
 
 	parsedStatement, err := parser.ParseStatement(statement)
 	if err != nil {
-		return fmt.Errorf("failed to parse span statement '%s': %w", statement, err)
+		return &statementParseError{ctx: contextTypeSpan, statement: statement, err: err}
 	}
 
 	resourceSpans := traces.ResourceSpans()
@@ -323,7 +803,7 @@ func applySpanTransformation(statement string, traces ptrace.Traces) error {
 
 				_, _, err := parsedStatement.Execute(context.Background(), spanCtx)
 				if err != nil {
-					return fmt.Errorf("failed to execute span transformation: %w", err)
+					return &statementExecutionError{kind: "span", record: spanLocator(i, j, k, span), err: err}
 				}
 			}
 		}
@@ -334,14 +814,14 @@ func applySpanTransformation(statement string, traces ptrace.Traces) error {
 
 // applyLogTransformation applies OTTL statement to logs
 func applyLogTransformation(statement string, logs plog.Logs) error {
-	parser, err := ottllog.NewParser(ottlfuncs.StandardFuncs[ottllog.TransformContext](), componenttest.NewNopTelemetrySettings())
+	parser, err := ottllog.NewParser(functions.Log, componenttest.NewNopTelemetrySettings())
 	if err != nil {
 		return fmt.Errorf("failed to create log parser: %w", err)
 	}
 
 	parsedStatement, err := parser.ParseStatement(statement)
 	if err != nil {
-		return fmt.Errorf("failed to parse log statement '%s': %w", statement, err)
+		return &statementParseError{ctx: contextTypeLog, statement: statement, err: err}
 	}
 
 	resourceLogs := logs.ResourceLogs()
@@ -359,7 +839,7 @@ func applyLogTransformation(statement string, logs plog.Logs) error {
 
 				_, _, err := parsedStatement.Execute(context.Background(), logCtx)
 				if err != nil {
-					return fmt.Errorf("failed to execute log transformation: %w", err)
+					return &statementExecutionError{kind: "log", record: logLocator(i, j, k, logRecord), err: err}
 				}
 			}
 		}
@@ -370,14 +850,14 @@ func applyLogTransformation(statement string, logs plog.Logs) error {
 
 // applyMetricTransformation applies OTTL statement to metrics
 func applyMetricTransformation(statement string, metrics pmetric.Metrics) error {
-	parser, err := ottlmetric.NewParser(ottlfuncs.StandardFuncs[ottlmetric.TransformContext](), componenttest.NewNopTelemetrySettings())
+	parser, err := ottlmetric.NewParser(functions.Metric, componenttest.NewNopTelemetrySettings())
 	if err != nil {
 		return fmt.Errorf("failed to create metric parser: %w", err)
 	}
 
 	parsedStatement, err := parser.ParseStatement(statement)
 	if err != nil {
-		return fmt.Errorf("failed to parse metric statement '%s': %w", statement, err)
+		return &statementParseError{ctx: contextTypeMetric, statement: statement, err: err}
 	}
 
 	resourceMetrics := metrics.ResourceMetrics()
@@ -395,7 +875,7 @@ func applyMetricTransformation(statement string, metrics pmetric.Metrics) error
 
 				_, _, err := parsedStatement.Execute(context.Background(), metricCtx)
 				if err != nil {
-					return fmt.Errorf("failed to execute metric transformation: %w", err)
+					return &statementExecutionError{kind: "metric", record: metricLocator(i, j, k, metric), err: err}
 				}
 			}
 		}
@@ -406,14 +886,14 @@ func applyMetricTransformation(statement string, metrics pmetric.Metrics) error
 
 // applyDataPointTransformation applies OTTL statement to metric data points
 func applyDataPointTransformation(statement string, metrics pmetric.Metrics) error {
-	parser, err := ottldatapoint.NewParser(ottlfuncs.StandardFuncs[ottldatapoint.TransformContext](), componenttest.NewNopTelemetrySettings())
+	parser, err := ottldatapoint.NewParser(functions.DataPoint, componenttest.NewNopTelemetrySettings())
 	if err != nil {
 		return fmt.Errorf("failed to create datapoint parser: %w", err)
 	}
 
 	parsedStatement, err := parser.ParseStatement(statement)
 	if err != nil {
-		return fmt.Errorf("failed to parse datapoint statement '%s': %w", statement, err)
+		return &statementParseError{ctx: contextTypeDatapoint, statement: statement, err: err}
 	}
 
 	resourceMetrics := metrics.ResourceMetrics()
@@ -438,7 +918,7 @@ func applyDataPointTransformation(statement string, metrics pmetric.Metrics) err
 						dpCtx := ottldatapoint.NewTransformContext(dp, metric, metricSlice, sm.Scope(), rm.Resource(), sm, rm)
 						_, _, err := parsedStatement.Execute(context.Background(), dpCtx)
 						if err != nil {
-							return fmt.Errorf("failed to execute gauge datapoint transformation: %w", err)
+							return &statementExecutionError{kind: "gauge datapoint", record: dataPointLocator(i, j, k, metric, l, dp.Attributes()), err: err}
 						}
 					}
 
@@ -450,7 +930,7 @@ func applyDataPointTransformation(statement string, metrics pmetric.Metrics) err
 						dpCtx := ottldatapoint.NewTransformContext(dp, metric, metricSlice, sm.Scope(), rm.Resource(), sm, rm)
 						_, _, err := parsedStatement.Execute(context.Background(), dpCtx)
 						if err != nil {
-							return fmt.Errorf("failed to execute sum datapoint transformation: %w", err)
+							return &statementExecutionError{kind: "sum datapoint", record: dataPointLocator(i, j, k, metric, l, dp.Attributes()), err: err}
 						}
 					}
 
@@ -462,7 +942,7 @@ func applyDataPointTransformation(statement string, metrics pmetric.Metrics) err
 						dpCtx := ottldatapoint.NewTransformContext(dp, metric, metricSlice, sm.Scope(), rm.Resource(), sm, rm)
 						_, _, err := parsedStatement.Execute(context.Background(), dpCtx)
 						if err != nil {
-							return fmt.Errorf("failed to execute histogram datapoint transformation: %w", err)
+							return &statementExecutionError{kind: "histogram datapoint", record: dataPointLocator(i, j, k, metric, l, dp.Attributes()), err: err}
 						}
 					}
 
@@ -474,7 +954,7 @@ func applyDataPointTransformation(statement string, metrics pmetric.Metrics) err
 						dpCtx := ottldatapoint.NewTransformContext(dp, metric, metricSlice, sm.Scope(), rm.Resource(), sm, rm)
 						_, _, err := parsedStatement.Execute(context.Background(), dpCtx)
 						if err != nil {
-							return fmt.Errorf("failed to execute exponential histogram datapoint transformation: %w", err)
+							return &statementExecutionError{kind: "exponential histogram datapoint", record: dataPointLocator(i, j, k, metric, l, dp.Attributes()), err: err}
 						}
 					}
 
@@ -486,7 +966,7 @@ func applyDataPointTransformation(statement string, metrics pmetric.Metrics) err
 						dpCtx := ottldatapoint.NewTransformContext(dp, metric, metricSlice, sm.Scope(), rm.Resource(), sm, rm)
 						_, _, err := parsedStatement.Execute(context.Background(), dpCtx)
 						if err != nil {
-							return fmt.Errorf("failed to execute summary datapoint transformation: %w", err)
+							return &statementExecutionError{kind: "summary datapoint", record: dataPointLocator(i, j, k, metric, l, dp.Attributes()), err: err}
 						}
 					}
 				}
@@ -497,6 +977,43 @@ func applyDataPointTransformation(statement string, metrics pmetric.Metrics) err
 	return nil
 }
 
+// applyProfileTransformation applies OTTL statement to profiles
+func applyProfileTransformation(statement string, profiles pprofile.Profiles) error {
+	parser, err := ottlprofile.NewParser(functions.Profile, componenttest.NewNopTelemetrySettings())
+	if err != nil {
+		return fmt.Errorf("failed to create profile parser: %w", err)
+	}
+
+	parsedStatement, err := parser.ParseStatement(statement)
+	if err != nil {
+		return &statementParseError{ctx: contextTypeProfile, statement: statement, err: err}
+	}
+
+	dictionary := profiles.ProfilesDictionary()
+	resourceProfiles := profiles.ResourceProfiles()
+	for i := 0; i < resourceProfiles.Len(); i++ {
+		rp := resourceProfiles.At(i)
+		scopeProfiles := rp.ScopeProfiles()
+
+		for j := 0; j < scopeProfiles.Len(); j++ {
+			sp := scopeProfiles.At(j)
+			profileSlice := sp.Profiles()
+
+			for k := 0; k < profileSlice.Len(); k++ {
+				profile := profileSlice.At(k)
+				profileCtx := ottlprofile.NewTransformContext(profile, dictionary, sp.Scope(), rp.Resource(), sp, rp)
+
+				_, _, err := parsedStatement.Execute(context.Background(), profileCtx)
+				if err != nil {
+					return &statementExecutionError{kind: "profile", record: profileLocator(i, j, k, profile), err: err}
+				}
+			}
+		}
+	}
+
+	return nil
+}
+
 // outputTransformedData outputs data as JSON based on context type
 func outputTransformedData(ctx contextType, data interface{}) error {
 	switch ctx {
@@ -518,6 +1035,12 @@ func outputTransformedData(ctx contextType, data interface{}) error {
 			return fmt.Errorf("expected pmetric.Metrics but got %T", data)
 		}
 		return outputTransformedMetrics(metrics)
+	case contextTypeProfile:
+		profiles, ok := data.(pprofile.Profiles)
+		if !ok {
+			return fmt.Errorf("expected pprofile.Profiles but got %T", data)
+		}
+		return outputTransformedProfiles(profiles)
 	default:
 		return fmt.Errorf("unsupported context type: %s", ctx)
 	}
@@ -555,3 +1078,14 @@ func outputTransformedMetrics(metrics pmetric.Metrics) error {
 	fmt.Print(string(jsonData))
 	return nil
 }
+
+// outputTransformedProfiles outputs profiles as JSON using pdata marshaler
+func outputTransformedProfiles(profiles pprofile.Profiles) error {
+	marshaler := &pprofile.JSONMarshaler{}
+	jsonData, err := marshaler.MarshalProfiles(profiles)
+	if err != nil {
+		return fmt.Errorf("failed to marshal profiles to JSON: %w", err)
+	}
+	fmt.Print(string(jsonData))
+	return nil
+}
@@ -11,6 +11,7 @@ import (
 	"github.com/stretchr/testify/require"
 	"go.opentelemetry.io/collector/pdata/plog"
 	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.opentelemetry.io/collector/pdata/pprofile"
 	"go.opentelemetry.io/collector/pdata/ptrace"
 )
 
@@ -30,6 +31,7 @@ func TestContextTypeString(t *testing.T) {
 		{contextTypeLog, "log"},
 		{contextTypeMetric, "metric"},
 		{contextTypeDatapoint, "datapoint"},
+		{contextTypeProfile, "profile"},
 		{contextTypeUnknown, "unknown"},
 	}
 
@@ -50,6 +52,7 @@ func TestParseContextFlag(t *testing.T) {
 		{"log", contextTypeLog},
 		{"metric", contextTypeMetric},
 		{"datapoint", contextTypeDatapoint},
+		{"profile", contextTypeProfile},
 		{"invalid", contextTypeUnknown},
 		{"", contextTypeUnknown},
 	}
@@ -86,6 +89,12 @@ func TestDetectContextType(t *testing.T) {
 			expectedType: contextTypeMetric,
 			shouldError:  false,
 		},
+		{
+			name:         "valid profiles",
+			filename:     "profiles.json",
+			expectedType: contextTypeProfile,
+			shouldError:  false,
+		},
 	}
 
 	for _, test := range tests {
@@ -119,10 +128,11 @@ func TestDetectContextType(t *testing.T) {
 	})
 }
 
-func TestParseDataWithContext(t *testing.T) {
+func TestParseInputWithContext(t *testing.T) {
 	tracesData := readTestData(t, "traces.json")
 	logsData := readTestData(t, "logs.json")
 	metricsData := readTestData(t, "metrics.json")
+	profilesData := readTestData(t, "profiles.json")
 
 	tests := []struct {
 		name        string
@@ -154,6 +164,12 @@ func TestParseDataWithContext(t *testing.T) {
 			ctx:         contextTypeDatapoint,
 			shouldError: false,
 		},
+		{
+			name:        "parse profiles as profile context",
+			data:        profilesData,
+			ctx:         contextTypeProfile,
+			shouldError: false,
+		},
 		{
 			name:        "invalid context type",
 			data:        tracesData,
@@ -170,7 +186,7 @@ func TestParseDataWithContext(t *testing.T) {
 
 	for _, test := range tests {
 		t.Run(test.name, func(t *testing.T) {
-			data, err := parseDataWithContext(test.data, test.ctx)
+			_, data, err := parseInput(test.data, test.ctx.String(), "")
 
 			if test.shouldError {
 				assert.Error(t, err)
@@ -188,7 +204,88 @@ func TestParseDataWithContext(t *testing.T) {
 				assert.IsType(t, plog.Logs{}, data)
 			case contextTypeMetric, contextTypeDatapoint:
 				assert.IsType(t, pmetric.Metrics{}, data)
+			case contextTypeProfile:
+				assert.IsType(t, pprofile.Profiles{}, data)
+			}
+		})
+	}
+}
+
+func TestParseInput(t *testing.T) {
+	tests := []struct {
+		name         string
+		filename     string
+		context      string
+		signal       string
+		expectedType contextType
+		expectErr    string
+	}{
+		{name: "detected", filename: "logs.json", expectedType: contextTypeLog},
+		{name: "context", filename: "metrics.json", context: "datapoint", expectedType: contextTypeDatapoint},
+		{name: "signal", filename: "metrics.json", signal: "metrics", expectedType: contextTypeMetric},
+		{name: "signal and context", filename: "metrics.json", signal: "METRICS", context: "datapoint", expectedType: contextTypeDatapoint},
+		{
+			name:      "context incompatible with the input",
+			filename:  "logs.json",
+			context:   "span",
+			expectErr: "the span context cannot be used with logs: it transforms traces (use --context log)",
+		},
+		{
+			name:      "context incompatible with the signal",
+			filename:  "traces.json",
+			context:   "datapoint",
+			signal:    "traces",
+			expectErr: "the datapoint context cannot be used with traces: it transforms metrics (use --context span)",
+		},
+		{
+			name:      "signal of another input",
+			filename:  "logs.json",
+			signal:    "traces",
+			expectErr: "input contains logs, not traces",
+		},
+		{name: "invalid signal", filename: "logs.json", signal: "spans", expectErr: "invalid signal flag: spans"},
+		{name: "invalid context", filename: "logs.json", context: "record", expectErr: "invalid context flag: record"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			ctx, data, err := parseInput(readTestData(t, test.filename), test.context, test.signal)
+			if test.expectErr != "" {
+				assert.ErrorContains(t, err, test.expectErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, test.expectedType, ctx)
+			assert.Positive(t, resourceCount(data))
+		})
+	}
+}
+
+func TestSniffSignal(t *testing.T) {
+	tests := []struct {
+		name      string
+		data      string
+		expected  string
+		expectErr bool
+	}{
+		{name: "traces", data: `{"resourceSpans": []}`, expected: "traces"},
+		{name: "snake case", data: `{"resource_logs": []}`, expected: "logs"},
+		{name: "after other fields", data: `{"partialSuccess": {"rejected": [1, {"a": 2}]}, "resourceMetrics": []}`, expected: "metrics"},
+		{name: "profiles", data: `{"dictionary": {}, "resourceProfiles": []}`, expected: "profiles"},
+		{name: "no signal", data: `{"hello": "world"}`, expectErr: true},
+		{name: "not an object", data: `[{"resourceSpans": []}]`, expectErr: true},
+		{name: "invalid JSON", data: `{"a": }`, expectErr: true},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			signal, err := sniffSignal([]byte(test.data))
+			if test.expectErr {
+				assert.Error(t, err)
+				return
 			}
+			require.NoError(t, err)
+			assert.Equal(t, test.expected, signal)
 		})
 	}
 }
@@ -470,6 +567,51 @@ func TestApplyDataPointTransformation(t *testing.T) {
 	}
 }
 
+func TestApplyProfileTransformation(t *testing.T) {
+	profilesData := readTestData(t, "profiles.json")
+
+	tests := []struct {
+		name        string
+		statement   string
+		shouldError bool
+	}{
+		{
+			name:        "valid profile transformation",
+			statement:   "set(profile.attributes[\"env\"], \"test\")",
+			shouldError: false,
+		},
+		{
+			name:        "invalid OTTL syntax",
+			statement:   "invalid_function()",
+			shouldError: true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			profilesCopy, err := (&pprofile.JSONUnmarshaler{}).UnmarshalProfiles(profilesData)
+			require.NoError(t, err)
+
+			err = applyProfileTransformation(test.statement, profilesCopy)
+
+			if test.shouldError {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+
+			profile := profilesCopy.ResourceProfiles().At(0).ScopeProfiles().At(0).Profiles().At(0)
+			attributes := pprofile.FromAttributeIndices(profilesCopy.ProfilesDictionary().AttributeTable(), profile)
+			env, ok := attributes.Get("env")
+			require.True(t, ok)
+			assert.Equal(t, "test", env.Str())
+			threadName, ok := attributes.Get("thread.name")
+			require.True(t, ok)
+			assert.Equal(t, "main", threadName.Str())
+		})
+	}
+}
+
 func TestOutputTransformedData(t *testing.T) {
 	tracesData := readTestData(t, "traces.json")
 	logsData := readTestData(t, "logs.json")
@@ -481,6 +623,8 @@ func TestOutputTransformedData(t *testing.T) {
 	require.NoError(t, err)
 	metrics, err := (&pmetric.JSONUnmarshaler{}).UnmarshalMetrics(metricsData)
 	require.NoError(t, err)
+	profiles, err := (&pprofile.JSONUnmarshaler{}).UnmarshalProfiles(readTestData(t, "profiles.json"))
+	require.NoError(t, err)
 
 	tests := []struct {
 		name        string
@@ -512,6 +656,12 @@ func TestOutputTransformedData(t *testing.T) {
 			data:        metrics,
 			shouldError: false,
 		},
+		{
+			name:        "output profiles",
+			ctx:         contextTypeProfile,
+			data:        profiles,
+			shouldError: false,
+		},
 		{
 			name:        "invalid context",
 			ctx:         contextTypeUnknown,
@@ -0,0 +1,196 @@
+// Copyright 2025 Dose de Telemetria GmbH
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import "strings"
+
+// contextPath documents a path that statements can read or set in a context
+type contextPath struct {
+	path       string
+	typ        string
+	doc        string
+	readOnly   bool   // setting the path has no effect
+	deprecated string // path to use instead
+}
+
+// commonPaths returns the resource and instrumentation scope paths shared by every context
+func commonPaths(record string) []contextPath {
+	return []contextPath{
+		{path: "resource", typ: "pcommon.Resource", doc: "resource of the " + record + " being processed"},
+		{path: "resource.attributes", typ: "pcommon.Map", doc: "resource attributes of the " + record + " being processed"},
+		{path: "resource.dropped_attributes_count", typ: "int64", doc: "number of dropped attributes of the resource of the " + record + " being processed"},
+		{path: "instrumentation_scope", typ: "pcommon.InstrumentationScope", doc: "instrumentation scope of the " + record + " being processed"},
+		{path: "instrumentation_scope.name", typ: "string", doc: "name of the instrumentation scope of the " + record + " being processed"},
+		{path: "instrumentation_scope.version", typ: "string", doc: "version of the instrumentation scope of the " + record + " being processed"},
+		{path: "instrumentation_scope.dropped_attributes_count", typ: "int64", doc: "number of dropped attributes of the instrumentation scope of the " + record + " being processed"},
+		{path: "instrumentation_scope.attributes", typ: "pcommon.Map", doc: "instrumentation scope attributes of the " + record + " being processed"},
+	}
+}
+
+// contextPaths lists the documented paths of each context, following the README of each
+// context package in pkg/ottl/contexts. Indexed paths such as span.attributes[""] are
+// covered by the map or slice they index.
+var contextPaths = map[contextType][]contextPath{
+	contextTypeSpan: append(commonPaths("span"),
+		contextPath{path: "span.cache", typ: "pcommon.Map", doc: "temporary cache of the transform context, a placeholder for data during complex transformations"},
+		contextPath{path: "span.attributes", typ: "pcommon.Map", doc: "attributes of the span being processed"},
+		contextPath{path: "span.trace_id", typ: "pcommon.TraceID", doc: "a byte slice representation of the trace id"},
+		contextPath{path: "span.trace_id.string", typ: "string", doc: "a string representation of the trace id"},
+		contextPath{path: "span.span_id", typ: "pcommon.SpanID", doc: "a byte slice representation of the span id"},
+		contextPath{path: "span.span_id.string", typ: "string", doc: "a string representation of the span id"},
+		contextPath{path: "span.parent_span_id", typ: "pcommon.SpanID", doc: "a byte slice representation of the parent span id"},
+		contextPath{path: "span.parent_span_id.string", typ: "string", doc: "a string representation of the parent span id"},
+		contextPath{path: "span.trace_state", typ: "string", doc: "the trace state of the current span"},
+		contextPath{path: "span.status", typ: "ptrace.Status", doc: "the status of the span being processed"},
+		contextPath{path: "span.status.code", typ: "int64", doc: "the status code of the span being processed"},
+		contextPath{path: "span.status.message", typ: "string", doc: "the status message of the span being processed"},
+		contextPath{path: "span.name", typ: "string", doc: "the name of the span"},
+		contextPath{path: "span.kind", typ: "int64", doc: "the kind of the span"},
+		contextPath{path: "span.kind.string", typ: "string", doc: "the kind of the span in string format. Valid values are Unspecified, Internal, Server, Client, Producer, and Consumer. When setting, if an invalid value is used Unspecified will be set"},
+		contextPath{path: "span.kind.deprecated_string", typ: "string", doc: "the kind of the span in deprecated string format. Valid values are SPAN_KIND_UNSPECIFIED, SPAN_KIND_INTERNAL, SPAN_KIND_SERVER, SPAN_KIND_CLIENT, SPAN_KIND_PRODUCER, and SPAN_KIND_CONSUMER. When setting, if an invalid value is used SPAN_KIND_UNSPECIFIED will be set. This accessor will eventually be removed, use kind or kind.string instead", deprecated: "span.kind.string"},
+		contextPath{path: "span.start_time_unix_nano", typ: "int64", doc: "the start time in unix nano of the span"},
+		contextPath{path: "span.end_time_unix_nano", typ: "int64", doc: "the end time in unix nano of the span"},
+		contextPath{path: "span.start_time", typ: "time.Time", doc: "the start time in time.Time of the span"},
+		contextPath{path: "span.end_time", typ: "time.Time", doc: "the end time in time.Time of the span"},
+		contextPath{path: "span.dropped_attributes_count", typ: "int64", doc: "the dropped attributes count of the span"},
+		contextPath{path: "span.events", typ: "ptrace.SpanEventSlice", doc: "the events of the span"},
+		contextPath{path: "span.dropped_events_count", typ: "int64", doc: "the dropped events count of the span"},
+		contextPath{path: "span.links", typ: "ptrace.SpanLinkSlice", doc: "the links of the span"},
+		contextPath{path: "span.dropped_links_count", typ: "int64", doc: "the dropped links count of the span"},
+	),
+	contextTypeLog: append(commonPaths("log record"),
+		contextPath{path: "log.cache", typ: "pcommon.Map", doc: "temporary cache of the transform context, a placeholder for data during complex transformations"},
+		contextPath{path: "log.attributes", typ: "pcommon.Map", doc: "attributes of the log being processed"},
+		contextPath{path: "log.event_name", typ: "string", doc: "the event name associated with the log being processed"},
+		contextPath{path: "log.trace_id", typ: "pcommon.TraceID", doc: "a byte slice representation of the trace id"},
+		contextPath{path: "log.trace_id.string", typ: "string", doc: "a string representation of the trace id"},
+		contextPath{path: "log.span_id", typ: "pcommon.SpanID", doc: "a byte slice representation of the span id"},
+		contextPath{path: "log.span_id.string", typ: "string", doc: "a string representation of the span id"},
+		contextPath{path: "log.time_unix_nano", typ: "int64", doc: "the time in unix nano of the log being processed"},
+		contextPath{path: "log.observed_time_unix_nano", typ: "int64", doc: "the observed time in unix nano of the log being processed"},
+		contextPath{path: "log.time", typ: "time.Time", doc: "the time in time.Time of the log being processed"},
+		contextPath{path: "log.observed_time", typ: "time.Time", doc: "the observed time in time.Time of the log being processed"},
+		contextPath{path: "log.severity_number", typ: "int64", doc: "the severity number of the log being processed"},
+		contextPath{path: "log.severity_text", typ: "string", doc: "the severity text of the log being processed"},
+		contextPath{path: "log.body", typ: "any", doc: "the body of the log being processed"},
+		contextPath{path: "log.body.string", typ: "string", doc: "the body of the log being processed represented as a string. When setting must pass a string"},
+		contextPath{path: "log.dropped_attributes_count", typ: "int64", doc: "the number of dropped attributes of the log being processed"},
+		contextPath{path: "log.flags", typ: "int64", doc: "the flags of the log being processed"},
+	),
+	contextTypeMetric: append(commonPaths("metric"),
+		contextPath{path: "metric.cache", typ: "pcommon.Map", doc: "temporary cache of the transform context, a placeholder for data during complex transformations"},
+		contextPath{path: "metric.name", typ: "string", doc: "the name of the metric"},
+		contextPath{path: "metric.description", typ: "string", doc: "the description of the metric"},
+		contextPath{path: "metric.unit", typ: "string", doc: "the unit of the metric"},
+		contextPath{path: "metric.type", typ: "int64", doc: "the data type of the metric", readOnly: true},
+		contextPath{path: "metric.metadata", typ: "pcommon.Map", doc: "metadata associated with the metric"},
+		contextPath{path: "metric.aggregation_temporality", typ: "int64", doc: "the aggregation temporality of the metric"},
+		contextPath{path: "metric.is_monotonic", typ: "bool", doc: "the monotonicity of the metric"},
+		contextPath{path: "metric.data_points", typ: "pmetric.NumberDataPointSlice, pmetric.HistogramDataPointSlice, pmetric.ExponentialHistogramDataPointSlice, or pmetric.SummaryDataPointSlice", doc: "the data points of the metric"},
+	),
+	contextTypeDatapoint: append(commonPaths("data point"),
+		contextPath{path: "datapoint.cache", typ: "pcommon.Map", doc: "temporary cache of the transform context, a placeholder for data during complex transformations"},
+		contextPath{path: "datapoint.attributes", typ: "pcommon.Map", doc: "attributes of the data point being processed"},
+		contextPath{path: "datapoint.positive", typ: "pmetric.ExponentialHistogramDataPoint", doc: "the positive buckets of the data point being processed"},
+		contextPath{path: "datapoint.positive.offset", typ: "int64", doc: "the offset of the positive buckets of the data point being processed"},
+		contextPath{path: "datapoint.positive.bucket_counts", typ: "uint64", doc: "the bucket_counts of the positive buckets of the data point being processed"},
+		contextPath{path: "datapoint.negative", typ: "pmetric.ExponentialHistogramDataPoint", doc: "the negative buckets of the data point being processed"},
+		contextPath{path: "datapoint.negative.offset", typ: "int64", doc: "the offset of the negative buckets of the data point being processed"},
+		contextPath{path: "datapoint.negative.bucket_counts", typ: "uint64", doc: "the bucket_counts of the negative buckets of the data point being processed"},
+		contextPath{path: "datapoint.start_time_unix_nano", typ: "int64", doc: "the start time in unix nano of the data point being processed"},
+		contextPath{path: "datapoint.time", typ: "time.Time", doc: "the time in time.Time of the data point being processed"},
+		contextPath{path: "datapoint.start_time", typ: "time.Time", doc: "the start time in time.Time of the data point being processed"},
+		contextPath{path: "datapoint.time_unix_nano", typ: "int64", doc: "the time in unix nano of the data point being processed"},
+		contextPath{path: "datapoint.value_double", typ: "float64", doc: "the double value of the data point being processed"},
+		contextPath{path: "datapoint.value_int", typ: "int64", doc: "the int value of the data point being processed"},
+		contextPath{path: "datapoint.exemplars", typ: "pmetric.ExemplarSlice", doc: "the exemplars of the data point being processed"},
+		contextPath{path: "datapoint.flags", typ: "int64", doc: "the flags of the data point being processed"},
+		contextPath{path: "datapoint.count", typ: "int64", doc: "the count of the data point being processed"},
+		contextPath{path: "datapoint.sum", typ: "float64", doc: "the sum of the data point being processed"},
+		contextPath{path: "datapoint.bucket_counts", typ: "[]uint64", doc: "the bucket counts of the data point being processed"},
+		contextPath{path: "datapoint.explicit_bounds", typ: "[]float64", doc: "the explicit bounds of the data point being processed"},
+		contextPath{path: "datapoint.scale", typ: "int64", doc: "the scale of the data point being processed"},
+		contextPath{path: "datapoint.zero_count", typ: "int64", doc: "the zero_count of the data point being processed"},
+		contextPath{path: "datapoint.quantile_values", typ: "pmetric.SummaryDataPointValueAtQuantileSlice", doc: "the quantile_values of the data point being processed"},
+		contextPath{path: "metric.name", typ: "string", doc: "the name of the metric"},
+		contextPath{path: "metric.description", typ: "string", doc: "the description of the metric"},
+		contextPath{path: "metric.unit", typ: "string", doc: "the unit of the metric"},
+		contextPath{path: "metric.type", typ: "int64", doc: "the data type of the metric", readOnly: true},
+		contextPath{path: "metric.metadata", typ: "pcommon.Map", doc: "metadata associated with the metric"},
+		contextPath{path: "metric.aggregation_temporality", typ: "int64", doc: "the aggregation temporality of the metric"},
+		contextPath{path: "metric.is_monotonic", typ: "bool", doc: "the monotonicity of the metric"},
+	),
+	contextTypeProfile: append(commonPaths("profile"),
+		contextPath{path: "profile.cache", typ: "pcommon.Map", doc: "temporary cache of the transform context, a placeholder for data during complex transformations"},
+		contextPath{path: "profile.attributes", typ: "pcommon.Map", doc: "attributes of the profile being processed"},
+		contextPath{path: "profile.sample_type", typ: "pprofile.ValueTypeSlice", doc: "the sample types of the profile being processed"},
+		contextPath{path: "profile.sample", typ: "pprofile.SampleSlice", doc: "the samples of the profile being processed"},
+		contextPath{path: "profile.location_indices", typ: "[]int64", doc: "the location indices of the profile being processed"},
+		contextPath{path: "profile.time_unix_nano", typ: "int64", doc: "the time in unix nano of the profile being processed"},
+		contextPath{path: "profile.time", typ: "time.Time", doc: "the time in time.Time of the profile being processed"},
+		contextPath{path: "profile.duration_unix_nano", typ: "int64", doc: "the duration in unix nano of the profile being processed"},
+		contextPath{path: "profile.duration", typ: "time.Time", doc: "the duration in nanoseconds of the profile being processed"},
+		contextPath{path: "profile.period_type", typ: "pprofile.ValueType", doc: "the period type of the profile being processed"},
+		contextPath{path: "profile.period", typ: "int64", doc: "the period of the profile being processed"},
+		contextPath{path: "profile.comment_string_indices", typ: "[]int64", doc: "the comment string indices of the profile being processed"},
+		contextPath{path: "profile.default_sample_type_index", typ: "int64", doc: "the default sample type string index of the profile being processed"},
+		contextPath{path: "profile.profile_id", typ: "pprofile.ProfileID", doc: "the profile id of the profile being processed"},
+		contextPath{path: "profile.profile_id.string", typ: "string", doc: "a string representation of the profile id"},
+		contextPath{path: "profile.attribute_indices", typ: "[]int64", doc: "the attribute indices of the profile being processed"},
+		contextPath{path: "profile.dropped_attributes_count", typ: "int64", doc: "the dropped attributes count of the profile being processed"},
+		contextPath{path: "profile.original_payload_format", typ: "string", doc: "the original payload format of the profile being processed"},
+		contextPath{path: "profile.original_payload", typ: "[]byte", doc: "the original payload of the profile being processed"},
+	),
+}
+
+// lookupPath returns the documentation of a path in any of the given contexts
+func lookupPath(path string, contexts []contextType) (contextPath, bool) {
+	for _, ctx := range contexts {
+		for _, p := range contextPaths[ctx] {
+			if p.path == path {
+				return p, true
+			}
+		}
+	}
+	return contextPath{}, false
+}
+
+// resolvePath documents a path of a statement that may omit its context name
+func resolvePath(path string, contexts []contextType) (contextPath, bool) {
+	if p, ok := lookupPath(path, contexts); ok {
+		return p, true
+	}
+	for _, ctx := range contexts {
+		if p, ok := lookupPath(ctx.String()+"."+path, []contextType{ctx}); ok {
+			return p, true
+		}
+	}
+	return contextPath{}, false
+}
+
+// pathsWithPrefix returns the distinct paths of the given contexts that start with prefix
+func pathsWithPrefix(prefix string, contexts []contextType) []contextPath {
+	seen := make(map[string]bool)
+	var result []contextPath
+	for _, ctx := range contexts {
+		for _, p := range contextPaths[ctx] {
+			if strings.HasPrefix(p.path, prefix) && !seen[p.path] {
+				seen[p.path] = true
+				result = append(result, p)
+			}
+		}
+	}
+	return result
+}
@@ -0,0 +1,293 @@
+// Copyright 2025 Dose de Telemetria GmbH
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"go.opentelemetry.io/collector/pdata/plog"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+	"gopkg.in/yaml.v3"
+)
+
+var pipelineCmd = &cobra.Command{
+	Use:   "pipeline",
+	Short: "Run the processors of a collector pipeline on OTLP data",
+	Long: `Reads a collector config and runs the processors of the selected pipeline,
+in order, against the OTLP JSON data in the specified input file. Outputs the
+final OTLP JSON to stdout.
+
+Supported processors are transform, filter, attributes and resource. Processors
+that do not change data (batch, memory_limiter) are skipped silently; any other
+processor is skipped with a warning.`,
+	Example: `  # Run the traces/backend pipeline
+  ottl pipeline --config collector.yaml --pipeline traces/backend --input-file spans.json
+
+  # Keep a snapshot of the data after every processor
+  ottl pipeline --config collector.yaml --pipeline logs --input-file logs.json --snapshot-dir ./steps`,
+	Args: cobra.NoArgs,
+	RunE: runPipeline,
+}
+
+var pipelineConfigFile string
+var pipelineName string
+var pipelineInputFile string
+var pipelineSnapshotDir string
+
+func init() {
+	pipelineCmd.Flags().StringVarP(&pipelineConfigFile, "config", "c", "", "Path to collector config YAML (required)")
+	pipelineCmd.Flags().StringVarP(&pipelineName, "pipeline", "p", "", "Pipeline ID, e.g. traces/backend (required)")
+	pipelineCmd.Flags().StringVarP(&pipelineInputFile, "input-file", "i", "", "Path to OTLP JSON input file (required)")
+	pipelineCmd.Flags().StringVar(&pipelineSnapshotDir, "snapshot-dir", "", "Write the input and the output of every processor to this directory")
+	_ = pipelineCmd.MarkFlagRequired("config")
+	_ = pipelineCmd.MarkFlagRequired("pipeline")
+	_ = pipelineCmd.MarkFlagRequired("input-file")
+	rootCmd.AddCommand(pipelineCmd)
+}
+
+// errUnsupportedProcessor is returned for processor types the CLI cannot run
+var errUnsupportedProcessor = errors.New("unsupported processor")
+
+// pipelineProcessor applies one configured collector processor to telemetry data
+type pipelineProcessor interface {
+	processTraces(ctx context.Context, td ptrace.Traces) error
+	processLogs(ctx context.Context, ld plog.Logs) error
+	processMetrics(ctx context.Context, md pmetric.Metrics) error
+}
+
+// pipelineStep is a processor of a pipeline together with its ID
+type pipelineStep struct {
+	id        string
+	processor pipelineProcessor
+}
+
+// collectorConfig holds the parts of a collector config used to run a pipeline
+type collectorConfig struct {
+	Processors map[string]yaml.Node `yaml:"processors"`
+	Service    struct {
+		Pipelines map[string]struct {
+			Processors []string `yaml:"processors"`
+		} `yaml:"pipelines"`
+	} `yaml:"service"`
+}
+
+// runPipeline executes the pipeline command
+func runPipeline(cmd *cobra.Command, args []string) error {
+	configData, err := readInputFile(pipelineConfigFile)
+	if err != nil {
+		return fmt.Errorf("failed to read collector config: %w", err)
+	}
+
+	steps, err := loadPipeline(configData, pipelineName, os.Stderr)
+	if err != nil {
+		return fmt.Errorf("invalid pipeline %s: %w", pipelineName, err)
+	}
+
+	data, err := readInputFile(pipelineInputFile)
+	if err != nil {
+		return fmt.Errorf("failed to read input file: %w", err)
+	}
+
+	ctx, parsedData, err := detectContextType(data)
+	if err != nil {
+		return fmt.Errorf("failed to detect context type: %w", err)
+	}
+
+	if signal := pipelineSignal(pipelineName); signal != signalForContext(ctx) {
+		return fmt.Errorf("pipeline %s expects %s but the input contains %s", pipelineName, signal, signalForContext(ctx))
+	}
+
+	snapshot := func(string, int) error { return nil }
+	if pipelineSnapshotDir != "" {
+		if err := os.MkdirAll(pipelineSnapshotDir, 0o750); err != nil {
+			return fmt.Errorf("cannot create snapshot directory: %w", err)
+		}
+		snapshot = func(name string, index int) error {
+			filename := filepath.Join(pipelineSnapshotDir, fmt.Sprintf("%02d-%s.json", index, strings.ReplaceAll(name, "/", "_")))
+			return writeSnapshot(filename, parsedData)
+		}
+	}
+
+	if err := snapshot("input", 0); err != nil {
+		return err
+	}
+	for i, step := range steps {
+		if err := runPipelineStep(context.Background(), step, parsedData); err != nil {
+			return fmt.Errorf("processor %s failed: %w", step.id, err)
+		}
+		if err := snapshot(step.id, i+1); err != nil {
+			return err
+		}
+	}
+
+	if err := outputTransformedData(ctx, parsedData); err != nil {
+		return fmt.Errorf("failed to output data: %w", err)
+	}
+
+	return nil
+}
+
+// loadPipeline builds the processors of a pipeline from a collector config.
+// Processors the CLI cannot run are reported to warnings and skipped.
+func loadPipeline(configData []byte, name string, warnings io.Writer) ([]pipelineStep, error) {
+	var cfg collectorConfig
+	if err := yaml.Unmarshal(configData, &cfg); err != nil {
+		return nil, fmt.Errorf("cannot parse YAML: %w", err)
+	}
+
+	pipeline, ok := cfg.Service.Pipelines[name]
+	if !ok {
+		names := make([]string, 0, len(cfg.Service.Pipelines))
+		for n := range cfg.Service.Pipelines {
+			names = append(names, n)
+		}
+		sort.Strings(names)
+		return nil, fmt.Errorf("pipeline not found in config (available: %s)", strings.Join(names, ", "))
+	}
+
+	if signal := pipelineSignal(name); signal != "traces" && signal != "logs" && signal != "metrics" {
+		return nil, fmt.Errorf("unsupported pipeline type %q", signal)
+	}
+
+	var steps []pipelineStep
+	for _, id := range pipeline.Processors {
+		section, ok := cfg.Processors[id]
+		if !ok {
+			return nil, fmt.Errorf("processor %s is not configured", id)
+		}
+
+		processor, err := newPipelineProcessor(id, &section)
+		if errors.Is(err, errUnsupportedProcessor) {
+			_, _ = fmt.Fprintf(warnings, "warning: skipping processor %s: %v\n", id, err)
+			continue
+		}
+		if err != nil {
+			return nil, fmt.Errorf("processor %s: %w", id, err)
+		}
+		if processor != nil {
+			steps = append(steps, pipelineStep{id: id, processor: processor})
+		}
+	}
+
+	return steps, nil
+}
+
+// newPipelineProcessor creates a processor from its ID and config section. It
+// returns a nil processor for processor types that do not change data.
+func newPipelineProcessor(id string, section *yaml.Node) (pipelineProcessor, error) {
+	switch componentType(id) {
+	case "transform":
+		var cfg transformProcessorConfig
+		if err := decodeSection(section, &cfg); err != nil {
+			return nil, err
+		}
+		return newTransformProcessor(cfg)
+	case "filter":
+		var cfg filterProcessorConfig
+		if err := decodeSection(section, &cfg); err != nil {
+			return nil, err
+		}
+		return newFilterProcessor(cfg)
+	case "attributes":
+		var cfg attributesProcessorConfig
+		if err := decodeSection(section, &cfg); err != nil {
+			return nil, err
+		}
+		return newAttributesProcessor(cfg)
+	case "resource":
+		var cfg resourceProcessorConfig
+		if err := decodeSection(section, &cfg); err != nil {
+			return nil, err
+		}
+		return newResourceProcessor(cfg)
+	case "batch", "memory_limiter":
+		return nil, nil
+	default:
+		return nil, fmt.Errorf("%w type %q", errUnsupportedProcessor, componentType(id))
+	}
+}
+
+// runPipelineStep applies a processor to the data of the matching signal
+func runPipelineStep(ctx context.Context, step pipelineStep, data interface{}) error {
+	switch data := data.(type) {
+	case ptrace.Traces:
+		return step.processor.processTraces(ctx, data)
+	case plog.Logs:
+		return step.processor.processLogs(ctx, data)
+	case pmetric.Metrics:
+		return step.processor.processMetrics(ctx, data)
+	default:
+		return fmt.Errorf("unsupported data type %T", data)
+	}
+}
+
+// decodeSection decodes a YAML config section into a typed config, rejecting unknown settings
+func decodeSection(section *yaml.Node, out interface{}) error {
+	if section == nil || section.Kind == 0 || section.Tag == "!!null" {
+		return nil
+	}
+
+	data, err := yaml.Marshal(section)
+	if err != nil {
+		return err
+	}
+
+	decoder := yaml.NewDecoder(bytes.NewReader(data))
+	decoder.KnownFields(true)
+	if err := decoder.Decode(out); err != nil {
+		return fmt.Errorf("unsupported or invalid settings: %w", err)
+	}
+	return nil
+}
+
+// componentType returns the type part of a component ID such as "transform/backend"
+func componentType(id string) string {
+	if i := strings.Index(id, "/"); i >= 0 {
+		return id[:i]
+	}
+	return id
+}
+
+// pipelineSignal returns the signal of a pipeline ID such as "traces/backend"
+func pipelineSignal(id string) string {
+	return componentType(id)
+}
+
+// writeSnapshot writes the current state of the data as OTLP JSON
+func writeSnapshot(filename string, data interface{}) error {
+	file, err := os.Create(filename) // #nosec G304 - User-provided directory is expected for CLI tool
+	if err != nil {
+		return fmt.Errorf("cannot create snapshot %s: %w", filename, err)
+	}
+	defer func() { _ = file.Close() }()
+
+	jsonData, err := marshalOTLPJSON(data)
+	if err != nil {
+		return fmt.Errorf("failed to marshal snapshot: %w", err)
+	}
+
+	_, err = file.Write(jsonData)
+	return err
+}
@@ -0,0 +1,138 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/pdata/plog"
+)
+
+const pipelineTestConfig = `
+processors:
+  batch:
+  k8sattributes:
+  transform:
+    log_statements:
+      - set(log.attributes["seen"], true)
+  filter/info:
+    logs:
+      log_record:
+        - severity_text == "INFO"
+  filter/legacy:
+    logs:
+      include:
+        match_type: strict
+service:
+  pipelines:
+    logs:
+      processors: [batch, k8sattributes, transform, filter/info]
+    logs/legacy:
+      processors: [filter/legacy]
+    logs/missing:
+      processors: [transform/missing]
+    profiles:
+      processors: [batch]
+`
+
+func TestLoadPipeline(t *testing.T) {
+	tests := []struct {
+		name        string
+		pipeline    string
+		expected    []string
+		warning     string
+		shouldError bool
+	}{
+		{
+			name:     "supported and skipped processors",
+			pipeline: "logs",
+			expected: []string{"transform", "filter/info"},
+			warning:  "warning: skipping processor k8sattributes",
+		},
+		{
+			name:        "unknown pipeline",
+			pipeline:    "traces",
+			shouldError: true,
+		},
+		{
+			name:        "legacy filter settings",
+			pipeline:    "logs/legacy",
+			shouldError: true,
+		},
+		{
+			name:        "processor without config",
+			pipeline:    "logs/missing",
+			shouldError: true,
+		},
+		{
+			name:        "unsupported pipeline type",
+			pipeline:    "profiles",
+			shouldError: true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			var warnings bytes.Buffer
+			steps, err := loadPipeline([]byte(pipelineTestConfig), test.pipeline, &warnings)
+
+			if test.shouldError {
+				assert.Error(t, err)
+				return
+			}
+
+			require.NoError(t, err)
+			ids := make([]string, 0, len(steps))
+			for _, step := range steps {
+				ids = append(ids, step.id)
+			}
+			assert.Equal(t, test.expected, ids)
+			assert.Contains(t, warnings.String(), test.warning)
+		})
+	}
+}
+
+func TestRunPipelineSteps(t *testing.T) {
+	logs, err := (&plog.JSONUnmarshaler{}).UnmarshalLogs(readTestData(t, "logs.json"))
+	require.NoError(t, err)
+
+	var warnings bytes.Buffer
+	steps, err := loadPipeline([]byte(pipelineTestConfig), "logs", &warnings)
+	require.NoError(t, err)
+
+	for _, step := range steps {
+		require.NoError(t, runPipelineStep(context.Background(), step, logs))
+	}
+
+	require.Equal(t, 1, logs.LogRecordCount())
+	record := logs.ResourceLogs().At(0).ScopeLogs().At(0).LogRecords().At(0)
+	assert.Equal(t, "ERROR", record.SeverityText())
+	seen, ok := record.Attributes().Get("seen")
+	require.True(t, ok)
+	assert.True(t, seen.Bool())
+}
+
+func TestComponentType(t *testing.T) {
+	assert.Equal(t, "transform", componentType("transform/backend"))
+	assert.Equal(t, "filter", componentType("filter"))
+	assert.Equal(t, "traces", pipelineSignal("traces/backend"))
+	assert.Equal(t, "metrics", signalForContext(contextTypeDatapoint))
+}
+
+func TestWriteSnapshot(t *testing.T) {
+	logs, err := (&plog.JSONUnmarshaler{}).UnmarshalLogs(readTestData(t, "logs.json"))
+	require.NoError(t, err)
+
+	filename := filepath.Join(t.TempDir(), "00-input.json")
+	require.NoError(t, writeSnapshot(filename, logs))
+
+	data, err := os.ReadFile(filename)
+	require.NoError(t, err)
+	snapshot, err := (&plog.JSONUnmarshaler{}).UnmarshalLogs(data)
+	require.NoError(t, err)
+	assert.Equal(t, logs.LogRecordCount(), snapshot.LogRecordCount())
+}
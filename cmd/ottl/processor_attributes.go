@@ -0,0 +1,435 @@
+// Copyright 2025 Dose de Telemetria GmbH
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/plog"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+	"go.uber.org/zap"
+
+	"github.com/telemetrydrops/ottl-cli/internal/attraction"
+)
+
+// attributesProcessorConfig mirrors the attributes processor configuration
+type attributesProcessorConfig struct {
+	Actions []attraction.ActionKeyValue `yaml:"actions"`
+	Include *matchProperties            `yaml:"include"`
+	Exclude *matchProperties            `yaml:"exclude"`
+}
+
+// resourceProcessorConfig mirrors the resource processor configuration
+type resourceProcessorConfig struct {
+	Attributes []attraction.ActionKeyValue `yaml:"attributes"`
+}
+
+// matchProperties mirrors the include/exclude properties of the attributes processor.
+// A record matches when it satisfies every configured property: any of the
+// listed values for the name-like properties and all of attributes and resources.
+type matchProperties struct {
+	MatchType        string           `yaml:"match_type"`
+	Services         []string         `yaml:"services"`
+	SpanNames        []string         `yaml:"span_names"`
+	SpanKinds        []string         `yaml:"span_kinds"`
+	LogBodies        []string         `yaml:"log_bodies"`
+	LogSeverityTexts []string         `yaml:"log_severity_texts"`
+	MetricNames      []string         `yaml:"metric_names"`
+	Attributes       []attributeMatch `yaml:"attributes"`
+	Resources        []attributeMatch `yaml:"resources"`
+	Libraries        []libraryMatch   `yaml:"libraries"`
+}
+
+// attributeMatch matches an attribute by key and, when set, value
+type attributeMatch struct {
+	Key   string `yaml:"key"`
+	Value any    `yaml:"value"`
+}
+
+// libraryMatch matches an instrumentation scope by name and, when set, version
+type libraryMatch struct {
+	Name    string  `yaml:"name"`
+	Version *string `yaml:"version"`
+}
+
+// Match types supported by matchProperties
+const (
+	matchTypeStrict = "strict"
+	matchTypeRegexp = "regexp"
+)
+
+// matchRecord holds the properties of a record that include/exclude properties match on.
+// Fields maps property names such as "span_names" to the value of the record;
+// properties that do not apply to the record's signal are absent.
+type matchRecord struct {
+	resource   pcommon.Resource
+	scope      pcommon.InstrumentationScope
+	attributes pcommon.Map
+	fields     map[string]string
+}
+
+// stringMatcher matches a single string value
+type stringMatcher func(string) bool
+
+// propertiesMatcher is the compiled form of matchProperties
+type propertiesMatcher struct {
+	fields     map[string]stringMatcher
+	attributes []valueMatcher
+	resources  []valueMatcher
+	libraries  []libraryMatcher
+}
+
+// valueMatcher matches the attribute with the given key
+type valueMatcher struct {
+	key   string
+	match func(pcommon.Value) bool
+}
+
+// libraryMatcher matches an instrumentation scope
+type libraryMatcher struct {
+	name    stringMatcher
+	version stringMatcher
+}
+
+// newPropertiesMatcher compiles match properties. A nil properties value returns a nil matcher.
+func newPropertiesMatcher(props *matchProperties) (*propertiesMatcher, error) {
+	if props == nil {
+		return nil, nil
+	}
+
+	regexpMatch := false
+	switch props.MatchType {
+	case matchTypeStrict:
+	case matchTypeRegexp:
+		regexpMatch = true
+	case "":
+		return nil, errors.New("match_type must be set")
+	default:
+		return nil, fmt.Errorf("unsupported match_type %q", props.MatchType)
+	}
+
+	newStringMatcher := func(pattern string) (stringMatcher, error) {
+		if !regexpMatch {
+			return func(s string) bool { return s == pattern }, nil
+		}
+		re, err := regexp.Compile(pattern)
+		if err != nil {
+			return nil, err
+		}
+		return re.MatchString, nil
+	}
+
+	anyOf := func(patterns []string) (stringMatcher, error) {
+		matchers := make([]stringMatcher, 0, len(patterns))
+		for _, pattern := range patterns {
+			m, err := newStringMatcher(pattern)
+			if err != nil {
+				return nil, err
+			}
+			matchers = append(matchers, m)
+		}
+		return func(s string) bool {
+			for _, m := range matchers {
+				if m(s) {
+					return true
+				}
+			}
+			return false
+		}, nil
+	}
+
+	newValueMatchers := func(attrs []attributeMatch) ([]valueMatcher, error) {
+		matchers := make([]valueMatcher, 0, len(attrs))
+		for _, attr := range attrs {
+			if attr.Key == "" {
+				return nil, errors.New("attribute key must be set")
+			}
+			matcher := valueMatcher{key: attr.Key, match: func(pcommon.Value) bool { return true }}
+			if attr.Value != nil {
+				if regexpMatch {
+					pattern, ok := attr.Value.(string)
+					if !ok {
+						return nil, fmt.Errorf("attribute %q: regexp match requires a string value", attr.Key)
+					}
+					re, err := regexp.Compile(pattern)
+					if err != nil {
+						return nil, err
+					}
+					matcher.match = func(v pcommon.Value) bool {
+						return v.Type() == pcommon.ValueTypeStr && re.MatchString(v.Str())
+					}
+				} else {
+					expected := pcommon.NewValueEmpty()
+					if err := expected.FromRaw(attr.Value); err != nil {
+						return nil, fmt.Errorf("attribute %q: %w", attr.Key, err)
+					}
+					matcher.match = expected.Equal
+				}
+			}
+			matchers = append(matchers, matcher)
+		}
+		return matchers, nil
+	}
+
+	m := &propertiesMatcher{fields: map[string]stringMatcher{}}
+	for name, values := range map[string][]string{
+		"services":           props.Services,
+		"span_names":         props.SpanNames,
+		"span_kinds":         props.SpanKinds,
+		"log_bodies":         props.LogBodies,
+		"log_severity_texts": props.LogSeverityTexts,
+		"metric_names":       props.MetricNames,
+	} {
+		if len(values) == 0 {
+			continue
+		}
+		matcher, err := anyOf(values)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", name, err)
+		}
+		m.fields[name] = matcher
+	}
+
+	var err error
+	if m.attributes, err = newValueMatchers(props.Attributes); err != nil {
+		return nil, fmt.Errorf("attributes: %w", err)
+	}
+	if m.resources, err = newValueMatchers(props.Resources); err != nil {
+		return nil, fmt.Errorf("resources: %w", err)
+	}
+
+	for _, library := range props.Libraries {
+		lm := libraryMatcher{}
+		if lm.name, err = newStringMatcher(library.Name); err != nil {
+			return nil, fmt.Errorf("libraries: %w", err)
+		}
+		if library.Version != nil {
+			if lm.version, err = newStringMatcher(*library.Version); err != nil {
+				return nil, fmt.Errorf("libraries: %w", err)
+			}
+		}
+		m.libraries = append(m.libraries, lm)
+	}
+
+	if len(m.fields) == 0 && len(m.attributes) == 0 && len(m.resources) == 0 && len(m.libraries) == 0 {
+		return nil, errors.New("at least one property must be set")
+	}
+
+	return m, nil
+}
+
+// matches reports whether a record satisfies every configured property
+func (m *propertiesMatcher) matches(r matchRecord) bool {
+	for name, match := range m.fields {
+		value, ok := r.fields[name]
+		if !ok || !match(value) {
+			return false
+		}
+	}
+
+	for _, attr := range m.attributes {
+		if v, ok := r.attributes.Get(attr.key); !ok || !attr.match(v) {
+			return false
+		}
+	}
+
+	for _, attr := range m.resources {
+		if v, ok := r.resource.Attributes().Get(attr.key); !ok || !attr.match(v) {
+			return false
+		}
+	}
+
+	if len(m.libraries) > 0 {
+		matched := false
+		for _, library := range m.libraries {
+			if library.name(r.scope.Name()) && (library.version == nil || library.version(r.scope.Version())) {
+				matched = true
+				break
+			}
+		}
+		if !matched {
+			return false
+		}
+	}
+
+	return true
+}
+
+// attributesProcessor emulates the attributes processor
+type attributesProcessor struct {
+	attrProc *attraction.AttrProc
+	include  *propertiesMatcher
+	exclude  *propertiesMatcher
+}
+
+// newAttributesProcessor validates the actions and compiles the include/exclude properties
+func newAttributesProcessor(cfg attributesProcessorConfig) (*attributesProcessor, error) {
+	if len(cfg.Actions) == 0 {
+		return nil, errors.New("missing required field \"actions\"")
+	}
+
+	attrProc, err := attraction.NewAttrProc(&attraction.Settings{Actions: cfg.Actions})
+	if err != nil {
+		return nil, err
+	}
+
+	p := &attributesProcessor{attrProc: attrProc}
+	if p.include, err = newPropertiesMatcher(cfg.Include); err != nil {
+		return nil, fmt.Errorf("include: %w", err)
+	}
+	if p.exclude, err = newPropertiesMatcher(cfg.Exclude); err != nil {
+		return nil, fmt.Errorf("exclude: %w", err)
+	}
+	return p, nil
+}
+
+// process applies the actions to the attributes of a record that passes include/exclude
+func (p *attributesProcessor) process(ctx context.Context, r matchRecord) {
+	if p.include != nil && !p.include.matches(r) {
+		return
+	}
+	if p.exclude != nil && p.exclude.matches(r) {
+		return
+	}
+	p.attrProc.Process(ctx, zap.NewNop(), r.attributes)
+}
+
+// processTraces applies the actions to span attributes
+func (p *attributesProcessor) processTraces(ctx context.Context, td ptrace.Traces) error {
+	for i := 0; i < td.ResourceSpans().Len(); i++ {
+		rs := td.ResourceSpans().At(i)
+		service := ""
+		if v, ok := rs.Resource().Attributes().Get("service.name"); ok {
+			service = v.AsString()
+		}
+		for j := 0; j < rs.ScopeSpans().Len(); j++ {
+			ss := rs.ScopeSpans().At(j)
+			for k := 0; k < ss.Spans().Len(); k++ {
+				span := ss.Spans().At(k)
+				p.process(ctx, matchRecord{
+					resource:   rs.Resource(),
+					scope:      ss.Scope(),
+					attributes: span.Attributes(),
+					fields: map[string]string{
+						"services":   service,
+						"span_names": span.Name(),
+						"span_kinds": spanKindName(span.Kind()),
+					},
+				})
+			}
+		}
+	}
+	return nil
+}
+
+// processLogs applies the actions to log record attributes
+func (p *attributesProcessor) processLogs(ctx context.Context, ld plog.Logs) error {
+	for i := 0; i < ld.ResourceLogs().Len(); i++ {
+		rl := ld.ResourceLogs().At(i)
+		for j := 0; j < rl.ScopeLogs().Len(); j++ {
+			sl := rl.ScopeLogs().At(j)
+			for k := 0; k < sl.LogRecords().Len(); k++ {
+				lr := sl.LogRecords().At(k)
+				p.process(ctx, matchRecord{
+					resource:   rl.Resource(),
+					scope:      sl.Scope(),
+					attributes: lr.Attributes(),
+					fields: map[string]string{
+						"log_bodies":         lr.Body().AsString(),
+						"log_severity_texts": lr.SeverityText(),
+					},
+				})
+			}
+		}
+	}
+	return nil
+}
+
+// processMetrics applies the actions to data point attributes
+func (p *attributesProcessor) processMetrics(ctx context.Context, md pmetric.Metrics) error {
+	for i := 0; i < md.ResourceMetrics().Len(); i++ {
+		rm := md.ResourceMetrics().At(i)
+		for j := 0; j < rm.ScopeMetrics().Len(); j++ {
+			sm := rm.ScopeMetrics().At(j)
+			for k := 0; k < sm.Metrics().Len(); k++ {
+				metric := sm.Metrics().At(k)
+				_ = forEachDataPoint(metric, func(_ int, dp any) error {
+					attrs, _ := dataPointAttributes(dp)
+					p.process(ctx, matchRecord{
+						resource:   rm.Resource(),
+						scope:      sm.Scope(),
+						attributes: attrs,
+						fields:     map[string]string{"metric_names": metric.Name()},
+					})
+					return nil
+				})
+			}
+		}
+	}
+	return nil
+}
+
+// spanKindName returns the OTLP enum name of a span kind, e.g. SPAN_KIND_SERVER
+func spanKindName(kind ptrace.SpanKind) string {
+	return "SPAN_KIND_" + strings.ToUpper(kind.String())
+}
+
+// resourceProcessor emulates the resource processor
+type resourceProcessor struct {
+	attrProc *attraction.AttrProc
+}
+
+// newResourceProcessor validates the resource attribute actions
+func newResourceProcessor(cfg resourceProcessorConfig) (*resourceProcessor, error) {
+	if len(cfg.Attributes) == 0 {
+		return nil, errors.New("missing required field \"attributes\"")
+	}
+
+	attrProc, err := attraction.NewAttrProc(&attraction.Settings{Actions: cfg.Attributes})
+	if err != nil {
+		return nil, err
+	}
+	return &resourceProcessor{attrProc: attrProc}, nil
+}
+
+// processTraces applies the actions to resource attributes
+func (p *resourceProcessor) processTraces(ctx context.Context, td ptrace.Traces) error {
+	for i := 0; i < td.ResourceSpans().Len(); i++ {
+		p.attrProc.Process(ctx, zap.NewNop(), td.ResourceSpans().At(i).Resource().Attributes())
+	}
+	return nil
+}
+
+// processLogs applies the actions to resource attributes
+func (p *resourceProcessor) processLogs(ctx context.Context, ld plog.Logs) error {
+	for i := 0; i < ld.ResourceLogs().Len(); i++ {
+		p.attrProc.Process(ctx, zap.NewNop(), ld.ResourceLogs().At(i).Resource().Attributes())
+	}
+	return nil
+}
+
+// processMetrics applies the actions to resource attributes
+func (p *resourceProcessor) processMetrics(ctx context.Context, md pmetric.Metrics) error {
+	for i := 0; i < md.ResourceMetrics().Len(); i++ {
+		p.attrProc.Process(ctx, zap.NewNop(), md.ResourceMetrics().At(i).Resource().Attributes())
+	}
+	return nil
+}
@@ -0,0 +1,152 @@
+package main
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/pdata/plog"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+
+	"github.com/telemetrydrops/ottl-cli/internal/attraction"
+)
+
+func TestPropertiesMatcher(t *testing.T) {
+	traces, err := (&ptrace.JSONUnmarshaler{}).UnmarshalTraces(readTestData(t, "traces.json"))
+	require.NoError(t, err)
+	rs := traces.ResourceSpans().At(0)
+	ss := rs.ScopeSpans().At(0)
+	span := ss.Spans().At(0)
+	record := matchRecord{
+		resource:   rs.Resource(),
+		scope:      ss.Scope(),
+		attributes: span.Attributes(),
+		fields: map[string]string{
+			"services":   "test-service",
+			"span_names": span.Name(),
+			"span_kinds": spanKindName(span.Kind()),
+		},
+	}
+
+	tests := []struct {
+		name        string
+		props       matchProperties
+		matches     bool
+		shouldError bool
+	}{
+		{
+			name:    "strict span name",
+			props:   matchProperties{MatchType: "strict", SpanNames: []string{"other", "test-span"}},
+			matches: true,
+		},
+		{
+			name:    "regexp span name",
+			props:   matchProperties{MatchType: "regexp", SpanNames: []string{"^test-"}},
+			matches: true,
+		},
+		{
+			name:    "strict attribute value",
+			props:   matchProperties{MatchType: "strict", Attributes: []attributeMatch{{Key: "http.method", Value: "GET"}}},
+			matches: true,
+		},
+		{
+			name: "all attributes must match",
+			props: matchProperties{MatchType: "strict", Attributes: []attributeMatch{
+				{Key: "http.method", Value: "GET"},
+				{Key: "missing"},
+			}},
+			matches: false,
+		},
+		{
+			name:    "properties of another signal",
+			props:   matchProperties{MatchType: "strict", LogBodies: []string{"test-span"}},
+			matches: false,
+		},
+		{
+			name:    "library name",
+			props:   matchProperties{MatchType: "strict", Libraries: []libraryMatch{{Name: ss.Scope().Name()}}},
+			matches: true,
+		},
+		{
+			name:        "missing match type",
+			props:       matchProperties{SpanNames: []string{"test-span"}},
+			shouldError: true,
+		},
+		{
+			name:        "no properties",
+			props:       matchProperties{MatchType: "strict"},
+			shouldError: true,
+		},
+		{
+			name:        "invalid regexp",
+			props:       matchProperties{MatchType: "regexp", SpanNames: []string{"("}},
+			shouldError: true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			m, err := newPropertiesMatcher(&test.props)
+
+			if test.shouldError {
+				assert.Error(t, err)
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, test.matches, m.matches(record))
+		})
+	}
+}
+
+func TestAttributesProcessor(t *testing.T) {
+	logs, err := (&plog.JSONUnmarshaler{}).UnmarshalLogs(readTestData(t, "logs.json"))
+	require.NoError(t, err)
+
+	p, err := newAttributesProcessor(attributesProcessorConfig{
+		Actions: []attraction.ActionKeyValue{{Key: "reviewed", Value: true, Action: attraction.INSERT}},
+		Exclude: &matchProperties{MatchType: "strict", LogSeverityTexts: []string{"INFO"}},
+	})
+	require.NoError(t, err)
+	require.NoError(t, p.processLogs(context.Background(), logs))
+
+	records := logs.ResourceLogs().At(0).ScopeLogs().At(0).LogRecords()
+	for i := 0; i < records.Len(); i++ {
+		_, ok := records.At(i).Attributes().Get("reviewed")
+		assert.Equal(t, records.At(i).SeverityText() != "INFO", ok)
+	}
+
+	metrics, err := (&pmetric.JSONUnmarshaler{}).UnmarshalMetrics(readTestData(t, "metrics.json"))
+	require.NoError(t, err)
+	p, err = newAttributesProcessor(attributesProcessorConfig{
+		Actions: []attraction.ActionKeyValue{{Key: "method", Action: attraction.DELETE}},
+		Include: &matchProperties{MatchType: "strict", MetricNames: []string{"http_requests_total"}},
+	})
+	require.NoError(t, err)
+	require.NoError(t, p.processMetrics(context.Background(), metrics))
+	_, ok := metrics.ResourceMetrics().At(0).ScopeMetrics().At(0).Metrics().At(0).Sum().DataPoints().At(0).Attributes().Get("method")
+	assert.False(t, ok)
+
+	_, err = newAttributesProcessor(attributesProcessorConfig{})
+	assert.Error(t, err)
+}
+
+func TestResourceProcessor(t *testing.T) {
+	traces, err := (&ptrace.JSONUnmarshaler{}).UnmarshalTraces(readTestData(t, "traces.json"))
+	require.NoError(t, err)
+
+	p, err := newResourceProcessor(resourceProcessorConfig{
+		Attributes: []attraction.ActionKeyValue{{Key: "service.name", Value: "renamed", Action: attraction.UPSERT}},
+	})
+	require.NoError(t, err)
+	require.NoError(t, p.processTraces(context.Background(), traces))
+
+	name, ok := traces.ResourceSpans().At(0).Resource().Attributes().Get("service.name")
+	require.True(t, ok)
+	assert.Equal(t, "renamed", name.Str())
+
+	_, err = newResourceProcessor(resourceProcessorConfig{})
+	assert.Error(t, err)
+}
@@ -0,0 +1,349 @@
+// Copyright 2025 Dose de Telemetria GmbH
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl/contexts/ottldatapoint"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl/contexts/ottllog"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl/contexts/ottlmetric"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl/contexts/ottlspan"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl/contexts/ottlspanevent"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl/ottlfuncs"
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/plog"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+)
+
+// filterProcessorConfig mirrors the OTTL configuration of the filter processor.
+// The legacy include/exclude settings are not supported.
+type filterProcessorConfig struct {
+	ErrorMode string `yaml:"error_mode"`
+	Traces    struct {
+		Span      []string `yaml:"span"`
+		SpanEvent []string `yaml:"spanevent"`
+	} `yaml:"traces"`
+	Logs struct {
+		LogRecord []string `yaml:"log_record"`
+	} `yaml:"logs"`
+	Metrics struct {
+		Metric    []string `yaml:"metric"`
+		DataPoint []string `yaml:"datapoint"`
+	} `yaml:"metrics"`
+}
+
+// filterProcessor emulates the filter processor. Nil condition sequences match nothing.
+type filterProcessor struct {
+	span      *ottl.ConditionSequence[ottlspan.TransformContext]
+	spanEvent *ottl.ConditionSequence[ottlspanevent.TransformContext]
+	log       *ottl.ConditionSequence[ottllog.TransformContext]
+	metric    *ottl.ConditionSequence[ottlmetric.TransformContext]
+	dataPoint *ottl.ConditionSequence[ottldatapoint.TransformContext]
+}
+
+// newFilterProcessor parses the conditions of every context in the config
+func newFilterProcessor(cfg filterProcessorConfig) (*filterProcessor, error) {
+	errorMode := ottl.PropagateError
+	if cfg.ErrorMode != "" {
+		if err := errorMode.UnmarshalText([]byte(cfg.ErrorMode)); err != nil {
+			return nil, err
+		}
+	}
+
+	spanFuncs := ottlfuncs.StandardConverters[ottlspan.TransformContext]()
+	isRootSpan := ottlfuncs.NewIsRootSpanFactory()
+	spanFuncs[isRootSpan.Name()] = isRootSpan
+
+	metricFuncs := ottlfuncs.StandardConverters[ottlmetric.TransformContext]()
+	hasAttrKey := newHasAttrKeyOnDatapointFactory()
+	metricFuncs[hasAttrKey.Name()] = hasAttrKey
+	hasAttr := newHasAttrOnDatapointFactory()
+	metricFuncs[hasAttr.Name()] = hasAttr
+
+	p := &filterProcessor{}
+	var err error
+	if p.span, err = parseFilterConditions(cfg.Traces.Span, ottlspan.NewParser, spanFuncs, errorMode); err != nil {
+		return nil, fmt.Errorf("traces.span: %w", err)
+	}
+	if p.spanEvent, err = parseFilterConditions(cfg.Traces.SpanEvent, ottlspanevent.NewParser, ottlfuncs.StandardConverters[ottlspanevent.TransformContext](), errorMode); err != nil {
+		return nil, fmt.Errorf("traces.spanevent: %w", err)
+	}
+	if p.log, err = parseFilterConditions(cfg.Logs.LogRecord, ottllog.NewParser, ottlfuncs.StandardConverters[ottllog.TransformContext](), errorMode); err != nil {
+		return nil, fmt.Errorf("logs.log_record: %w", err)
+	}
+	if p.metric, err = parseFilterConditions(cfg.Metrics.Metric, ottlmetric.NewParser, metricFuncs, errorMode); err != nil {
+		return nil, fmt.Errorf("metrics.metric: %w", err)
+	}
+	if p.dataPoint, err = parseFilterConditions(cfg.Metrics.DataPoint, ottldatapoint.NewParser, ottlfuncs.StandardConverters[ottldatapoint.TransformContext](), errorMode); err != nil {
+		return nil, fmt.Errorf("metrics.datapoint: %w", err)
+	}
+	return p, nil
+}
+
+// parseFilterConditions parses conditions into a sequence that matches when any condition is true
+func parseFilterConditions[K any](
+	conditions []string,
+	newParser func(map[string]ottl.Factory[K], component.TelemetrySettings, ...ottl.Option[K]) (ottl.Parser[K], error),
+	funcs map[string]ottl.Factory[K],
+	errorMode ottl.ErrorMode,
+) (*ottl.ConditionSequence[K], error) {
+	if len(conditions) == 0 {
+		return nil, nil
+	}
+
+	settings := componenttest.NewNopTelemetrySettings()
+	parser, err := newParser(funcs, settings)
+	if err != nil {
+		return nil, err
+	}
+	parsed, err := parser.ParseConditions(conditions)
+	if err != nil {
+		return nil, err
+	}
+
+	sequence := ottl.NewConditionSequence(parsed, settings, ottl.WithConditionSequenceErrorMode[K](errorMode))
+	return &sequence, nil
+}
+
+// evalFilter evaluates an optional condition sequence
+func evalFilter[K any](ctx context.Context, sequence *ottl.ConditionSequence[K], tCtx K) (bool, error) {
+	if sequence == nil {
+		return false, nil
+	}
+	return sequence.Eval(ctx, tCtx)
+}
+
+// processTraces drops matching spans and span events, then empty scopes and resources
+func (p *filterProcessor) processTraces(ctx context.Context, td ptrace.Traces) error {
+	if p.span == nil && p.spanEvent == nil {
+		return nil
+	}
+
+	var errs error
+	td.ResourceSpans().RemoveIf(func(rs ptrace.ResourceSpans) bool {
+		rs.ScopeSpans().RemoveIf(func(ss ptrace.ScopeSpans) bool {
+			ss.Spans().RemoveIf(func(span ptrace.Span) bool {
+				if errs != nil {
+					return false
+				}
+				drop, err := evalFilter(ctx, p.span, ottlspan.NewTransformContext(span, ss.Scope(), rs.Resource(), ss, rs))
+				if err != nil {
+					errs = err
+					return false
+				}
+				if drop {
+					return true
+				}
+				span.Events().RemoveIf(func(event ptrace.SpanEvent) bool {
+					if errs != nil {
+						return false
+					}
+					drop, err := evalFilter(ctx, p.spanEvent, ottlspanevent.NewTransformContext(event, span, ss.Scope(), rs.Resource(), ss, rs))
+					if err != nil {
+						errs = err
+					}
+					return drop
+				})
+				return false
+			})
+			return ss.Spans().Len() == 0
+		})
+		return rs.ScopeSpans().Len() == 0
+	})
+	return errs
+}
+
+// processLogs drops matching log records, then empty scopes and resources
+func (p *filterProcessor) processLogs(ctx context.Context, ld plog.Logs) error {
+	if p.log == nil {
+		return nil
+	}
+
+	var errs error
+	ld.ResourceLogs().RemoveIf(func(rl plog.ResourceLogs) bool {
+		rl.ScopeLogs().RemoveIf(func(sl plog.ScopeLogs) bool {
+			sl.LogRecords().RemoveIf(func(lr plog.LogRecord) bool {
+				if errs != nil {
+					return false
+				}
+				drop, err := evalFilter(ctx, p.log, ottllog.NewTransformContext(lr, sl.Scope(), rl.Resource(), sl, rl))
+				if err != nil {
+					errs = err
+				}
+				return drop
+			})
+			return sl.LogRecords().Len() == 0
+		})
+		return rl.ScopeLogs().Len() == 0
+	})
+	return errs
+}
+
+// processMetrics drops matching metrics and data points. Metrics left without
+// data points are dropped, then empty scopes and resources.
+func (p *filterProcessor) processMetrics(ctx context.Context, md pmetric.Metrics) error {
+	if p.metric == nil && p.dataPoint == nil {
+		return nil
+	}
+
+	var errs error
+	md.ResourceMetrics().RemoveIf(func(rm pmetric.ResourceMetrics) bool {
+		rm.ScopeMetrics().RemoveIf(func(sm pmetric.ScopeMetrics) bool {
+			sm.Metrics().RemoveIf(func(metric pmetric.Metric) bool {
+				if errs != nil {
+					return false
+				}
+				drop, err := evalFilter(ctx, p.metric, ottlmetric.NewTransformContext(metric, sm.Metrics(), sm.Scope(), rm.Resource(), sm, rm))
+				if err != nil {
+					errs = err
+					return false
+				}
+				if drop {
+					return true
+				}
+				if p.dataPoint == nil {
+					return false
+				}
+				err = removeDataPoints(metric, func(dp any) (bool, error) {
+					return evalFilter(ctx, p.dataPoint, ottldatapoint.NewTransformContext(dp, metric, sm.Metrics(), sm.Scope(), rm.Resource(), sm, rm))
+				})
+				if err != nil {
+					errs = err
+					return false
+				}
+				return dataPointCount(metric) == 0
+			})
+			return sm.Metrics().Len() == 0
+		})
+		return rm.ScopeMetrics().Len() == 0
+	})
+	return errs
+}
+
+// removeDataPoints removes the data points of a metric for which fn returns true
+func removeDataPoints(metric pmetric.Metric, fn func(dp any) (bool, error)) error {
+	var errs error
+	remove := func(dp any) bool {
+		if errs != nil {
+			return false
+		}
+		drop, err := fn(dp)
+		if err != nil {
+			errs = err
+		}
+		return drop
+	}
+
+	switch metric.Type() {
+	case pmetric.MetricTypeGauge:
+		metric.Gauge().DataPoints().RemoveIf(func(dp pmetric.NumberDataPoint) bool { return remove(dp) })
+	case pmetric.MetricTypeSum:
+		metric.Sum().DataPoints().RemoveIf(func(dp pmetric.NumberDataPoint) bool { return remove(dp) })
+	case pmetric.MetricTypeHistogram:
+		metric.Histogram().DataPoints().RemoveIf(func(dp pmetric.HistogramDataPoint) bool { return remove(dp) })
+	case pmetric.MetricTypeExponentialHistogram:
+		metric.ExponentialHistogram().DataPoints().RemoveIf(func(dp pmetric.ExponentialHistogramDataPoint) bool { return remove(dp) })
+	case pmetric.MetricTypeSummary:
+		metric.Summary().DataPoints().RemoveIf(func(dp pmetric.SummaryDataPoint) bool { return remove(dp) })
+	}
+	return errs
+}
+
+// dataPointCount returns the number of data points of a metric
+func dataPointCount(metric pmetric.Metric) int {
+	count := 0
+	_ = forEachDataPoint(metric, func(int, any) error {
+		count++
+		return nil
+	})
+	return count
+}
+
+// hasAttrOnDatapointArguments are the arguments of HasAttrOnDatapoint
+type hasAttrOnDatapointArguments struct {
+	Key         string
+	ExpectedVal string
+}
+
+// newHasAttrOnDatapointFactory creates the filter processor's HasAttrOnDatapoint converter
+func newHasAttrOnDatapointFactory() ottl.Factory[ottlmetric.TransformContext] {
+	return ottl.NewFactory("HasAttrOnDatapoint", &hasAttrOnDatapointArguments{}, func(_ ottl.FunctionContext, oArgs ottl.Arguments) (ottl.ExprFunc[ottlmetric.TransformContext], error) {
+		args, ok := oArgs.(*hasAttrOnDatapointArguments)
+		if !ok {
+			return nil, fmt.Errorf("HasAttrOnDatapointFactory args must be of type *hasAttrOnDatapointArguments")
+		}
+		return func(_ context.Context, tCtx ottlmetric.TransformContext) (any, error) {
+			return metricHasAttribute(tCtx.GetMetric(), func(attrs pcommon.Map) bool {
+				v, ok := attrs.Get(args.Key)
+				return ok && v.AsString() == args.ExpectedVal
+			}), nil
+		}, nil
+	})
+}
+
+// hasAttrKeyOnDatapointArguments are the arguments of HasAttrKeyOnDatapoint
+type hasAttrKeyOnDatapointArguments struct {
+	Key string
+}
+
+// newHasAttrKeyOnDatapointFactory creates the filter processor's HasAttrKeyOnDatapoint converter
+func newHasAttrKeyOnDatapointFactory() ottl.Factory[ottlmetric.TransformContext] {
+	return ottl.NewFactory("HasAttrKeyOnDatapoint", &hasAttrKeyOnDatapointArguments{}, func(_ ottl.FunctionContext, oArgs ottl.Arguments) (ottl.ExprFunc[ottlmetric.TransformContext], error) {
+		args, ok := oArgs.(*hasAttrKeyOnDatapointArguments)
+		if !ok {
+			return nil, fmt.Errorf("HasAttrKeyOnDatapointFactory args must be of type *hasAttrKeyOnDatapointArguments")
+		}
+		return func(_ context.Context, tCtx ottlmetric.TransformContext) (any, error) {
+			return metricHasAttribute(tCtx.GetMetric(), func(attrs pcommon.Map) bool {
+				_, ok := attrs.Get(args.Key)
+				return ok
+			}), nil
+		}, nil
+	})
+}
+
+// metricHasAttribute reports whether the attributes of any data point of a metric match
+func metricHasAttribute(metric pmetric.Metric, match func(pcommon.Map) bool) bool {
+	found := false
+	_ = forEachDataPoint(metric, func(_ int, dp any) error {
+		if attrs, ok := dataPointAttributes(dp); ok && match(attrs) {
+			found = true
+		}
+		return nil
+	})
+	return found
+}
+
+// dataPointAttributes returns the attributes of any data point type
+func dataPointAttributes(dp any) (pcommon.Map, bool) {
+	switch dp := dp.(type) {
+	case pmetric.NumberDataPoint:
+		return dp.Attributes(), true
+	case pmetric.HistogramDataPoint:
+		return dp.Attributes(), true
+	case pmetric.ExponentialHistogramDataPoint:
+		return dp.Attributes(), true
+	case pmetric.SummaryDataPoint:
+		return dp.Attributes(), true
+	default:
+		return pcommon.Map{}, false
+	}
+}
@@ -0,0 +1,92 @@
+package main
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/pdata/plog"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+)
+
+func TestFilterProcessorTraces(t *testing.T) {
+	traces, err := (&ptrace.JSONUnmarshaler{}).UnmarshalTraces(readTestData(t, "traces.json"))
+	require.NoError(t, err)
+
+	var cfg filterProcessorConfig
+	cfg.Traces.Span = []string{`attributes["http.method"] == "POST"`}
+	p, err := newFilterProcessor(cfg)
+	require.NoError(t, err)
+	require.NoError(t, p.processTraces(context.Background(), traces))
+	assert.Equal(t, 1, traces.SpanCount())
+
+	cfg.Traces.Span = []string{`attributes["http.method"] == "POST"`, "IsRootSpan()"}
+	p, err = newFilterProcessor(cfg)
+	require.NoError(t, err)
+	require.NoError(t, p.processTraces(context.Background(), traces))
+	assert.Equal(t, 0, traces.SpanCount())
+	assert.Equal(t, 0, traces.ResourceSpans().Len(), "empty resources should be removed")
+}
+
+func TestFilterProcessorLogs(t *testing.T) {
+	logs, err := (&plog.JSONUnmarshaler{}).UnmarshalLogs(readTestData(t, "logs.json"))
+	require.NoError(t, err)
+
+	var cfg filterProcessorConfig
+	cfg.Logs.LogRecord = []string{`severity_text == "INFO"`}
+	p, err := newFilterProcessor(cfg)
+	require.NoError(t, err)
+	require.NoError(t, p.processLogs(context.Background(), logs))
+
+	require.Equal(t, 1, logs.LogRecordCount())
+	assert.Equal(t, "ERROR", logs.ResourceLogs().At(0).ScopeLogs().At(0).LogRecords().At(0).SeverityText())
+}
+
+func TestFilterProcessorMetrics(t *testing.T) {
+	metrics, err := (&pmetric.JSONUnmarshaler{}).UnmarshalMetrics(readTestData(t, "metrics.json"))
+	require.NoError(t, err)
+	before := metrics.MetricCount()
+
+	var cfg filterProcessorConfig
+	cfg.Metrics.Metric = []string{`name == "cpu_usage_percent"`}
+	cfg.Metrics.DataPoint = []string{`attributes["method"] == "GET"`}
+	p, err := newFilterProcessor(cfg)
+	require.NoError(t, err)
+	require.NoError(t, p.processMetrics(context.Background(), metrics))
+
+	assert.Less(t, metrics.MetricCount(), before)
+	metricsSlice := metrics.ResourceMetrics().At(0).ScopeMetrics().At(0).Metrics()
+	for i := 0; i < metricsSlice.Len(); i++ {
+		name := metricsSlice.At(i).Name()
+		assert.NotEqual(t, "cpu_usage_percent", name)
+		assert.NotEqual(t, "http_requests_total", name, "metrics without data points should be removed")
+	}
+}
+
+func TestHasAttrOnDatapoint(t *testing.T) {
+	metrics, err := (&pmetric.JSONUnmarshaler{}).UnmarshalMetrics(readTestData(t, "metrics.json"))
+	require.NoError(t, err)
+
+	var cfg filterProcessorConfig
+	cfg.Metrics.Metric = []string{`HasAttrOnDatapoint("method", "GET")`, `HasAttrKeyOnDatapoint("missing")`}
+	p, err := newFilterProcessor(cfg)
+	require.NoError(t, err)
+	require.NoError(t, p.processMetrics(context.Background(), metrics))
+
+	metricsSlice := metrics.ResourceMetrics().At(0).ScopeMetrics().At(0).Metrics()
+	for i := 0; i < metricsSlice.Len(); i++ {
+		assert.NotEqual(t, "http_requests_total", metricsSlice.At(i).Name())
+	}
+}
+
+func TestNewFilterProcessorErrors(t *testing.T) {
+	_, err := newFilterProcessor(filterProcessorConfig{ErrorMode: "unknown"})
+	assert.Error(t, err)
+
+	var cfg filterProcessorConfig
+	cfg.Logs.LogRecord = []string{`set(attributes["a"], 1)`}
+	_, err = newFilterProcessor(cfg)
+	assert.Error(t, err, "editors are not conditions")
+}
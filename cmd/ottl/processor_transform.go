@@ -0,0 +1,472 @@
+// Copyright 2025 Dose de Telemetria GmbH
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl/contexts/ottldatapoint"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl/contexts/ottllog"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl/contexts/ottlmetric"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl/contexts/ottlresource"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl/contexts/ottlscope"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl/contexts/ottlspan"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl/contexts/ottlspanevent"
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/pdata/plog"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+	"gopkg.in/yaml.v3"
+
+	"github.com/telemetrydrops/ottl-cli/pkg/functions"
+)
+
+// transformProcessorConfig mirrors the transform processor configuration
+type transformProcessorConfig struct {
+	ErrorMode        string                  `yaml:"error_mode,omitempty"`
+	TraceStatements  transformStatementsList `yaml:"trace_statements,omitempty"`
+	LogStatements    transformStatementsList `yaml:"log_statements,omitempty"`
+	MetricStatements transformStatementsList `yaml:"metric_statements,omitempty"`
+}
+
+// transformStatements is a group of statements sharing a context, conditions and error mode
+type transformStatements struct {
+	Context    string   `yaml:"context,omitempty"`
+	Conditions []string `yaml:"conditions,omitempty"`
+	Statements []string `yaml:"statements"`
+	ErrorMode  string   `yaml:"error_mode,omitempty"`
+}
+
+// GetStatements implements ottl.StatementsGetter
+func (s transformStatements) GetStatements() []string {
+	return s.Statements
+}
+
+// transformStatementsList holds statement groups configured in either the
+// basic style (a list of statements) or the advanced style (a list of groups)
+type transformStatementsList []transformStatements
+
+// UnmarshalYAML groups basic style statements into a single group without a context
+func (l *transformStatementsList) UnmarshalYAML(value *yaml.Node) error {
+	if value.Kind != yaml.SequenceNode {
+		return fmt.Errorf("line %d: expected a list of statements or statement groups", value.Line)
+	}
+
+	var basic []string
+	var groups transformStatementsList
+	for _, item := range value.Content {
+		if item.Kind == yaml.ScalarNode {
+			basic = append(basic, item.Value)
+			continue
+		}
+
+		var group transformStatements
+		if err := item.Decode(&group); err != nil {
+			return err
+		}
+		groups = append(groups, group)
+	}
+
+	if len(basic) > 0 && len(groups) > 0 {
+		return errors.New("configuring multiple configuration styles is not supported, please use only basic or only advanced configuration")
+	}
+	if len(basic) > 0 {
+		groups = transformStatementsList{{Statements: basic}}
+	}
+
+	*l = groups
+	return nil
+}
+
+// transformSequence is a parsed statement group of one OTTL context
+type transformSequence[K any] struct {
+	statements ottl.StatementSequence[K]
+	conditions *ottl.ConditionSequence[K]
+}
+
+// execute runs the statements when the group conditions match, or when the group has none
+func (s *transformSequence[K]) execute(ctx context.Context, tCtx K) error {
+	if s.conditions != nil {
+		matched, err := s.conditions.Eval(ctx, tCtx)
+		if err != nil {
+			return err
+		}
+		if !matched {
+			return nil
+		}
+	}
+	return s.statements.Execute(ctx, tCtx)
+}
+
+// transformGroup runs a parsed statement group against the data of a signal
+type transformGroup struct {
+	traces  func(context.Context, ptrace.Traces) error
+	logs    func(context.Context, plog.Logs) error
+	metrics func(context.Context, pmetric.Metrics) error
+}
+
+// transformProcessor emulates the transform processor
+type transformProcessor struct {
+	traces  []transformGroup
+	logs    []transformGroup
+	metrics []transformGroup
+}
+
+// newTransformProcessor parses the statements of every signal in the config
+func newTransformProcessor(cfg transformProcessorConfig) (*transformProcessor, error) {
+	errorMode := ottl.PropagateError
+	if cfg.ErrorMode != "" {
+		if err := errorMode.UnmarshalText([]byte(cfg.ErrorMode)); err != nil {
+			return nil, err
+		}
+	}
+
+	p := &transformProcessor{}
+	var err error
+	if p.traces, err = parseTransformStatements("traces", cfg.TraceStatements, errorMode); err != nil {
+		return nil, fmt.Errorf("trace_statements: %w", err)
+	}
+	if p.logs, err = parseTransformStatements("logs", cfg.LogStatements, errorMode); err != nil {
+		return nil, fmt.Errorf("log_statements: %w", err)
+	}
+	if p.metrics, err = parseTransformStatements("metrics", cfg.MetricStatements, errorMode); err != nil {
+		return nil, fmt.Errorf("metric_statements: %w", err)
+	}
+	return p, nil
+}
+
+// parseTransformStatements parses statement groups with the contexts available to a signal.
+// Groups without a context have it inferred from their statements and conditions.
+func parseTransformStatements(signal string, list transformStatementsList, errorMode ottl.ErrorMode) ([]transformGroup, error) {
+	if len(list) == 0 {
+		return nil, nil
+	}
+
+	options := []ottl.ParserCollectionOption[transformGroup]{
+		ottl.WithParserCollectionErrorMode[transformGroup](errorMode),
+		withTransformContext(ottlresource.ContextName, ottlresource.NewParser, functions.Resource, ottlresource.EnablePathContextNames(), resourceTransformGroup),
+		withTransformContext(ottlscope.ContextName, ottlscope.NewParser, functions.Scope, ottlscope.EnablePathContextNames(), scopeTransformGroup),
+	}
+	switch signal {
+	case "traces":
+		options = append(options,
+			withTransformContext(ottlspan.ContextName, ottlspan.NewParser, functions.Span, ottlspan.EnablePathContextNames(), spanTransformGroup),
+			withTransformContext(ottlspanevent.ContextName, ottlspanevent.NewParser, functions.SpanEvent, ottlspanevent.EnablePathContextNames(), spanEventTransformGroup))
+	case "logs":
+		options = append(options,
+			withTransformContext(ottllog.ContextName, ottllog.NewParser, functions.Log, ottllog.EnablePathContextNames(), logTransformGroup))
+	case "metrics":
+		options = append(options,
+			withTransformContext(ottlmetric.ContextName, ottlmetric.NewParser, functions.Metric, ottlmetric.EnablePathContextNames(), metricTransformGroup),
+			withTransformContext(ottldatapoint.ContextName, ottldatapoint.NewParser, functions.DataPoint, ottldatapoint.EnablePathContextNames(), dataPointTransformGroup))
+	}
+
+	pc, err := ottl.NewParserCollection(componenttest.NewNopTelemetrySettings(), options...)
+	if err != nil {
+		return nil, err
+	}
+
+	groups := make([]transformGroup, 0, len(list))
+	for i, statements := range list {
+		var group transformGroup
+		if statements.Context != "" {
+			group, err = pc.ParseStatementsWithContext(statements.Context, statements, true)
+		} else {
+			group, err = pc.ParseStatements(statements, ottl.WithContextInferenceConditions(statements.Conditions))
+		}
+		if err != nil {
+			return nil, fmt.Errorf("group %d: %w", i, err)
+		}
+		groups = append(groups, group)
+	}
+	return groups, nil
+}
+
+// withTransformContext registers an OTTL context with a parser collection. The
+// converter builds a transformGroup from the parsed statements and the group
+// conditions, which use path context names only when the context was inferred.
+func withTransformContext[K any](
+	name string,
+	newParser func(map[string]ottl.Factory[K], component.TelemetrySettings, ...ottl.Option[K]) (ottl.Parser[K], error),
+	funcs map[string]ottl.Factory[K],
+	pathContextNames ottl.Option[K],
+	newGroup func(*transformSequence[K]) transformGroup,
+) ottl.ParserCollectionOption[transformGroup] {
+	return func(pc *ottl.ParserCollection[transformGroup]) error {
+		parser, err := newParser(funcs, pc.Settings, pathContextNames)
+		if err != nil {
+			return err
+		}
+		conditionParser, err := newParser(funcs, pc.Settings)
+		if err != nil {
+			return err
+		}
+
+		converter := func(pc *ottl.ParserCollection[transformGroup], getter ottl.StatementsGetter, parsed []*ottl.Statement[K]) (transformGroup, error) {
+			statements, ok := getter.(transformStatements)
+			if !ok {
+				return transformGroup{}, fmt.Errorf("unexpected statements type %T", getter)
+			}
+
+			errorMode := pc.ErrorMode
+			if statements.ErrorMode != "" {
+				if err := errorMode.UnmarshalText([]byte(statements.ErrorMode)); err != nil {
+					return transformGroup{}, err
+				}
+			}
+
+			seq := &transformSequence[K]{
+				statements: ottl.NewStatementSequence(parsed, pc.Settings, ottl.WithStatementSequenceErrorMode[K](errorMode)),
+			}
+			if len(statements.Conditions) > 0 {
+				p := conditionParser
+				if statements.Context == "" {
+					p = parser
+				}
+				conditions, err := p.ParseConditions(statements.Conditions)
+				if err != nil {
+					return transformGroup{}, err
+				}
+				sequence := ottl.NewConditionSequence(conditions, pc.Settings, ottl.WithConditionSequenceErrorMode[K](errorMode))
+				seq.conditions = &sequence
+			}
+			return newGroup(seq), nil
+		}
+
+		return ottl.WithParserCollectionContext(name, &parser, ottl.WithStatementConverter(converter))(pc)
+	}
+}
+
+// resourceTransformGroup runs resource statements against every signal
+func resourceTransformGroup(seq *transformSequence[ottlresource.TransformContext]) transformGroup {
+	return transformGroup{
+		traces: func(ctx context.Context, td ptrace.Traces) error {
+			for i := 0; i < td.ResourceSpans().Len(); i++ {
+				rs := td.ResourceSpans().At(i)
+				if err := seq.execute(ctx, ottlresource.NewTransformContext(rs.Resource(), rs)); err != nil {
+					return err
+				}
+			}
+			return nil
+		},
+		logs: func(ctx context.Context, ld plog.Logs) error {
+			for i := 0; i < ld.ResourceLogs().Len(); i++ {
+				rl := ld.ResourceLogs().At(i)
+				if err := seq.execute(ctx, ottlresource.NewTransformContext(rl.Resource(), rl)); err != nil {
+					return err
+				}
+			}
+			return nil
+		},
+		metrics: func(ctx context.Context, md pmetric.Metrics) error {
+			for i := 0; i < md.ResourceMetrics().Len(); i++ {
+				rm := md.ResourceMetrics().At(i)
+				if err := seq.execute(ctx, ottlresource.NewTransformContext(rm.Resource(), rm)); err != nil {
+					return err
+				}
+			}
+			return nil
+		},
+	}
+}
+
+// scopeTransformGroup runs scope statements against every signal
+func scopeTransformGroup(seq *transformSequence[ottlscope.TransformContext]) transformGroup {
+	return transformGroup{
+		traces: func(ctx context.Context, td ptrace.Traces) error {
+			for i := 0; i < td.ResourceSpans().Len(); i++ {
+				rs := td.ResourceSpans().At(i)
+				for j := 0; j < rs.ScopeSpans().Len(); j++ {
+					ss := rs.ScopeSpans().At(j)
+					if err := seq.execute(ctx, ottlscope.NewTransformContext(ss.Scope(), rs.Resource(), ss)); err != nil {
+						return err
+					}
+				}
+			}
+			return nil
+		},
+		logs: func(ctx context.Context, ld plog.Logs) error {
+			for i := 0; i < ld.ResourceLogs().Len(); i++ {
+				rl := ld.ResourceLogs().At(i)
+				for j := 0; j < rl.ScopeLogs().Len(); j++ {
+					sl := rl.ScopeLogs().At(j)
+					if err := seq.execute(ctx, ottlscope.NewTransformContext(sl.Scope(), rl.Resource(), sl)); err != nil {
+						return err
+					}
+				}
+			}
+			return nil
+		},
+		metrics: func(ctx context.Context, md pmetric.Metrics) error {
+			for i := 0; i < md.ResourceMetrics().Len(); i++ {
+				rm := md.ResourceMetrics().At(i)
+				for j := 0; j < rm.ScopeMetrics().Len(); j++ {
+					sm := rm.ScopeMetrics().At(j)
+					if err := seq.execute(ctx, ottlscope.NewTransformContext(sm.Scope(), rm.Resource(), sm)); err != nil {
+						return err
+					}
+				}
+			}
+			return nil
+		},
+	}
+}
+
+// spanTransformGroup runs span statements against traces
+func spanTransformGroup(seq *transformSequence[ottlspan.TransformContext]) transformGroup {
+	return transformGroup{
+		traces: func(ctx context.Context, td ptrace.Traces) error {
+			for i := 0; i < td.ResourceSpans().Len(); i++ {
+				rs := td.ResourceSpans().At(i)
+				for j := 0; j < rs.ScopeSpans().Len(); j++ {
+					ss := rs.ScopeSpans().At(j)
+					for k := 0; k < ss.Spans().Len(); k++ {
+						tCtx := ottlspan.NewTransformContext(ss.Spans().At(k), ss.Scope(), rs.Resource(), ss, rs)
+						if err := seq.execute(ctx, tCtx); err != nil {
+							return err
+						}
+					}
+				}
+			}
+			return nil
+		},
+	}
+}
+
+// spanEventTransformGroup runs span event statements against traces
+func spanEventTransformGroup(seq *transformSequence[ottlspanevent.TransformContext]) transformGroup {
+	return transformGroup{
+		traces: func(ctx context.Context, td ptrace.Traces) error {
+			for i := 0; i < td.ResourceSpans().Len(); i++ {
+				rs := td.ResourceSpans().At(i)
+				for j := 0; j < rs.ScopeSpans().Len(); j++ {
+					ss := rs.ScopeSpans().At(j)
+					for k := 0; k < ss.Spans().Len(); k++ {
+						span := ss.Spans().At(k)
+						for l := 0; l < span.Events().Len(); l++ {
+							tCtx := ottlspanevent.NewTransformContext(span.Events().At(l), span, ss.Scope(), rs.Resource(), ss, rs)
+							if err := seq.execute(ctx, tCtx); err != nil {
+								return err
+							}
+						}
+					}
+				}
+			}
+			return nil
+		},
+	}
+}
+
+// logTransformGroup runs log statements against logs
+func logTransformGroup(seq *transformSequence[ottllog.TransformContext]) transformGroup {
+	return transformGroup{
+		logs: func(ctx context.Context, ld plog.Logs) error {
+			for i := 0; i < ld.ResourceLogs().Len(); i++ {
+				rl := ld.ResourceLogs().At(i)
+				for j := 0; j < rl.ScopeLogs().Len(); j++ {
+					sl := rl.ScopeLogs().At(j)
+					for k := 0; k < sl.LogRecords().Len(); k++ {
+						tCtx := ottllog.NewTransformContext(sl.LogRecords().At(k), sl.Scope(), rl.Resource(), sl, rl)
+						if err := seq.execute(ctx, tCtx); err != nil {
+							return err
+						}
+					}
+				}
+			}
+			return nil
+		},
+	}
+}
+
+// metricTransformGroup runs metric statements against metrics
+func metricTransformGroup(seq *transformSequence[ottlmetric.TransformContext]) transformGroup {
+	return transformGroup{
+		metrics: func(ctx context.Context, md pmetric.Metrics) error {
+			for i := 0; i < md.ResourceMetrics().Len(); i++ {
+				rm := md.ResourceMetrics().At(i)
+				for j := 0; j < rm.ScopeMetrics().Len(); j++ {
+					sm := rm.ScopeMetrics().At(j)
+					// Functions such as copy_metric append to the slice, so its length is re-read every iteration
+					for k := 0; k < sm.Metrics().Len(); k++ {
+						tCtx := ottlmetric.NewTransformContext(sm.Metrics().At(k), sm.Metrics(), sm.Scope(), rm.Resource(), sm, rm)
+						if err := seq.execute(ctx, tCtx); err != nil {
+							return err
+						}
+					}
+				}
+			}
+			return nil
+		},
+	}
+}
+
+// dataPointTransformGroup runs datapoint statements against metrics
+func dataPointTransformGroup(seq *transformSequence[ottldatapoint.TransformContext]) transformGroup {
+	return transformGroup{
+		metrics: func(ctx context.Context, md pmetric.Metrics) error {
+			for i := 0; i < md.ResourceMetrics().Len(); i++ {
+				rm := md.ResourceMetrics().At(i)
+				for j := 0; j < rm.ScopeMetrics().Len(); j++ {
+					sm := rm.ScopeMetrics().At(j)
+					for k := 0; k < sm.Metrics().Len(); k++ {
+						metric := sm.Metrics().At(k)
+						err := forEachDataPoint(metric, func(_ int, dp any) error {
+							tCtx := ottldatapoint.NewTransformContext(dp, metric, sm.Metrics(), sm.Scope(), rm.Resource(), sm, rm)
+							return seq.execute(ctx, tCtx)
+						})
+						if err != nil {
+							return err
+						}
+					}
+				}
+			}
+			return nil
+		},
+	}
+}
+
+// processTraces runs the trace statement groups in order
+func (p *transformProcessor) processTraces(ctx context.Context, td ptrace.Traces) error {
+	for _, group := range p.traces {
+		if err := group.traces(ctx, td); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// processLogs runs the log statement groups in order
+func (p *transformProcessor) processLogs(ctx context.Context, ld plog.Logs) error {
+	for _, group := range p.logs {
+		if err := group.logs(ctx, ld); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// processMetrics runs the metric statement groups in order
+func (p *transformProcessor) processMetrics(ctx context.Context, md pmetric.Metrics) error {
+	for _, group := range p.metrics {
+		if err := group.metrics(ctx, md); err != nil {
+			return err
+		}
+	}
+	return nil
+}
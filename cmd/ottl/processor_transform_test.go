@@ -0,0 +1,134 @@
+package main
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/pdata/plog"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+	"gopkg.in/yaml.v3"
+)
+
+func TestTransformStatementsList(t *testing.T) {
+	tests := []struct {
+		name        string
+		config      string
+		expected    transformStatementsList
+		shouldError bool
+	}{
+		{
+			name:   "basic style",
+			config: "trace_statements: ['set(span.name, \"a\")', 'set(resource.attributes[\"b\"], 1)']",
+			expected: transformStatementsList{
+				{Statements: []string{`set(span.name, "a")`, `set(resource.attributes["b"], 1)`}},
+			},
+		},
+		{
+			name:   "advanced style",
+			config: "trace_statements:\n  - context: span\n    conditions: ['kind == 2']\n    statements: ['set(name, \"a\")']",
+			expected: transformStatementsList{
+				{Context: "span", Conditions: []string{"kind == 2"}, Statements: []string{`set(name, "a")`}},
+			},
+		},
+		{
+			name:        "mixed styles",
+			config:      "trace_statements:\n  - 'set(span.name, \"a\")'\n  - context: span\n    statements: ['set(name, \"a\")']",
+			shouldError: true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			var cfg transformProcessorConfig
+			err := yaml.Unmarshal([]byte(test.config), &cfg)
+
+			if test.shouldError {
+				assert.Error(t, err)
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, test.expected, cfg.TraceStatements)
+		})
+	}
+}
+
+func TestTransformProcessorTraces(t *testing.T) {
+	traces, err := (&ptrace.JSONUnmarshaler{}).UnmarshalTraces(readTestData(t, "traces.json"))
+	require.NoError(t, err)
+
+	p, err := newTransformProcessor(transformProcessorConfig{
+		TraceStatements: transformStatementsList{
+			{Statements: []string{`set(resource.attributes["env"], "prod")`}},
+			{Statements: []string{`set(span.attributes["method"], span.attributes["http.method"])`}},
+			{Context: "span", Conditions: []string{`attributes["http.method"] == "POST"`}, Statements: []string{`set(name, "post")`}},
+		},
+	})
+	require.NoError(t, err)
+	require.NoError(t, p.processTraces(context.Background(), traces))
+
+	rs := traces.ResourceSpans().At(0)
+	env, ok := rs.Resource().Attributes().Get("env")
+	require.True(t, ok)
+	assert.Equal(t, "prod", env.Str())
+
+	span := rs.ScopeSpans().At(0).Spans().At(0)
+	assert.Equal(t, "test-span", span.Name(), "the group condition should not match")
+	method, ok := span.Attributes().Get("method")
+	require.True(t, ok)
+	assert.Equal(t, "GET", method.Str())
+}
+
+func TestTransformProcessorMetrics(t *testing.T) {
+	metrics, err := (&pmetric.JSONUnmarshaler{}).UnmarshalMetrics(readTestData(t, "metrics.json"))
+	require.NoError(t, err)
+
+	p, err := newTransformProcessor(transformProcessorConfig{
+		MetricStatements: transformStatementsList{
+			{Context: "metric", Statements: []string{`set(description, "checked")`}},
+			{Context: "datapoint", Conditions: []string{`attributes["method"] == "GET"`}, Statements: []string{`set(attributes["get"], true)`}},
+		},
+	})
+	require.NoError(t, err)
+	require.NoError(t, p.processMetrics(context.Background(), metrics))
+
+	metric := metrics.ResourceMetrics().At(0).ScopeMetrics().At(0).Metrics().At(0)
+	assert.Equal(t, "checked", metric.Description())
+	_, ok := metric.Sum().DataPoints().At(0).Attributes().Get("get")
+	assert.True(t, ok)
+}
+
+func TestTransformProcessorErrorMode(t *testing.T) {
+	statements := transformStatementsList{
+		{Context: "log", Statements: []string{`set(attributes["code"], ParseJSON(body)["code"])`}},
+	}
+
+	logs, err := (&plog.JSONUnmarshaler{}).UnmarshalLogs(readTestData(t, "logs.json"))
+	require.NoError(t, err)
+
+	p, err := newTransformProcessor(transformProcessorConfig{LogStatements: statements})
+	require.NoError(t, err)
+	assert.Error(t, p.processLogs(context.Background(), logs), "errors should propagate by default")
+
+	p, err = newTransformProcessor(transformProcessorConfig{ErrorMode: "ignore", LogStatements: statements})
+	require.NoError(t, err)
+	assert.NoError(t, p.processLogs(context.Background(), logs))
+}
+
+func TestNewTransformProcessorErrors(t *testing.T) {
+	_, err := newTransformProcessor(transformProcessorConfig{ErrorMode: "unknown"})
+	assert.Error(t, err)
+
+	_, err = newTransformProcessor(transformProcessorConfig{
+		LogStatements: transformStatementsList{{Context: "span", Statements: []string{`set(name, "a")`}}},
+	})
+	assert.Error(t, err, "span context is not available to logs")
+
+	_, err = newTransformProcessor(transformProcessorConfig{
+		TraceStatements: transformStatementsList{{Statements: []string{`set(attributes["a"], 1)`}}},
+	})
+	assert.Error(t, err, "context cannot be inferred without path context names")
+}
@@ -0,0 +1,371 @@
+// Copyright 2025 Dose de Telemetria GmbH
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/binary"
+	"encoding/hex"
+	"fmt"
+	"path"
+	"regexp"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/plog"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.opentelemetry.io/collector/pdata/pprofile"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+)
+
+var redactCmd = &cobra.Command{
+	Use:   "redact",
+	Short: "Replace sensitive values in OTLP JSON data with deterministic hashes",
+	Long: `Reads OTLP JSON data and writes it back with sensitive values replaced by
+hashes, so that captured samples can be attached to test cases and bug reports.
+
+Attributes whose key matches one of --keys are replaced as a whole, at any level
+of nesting. In all other string values, including log bodies, the parts matching
+one of --values are replaced. With --bodies, log bodies are replaced as a whole,
+and with --ids, trace and span IDs are replaced too.
+
+Replacements keep the structure and the value types: strings become
+"redacted-" followed by a hash, numbers and booleans become other numbers and
+booleans, and IDs other IDs of the same size. The same value always gets the same
+replacement, so that records can still be grouped and spans still link to their
+parents. Use --salt to prevent short values from being recovered by hashing
+guesses.`,
+	Example: `  # Redact the default keys and patterns
+  ottl redact --input-file spans.json > spans-redacted.json
+
+  # Also hash log bodies and trace context, with a secret salt
+  ottl redact -i logs.json --bodies --ids --salt "$REDACT_SALT"
+
+  # Redact custom keys and card numbers only
+  ottl redact -i logs.json --keys 'customer.*,*.iban' --values '\b\d{4}(?:[ -]?\d{4}){3}\b'`,
+	Args: cobra.NoArgs,
+	RunE: runRedact,
+}
+
+var redactInputFile string
+var redactKeys []string
+var redactValues []string
+var redactBodies bool
+var redactIDs bool
+var redactSalt string
+
+// defaultRedactKeys are the attribute key patterns redacted by default
+var defaultRedactKeys = []string{
+	"*password*", "*passwd*", "*secret*", "*token*", "*api_key*", "*apikey*",
+	"*authorization*", "*cookie*", "*email*", "*phone*",
+	"user.*", "enduser.*", "client.address", "source.address", "net.peer.ip",
+}
+
+// defaultRedactValues are the value patterns redacted by default: email addresses and IPv4 addresses
+var defaultRedactValues = []string{
+	`[A-Za-z0-9._%+-]+@[A-Za-z0-9.-]+\.[A-Za-z]{2,}`,
+	`\b(?:\d{1,3}\.){3}\d{1,3}\b`,
+}
+
+func init() {
+	redactCmd.Flags().StringVarP(&redactInputFile, "input-file", "i", "", "Path to OTLP JSON input file (required)")
+	redactCmd.Flags().StringSliceVar(&redactKeys, "keys", defaultRedactKeys, "Attribute key patterns whose values are replaced, case-insensitive (* matches any characters)")
+	redactCmd.Flags().StringArrayVar(&redactValues, "values", defaultRedactValues, "Regular expression of the string parts that are replaced (repeatable)")
+	redactCmd.Flags().BoolVar(&redactBodies, "bodies", false, "Replace log bodies as a whole")
+	redactCmd.Flags().BoolVar(&redactIDs, "ids", false, "Replace trace and span IDs")
+	redactCmd.Flags().StringVar(&redactSalt, "salt", "", "Secret mixed into the hashes")
+	_ = redactCmd.MarkFlagRequired("input-file")
+	rootCmd.AddCommand(redactCmd)
+}
+
+// runRedact executes the redact command
+func runRedact(cmd *cobra.Command, args []string) error {
+	r, err := newRedactor(redactKeys, redactValues, redactBodies, redactIDs, redactSalt)
+	if err != nil {
+		return err
+	}
+
+	data, err := readInputFile(redactInputFile)
+	if err != nil {
+		return fmt.Errorf("failed to read input file: %w", err)
+	}
+
+	ctx, parsedData, err := detectContextType(data)
+	if err != nil {
+		return fmt.Errorf("failed to detect context type: %w", err)
+	}
+
+	r.redact(parsedData)
+	return outputTransformedData(ctx, parsedData)
+}
+
+// redactor replaces sensitive values in pdata with deterministic hashes
+type redactor struct {
+	keys   []string
+	values []*regexp.Regexp
+	bodies bool
+	ids    bool
+	salt   []byte
+}
+
+// newRedactor validates the key patterns and compiles the value patterns
+func newRedactor(keys, values []string, bodies, ids bool, salt string) (*redactor, error) {
+	r := &redactor{bodies: bodies, ids: ids, salt: []byte(salt)}
+	for _, key := range keys {
+		if _, err := path.Match(key, ""); err != nil {
+			return nil, fmt.Errorf("invalid key pattern %q: %w", key, err)
+		}
+		r.keys = append(r.keys, strings.ToLower(key))
+	}
+	for _, value := range values {
+		re, err := regexp.Compile(value)
+		if err != nil {
+			return nil, fmt.Errorf("invalid value pattern %q: %w", value, err)
+		}
+		r.values = append(r.values, re)
+	}
+	return r, nil
+}
+
+// digest returns the keyed hash of data
+func (r *redactor) digest(data []byte) []byte {
+	mac := hmac.New(sha256.New, r.salt)
+	mac.Write(data)
+	return mac.Sum(nil)
+}
+
+// hashString returns the replacement of a string
+func (r *redactor) hashString(s string) string {
+	return "redacted-" + hex.EncodeToString(r.digest([]byte(s)))[:12]
+}
+
+// matchesKey reports whether an attribute key matches one of the key patterns
+func (r *redactor) matchesKey(key string) bool {
+	key = strings.ToLower(key)
+	for _, pattern := range r.keys {
+		if matched, _ := path.Match(pattern, key); matched {
+			return true
+		}
+	}
+	return false
+}
+
+// replaceValue replaces a value as a whole, keeping its type
+func (r *redactor) replaceValue(value pcommon.Value) {
+	switch value.Type() {
+	case pcommon.ValueTypeStr:
+		value.SetStr(r.hashString(value.Str()))
+	case pcommon.ValueTypeInt:
+		sum := r.digest([]byte(fmt.Sprint(value.Int())))
+		value.SetInt(int64(binary.BigEndian.Uint64(sum) >> 1)) // #nosec G115 - shifted to fit
+	case pcommon.ValueTypeDouble:
+		sum := r.digest([]byte(fmt.Sprint(value.Double())))
+		value.SetDouble(float64(binary.BigEndian.Uint64(sum)%1_000_000) / 100)
+	case pcommon.ValueTypeBool:
+		sum := r.digest([]byte(fmt.Sprint(value.Bool())))
+		value.SetBool(sum[0]&1 == 1)
+	case pcommon.ValueTypeBytes:
+		original := value.Bytes().AsRaw()
+		replaced := make([]byte, 0, len(original))
+		for block := r.digest(original); len(replaced) < len(original); block = r.digest(block) {
+			replaced = append(replaced, block[:min(len(block), len(original)-len(replaced))]...)
+		}
+		value.SetEmptyBytes().FromRaw(replaced)
+	case pcommon.ValueTypeMap:
+		value.Map().Range(func(_ string, v pcommon.Value) bool {
+			r.replaceValue(v)
+			return true
+		})
+	case pcommon.ValueTypeSlice:
+		for i := 0; i < value.Slice().Len(); i++ {
+			r.replaceValue(value.Slice().At(i))
+		}
+	}
+}
+
+// redactValue replaces the parts of a value matching the value patterns, and the values of matching keys in maps
+func (r *redactor) redactValue(value pcommon.Value) {
+	switch value.Type() {
+	case pcommon.ValueTypeStr:
+		s := value.Str()
+		for _, re := range r.values {
+			s = re.ReplaceAllStringFunc(s, r.hashString)
+		}
+		if s != value.Str() {
+			value.SetStr(s)
+		}
+	case pcommon.ValueTypeMap:
+		r.redactMap(value.Map())
+	case pcommon.ValueTypeSlice:
+		for i := 0; i < value.Slice().Len(); i++ {
+			r.redactValue(value.Slice().At(i))
+		}
+	}
+}
+
+// redactMap redacts attributes, replacing the values of keys matching the key patterns as a whole
+func (r *redactor) redactMap(attrs pcommon.Map) {
+	attrs.Range(func(key string, value pcommon.Value) bool {
+		if r.matchesKey(key) {
+			r.replaceValue(value)
+		} else {
+			r.redactValue(value)
+		}
+		return true
+	})
+}
+
+// traceID returns the replacement of a trace ID, which is empty for an empty ID or when IDs are kept
+func (r *redactor) traceID(id pcommon.TraceID) pcommon.TraceID {
+	if !r.ids || id.IsEmpty() {
+		return id
+	}
+	var replaced pcommon.TraceID
+	copy(replaced[:], r.digest(id[:]))
+	return replaced
+}
+
+// spanID returns the replacement of a span ID, which is empty for an empty ID or when IDs are kept
+func (r *redactor) spanID(id pcommon.SpanID) pcommon.SpanID {
+	if !r.ids || id.IsEmpty() {
+		return id
+	}
+	var replaced pcommon.SpanID
+	copy(replaced[:], r.digest(id[:]))
+	return replaced
+}
+
+// redact redacts traces, logs, metrics or profiles in place
+func (r *redactor) redact(data interface{}) {
+	switch d := data.(type) {
+	case ptrace.Traces:
+		r.redactTraces(d)
+	case plog.Logs:
+		r.redactLogs(d)
+	case pmetric.Metrics:
+		r.redactMetrics(d)
+	case pprofile.Profiles:
+		r.redactProfiles(d)
+	}
+}
+
+// redactTraces redacts resources, scopes, spans, span events and span links
+func (r *redactor) redactTraces(traces ptrace.Traces) {
+	for i := 0; i < traces.ResourceSpans().Len(); i++ {
+		rs := traces.ResourceSpans().At(i)
+		r.redactMap(rs.Resource().Attributes())
+		for j := 0; j < rs.ScopeSpans().Len(); j++ {
+			ss := rs.ScopeSpans().At(j)
+			r.redactMap(ss.Scope().Attributes())
+			for k := 0; k < ss.Spans().Len(); k++ {
+				span := ss.Spans().At(k)
+				r.redactMap(span.Attributes())
+				span.SetTraceID(r.traceID(span.TraceID()))
+				span.SetSpanID(r.spanID(span.SpanID()))
+				span.SetParentSpanID(r.spanID(span.ParentSpanID()))
+				for l := 0; l < span.Events().Len(); l++ {
+					r.redactMap(span.Events().At(l).Attributes())
+				}
+				for l := 0; l < span.Links().Len(); l++ {
+					link := span.Links().At(l)
+					r.redactMap(link.Attributes())
+					link.SetTraceID(r.traceID(link.TraceID()))
+					link.SetSpanID(r.spanID(link.SpanID()))
+				}
+			}
+		}
+	}
+}
+
+// redactLogs redacts resources, scopes and log records, including their bodies
+func (r *redactor) redactLogs(logs plog.Logs) {
+	for i := 0; i < logs.ResourceLogs().Len(); i++ {
+		rl := logs.ResourceLogs().At(i)
+		r.redactMap(rl.Resource().Attributes())
+		for j := 0; j < rl.ScopeLogs().Len(); j++ {
+			sl := rl.ScopeLogs().At(j)
+			r.redactMap(sl.Scope().Attributes())
+			for k := 0; k < sl.LogRecords().Len(); k++ {
+				logRecord := sl.LogRecords().At(k)
+				r.redactMap(logRecord.Attributes())
+				if r.bodies {
+					r.replaceValue(logRecord.Body())
+				} else {
+					r.redactValue(logRecord.Body())
+				}
+				logRecord.SetTraceID(r.traceID(logRecord.TraceID()))
+				logRecord.SetSpanID(r.spanID(logRecord.SpanID()))
+			}
+		}
+	}
+}
+
+// redactMetrics redacts resources, scopes, data points and exemplars
+func (r *redactor) redactMetrics(metrics pmetric.Metrics) {
+	for i := 0; i < metrics.ResourceMetrics().Len(); i++ {
+		rm := metrics.ResourceMetrics().At(i)
+		r.redactMap(rm.Resource().Attributes())
+		for j := 0; j < rm.ScopeMetrics().Len(); j++ {
+			sm := rm.ScopeMetrics().At(j)
+			r.redactMap(sm.Scope().Attributes())
+			for k := 0; k < sm.Metrics().Len(); k++ {
+				_ = forEachDataPoint(sm.Metrics().At(k), func(_ int, dp any) error {
+					r.redactDataPoint(dp)
+					return nil
+				})
+			}
+		}
+	}
+}
+
+// redactDataPoint redacts the attributes and exemplars of a data point of any type
+func (r *redactor) redactDataPoint(dp any) {
+	if attributed, ok := dp.(interface{ Attributes() pcommon.Map }); ok {
+		r.redactMap(attributed.Attributes())
+	}
+	withExemplars, ok := dp.(interface{ Exemplars() pmetric.ExemplarSlice })
+	if !ok {
+		return
+	}
+	for i := 0; i < withExemplars.Exemplars().Len(); i++ {
+		exemplar := withExemplars.Exemplars().At(i)
+		r.redactMap(exemplar.FilteredAttributes())
+		exemplar.SetTraceID(r.traceID(exemplar.TraceID()))
+		exemplar.SetSpanID(r.spanID(exemplar.SpanID()))
+	}
+}
+
+// redactProfiles redacts resources, scopes and the attribute table that profiles and samples
+// reference their attributes from
+func (r *redactor) redactProfiles(profiles pprofile.Profiles) {
+	attributes := profiles.ProfilesDictionary().AttributeTable()
+	for i := 0; i < attributes.Len(); i++ {
+		attribute := attributes.At(i)
+		if r.matchesKey(attribute.Key()) {
+			r.replaceValue(attribute.Value())
+		} else {
+			r.redactValue(attribute.Value())
+		}
+	}
+	for i := 0; i < profiles.ResourceProfiles().Len(); i++ {
+		rp := profiles.ResourceProfiles().At(i)
+		r.redactMap(rp.Resource().Attributes())
+		for j := 0; j < rp.ScopeProfiles().Len(); j++ {
+			r.redactMap(rp.ScopeProfiles().At(j).Scope().Attributes())
+		}
+	}
+}
@@ -0,0 +1,190 @@
+package main
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/plog"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+)
+
+func TestNewRedactorInvalidPatterns(t *testing.T) {
+	_, err := newRedactor([]string{"user.["}, nil, false, false, "")
+	assert.ErrorContains(t, err, `invalid key pattern "user.["`)
+
+	_, err = newRedactor(nil, []string{"("}, false, false, "")
+	assert.ErrorContains(t, err, `invalid value pattern "("`)
+}
+
+func TestRedactorAttributes(t *testing.T) {
+	r, err := newRedactor(defaultRedactKeys, defaultRedactValues, false, false, "")
+	require.NoError(t, err)
+
+	attrs := pcommon.NewMap()
+	attrs.PutStr("User.Email", "jane@example.com")
+	attrs.PutInt("user.age", 42)
+	attrs.PutDouble("user.score", 1.5)
+	attrs.PutBool("user.admin", true)
+	attrs.PutEmptyBytes("session.token").FromRaw([]byte{1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13, 14, 15, 16, 17, 18, 19, 20, 21, 22, 23, 24, 25, 26, 27, 28, 29, 30, 31, 32, 33})
+	attrs.PutStr("message", "login from 10.0.0.1 by jane@example.com")
+	attrs.PutStr("http.route", "/api/users/{id}")
+	nested := attrs.PutEmptyMap("request")
+	nested.PutStr("authorization", "Bearer abc")
+	nested.PutEmptySlice("peers").AppendEmpty().SetStr("192.168.1.1")
+
+	r.redactMap(attrs)
+
+	email, _ := attrs.Get("User.Email")
+	assert.Equal(t, r.hashString("jane@example.com"), email.Str())
+	assert.Regexp(t, `^redacted-[0-9a-f]{12}$`, email.Str())
+
+	for key, want := range map[string]pcommon.ValueType{
+		"user.age":      pcommon.ValueTypeInt,
+		"user.score":    pcommon.ValueTypeDouble,
+		"user.admin":    pcommon.ValueTypeBool,
+		"session.token": pcommon.ValueTypeBytes,
+	} {
+		value, ok := attrs.Get(key)
+		require.True(t, ok, key)
+		assert.Equal(t, want, value.Type(), key)
+	}
+	age, _ := attrs.Get("user.age")
+	assert.NotEqual(t, int64(42), age.Int())
+	assert.GreaterOrEqual(t, age.Int(), int64(0))
+	token, _ := attrs.Get("session.token")
+	assert.Len(t, token.Bytes().AsRaw(), 33)
+
+	message, _ := attrs.Get("message")
+	assert.Equal(t, "login from "+r.hashString("10.0.0.1")+" by "+r.hashString("jane@example.com"), message.Str())
+
+	route, _ := attrs.Get("http.route")
+	assert.Equal(t, "/api/users/{id}", route.Str())
+
+	authorization, _ := nested.Get("authorization")
+	assert.Equal(t, r.hashString("Bearer abc"), authorization.Str())
+	peers, _ := nested.Get("peers")
+	assert.Equal(t, r.hashString("192.168.1.1"), peers.Slice().At(0).Str())
+}
+
+func TestRedactorSalt(t *testing.T) {
+	plain, err := newRedactor(nil, nil, false, false, "")
+	require.NoError(t, err)
+	salted, err := newRedactor(nil, nil, false, false, "secret")
+	require.NoError(t, err)
+
+	assert.Equal(t, plain.hashString("jane"), plain.hashString("jane"))
+	assert.NotEqual(t, plain.hashString("jane"), salted.hashString("jane"))
+}
+
+func TestRedactorTraces(t *testing.T) {
+	tests := []struct {
+		name string
+		ids  bool
+	}{
+		{name: "keep ids", ids: false},
+		{name: "replace ids", ids: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			traces := ptrace.NewTraces()
+			spans := traces.ResourceSpans().AppendEmpty().ScopeSpans().AppendEmpty().Spans()
+			parent := spans.AppendEmpty()
+			parent.SetTraceID(pcommon.TraceID{1, 2, 3})
+			parent.SetSpanID(pcommon.SpanID{1})
+			child := spans.AppendEmpty()
+			child.SetTraceID(pcommon.TraceID{1, 2, 3})
+			child.SetSpanID(pcommon.SpanID{2})
+			child.SetParentSpanID(pcommon.SpanID{1})
+			child.Events().AppendEmpty().Attributes().PutStr("enduser.id", "jane")
+
+			r, err := newRedactor(defaultRedactKeys, nil, false, tt.ids, "")
+			require.NoError(t, err)
+			r.redact(traces)
+
+			assert.Equal(t, parent.TraceID(), child.TraceID())
+			assert.Equal(t, parent.SpanID(), child.ParentSpanID())
+			assert.True(t, parent.ParentSpanID().IsEmpty())
+			assert.Equal(t, !tt.ids, parent.TraceID() == pcommon.TraceID{1, 2, 3})
+			assert.Equal(t, !tt.ids, child.SpanID() == pcommon.SpanID{2})
+
+			user, _ := child.Events().At(0).Attributes().Get("enduser.id")
+			assert.Equal(t, r.hashString("jane"), user.Str())
+		})
+	}
+}
+
+func TestRedactorLogBodies(t *testing.T) {
+	tests := []struct {
+		name   string
+		bodies bool
+		want   func(r *redactor) string
+	}{
+		{
+			name: "matching parts",
+			want: func(r *redactor) string { return "password reset for " + r.hashString("jane@example.com") },
+		},
+		{
+			name:   "whole body",
+			bodies: true,
+			want:   func(r *redactor) string { return r.hashString("password reset for jane@example.com") },
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			logs := plog.NewLogs()
+			logRecord := logs.ResourceLogs().AppendEmpty().ScopeLogs().AppendEmpty().LogRecords().AppendEmpty()
+			logRecord.Body().SetStr("password reset for jane@example.com")
+
+			r, err := newRedactor(defaultRedactKeys, defaultRedactValues, tt.bodies, false, "")
+			require.NoError(t, err)
+			r.redact(logs)
+
+			assert.Equal(t, tt.want(r), logRecord.Body().Str())
+		})
+	}
+}
+
+func TestRedactorMetrics(t *testing.T) {
+	metrics := pmetric.NewMetrics()
+	dp := metrics.ResourceMetrics().AppendEmpty().ScopeMetrics().AppendEmpty().Metrics().AppendEmpty().SetEmptyHistogram().DataPoints().AppendEmpty()
+	dp.Attributes().PutStr("client.address", "10.1.2.3")
+	exemplar := dp.Exemplars().AppendEmpty()
+	exemplar.FilteredAttributes().PutStr("user.name", "jane")
+	exemplar.SetTraceID(pcommon.TraceID{9})
+
+	r, err := newRedactor(defaultRedactKeys, nil, false, true, "")
+	require.NoError(t, err)
+	r.redact(metrics)
+
+	address, _ := dp.Attributes().Get("client.address")
+	assert.Equal(t, r.hashString("10.1.2.3"), address.Str())
+	user, _ := exemplar.FilteredAttributes().Get("user.name")
+	assert.Equal(t, r.hashString("jane"), user.Str())
+	assert.NotEqual(t, pcommon.TraceID{9}, exemplar.TraceID())
+	assert.True(t, exemplar.SpanID().IsEmpty())
+}
+
+func TestRedactorKeepsTestData(t *testing.T) {
+	r, err := newRedactor(defaultRedactKeys, defaultRedactValues, false, false, "")
+	require.NoError(t, err)
+
+	for _, filename := range []string{"traces.json", "logs.json", "metrics.json"} {
+		t.Run(filename, func(t *testing.T) {
+			data := readTestData(t, filename)
+			_, parsed, err := detectContextType(data)
+			require.NoError(t, err)
+			before, err := marshalOTLPJSON(parsed)
+			require.NoError(t, err)
+
+			r.redact(parsed)
+			after, err := marshalOTLPJSON(parsed)
+			require.NoError(t, err)
+			assert.JSONEq(t, string(before), string(after))
+		})
+	}
+}
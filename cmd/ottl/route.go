@@ -0,0 +1,753 @@
+// Copyright 2025 Dose de Telemetria GmbH
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl/contexts/ottldatapoint"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl/contexts/ottllog"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl/contexts/ottlmetric"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl/contexts/ottlresource"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl/contexts/ottlspan"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl/ottlfuncs"
+	"github.com/spf13/cobra"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/pdata/plog"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+	"gopkg.in/yaml.v3"
+)
+
+var routeCmd = &cobra.Command{
+	Use:   "route",
+	Short: "Show how the routing connector would route OTLP data",
+	Long: `Evaluates a routing connector table against the OTLP JSON data in the
+specified input file and prints the pipeline(s) each record would be routed to.
+With --output-dir, the input is split into one OTLP JSON file per pipeline instead.
+
+Routes are evaluated in order and each record takes the first route that
+matches, like the routing connector does. Records that match no route go to
+the default pipelines, or are dropped when none are configured.
+
+The config file holds either the routing connector settings (default_pipelines,
+error_mode, table) or a full collector config, in which case the connector is
+selected with --connector.`,
+	Example: `  # Print the route of every span
+  ottl route --config routing.yaml --input-file spans.json
+
+  # Use the routing connector from a collector config
+  ottl route --config collector.yaml --connector routing/tenants --input-file logs.json
+
+  # Split the input into one file per pipeline
+  ottl route --config routing.yaml --input-file metrics.json --output-dir ./routed`,
+	Args: cobra.NoArgs,
+	RunE: runRoute,
+}
+
+var routeInputFile string
+var routeConfigFile string
+var routeConnector string
+var routeOutputDir string
+
+func init() {
+	routeCmd.Flags().StringVarP(&routeInputFile, "input-file", "i", "", "Path to OTLP JSON input file (required)")
+	routeCmd.Flags().StringVarP(&routeConfigFile, "config", "c", "", "Path to routing connector or collector config YAML (required)")
+	routeCmd.Flags().StringVar(&routeConnector, "connector", "", "Routing connector ID in a collector config (default: the only routing connector)")
+	routeCmd.Flags().StringVarP(&routeOutputDir, "output-dir", "o", "", "Write one OTLP JSON file per pipeline to this directory")
+	_ = routeCmd.MarkFlagRequired("input-file")
+	_ = routeCmd.MarkFlagRequired("config")
+	rootCmd.AddCommand(routeCmd)
+}
+
+// routingConfig mirrors the routing connector configuration
+type routingConfig struct {
+	ErrorMode        string             `yaml:"error_mode"`
+	DefaultPipelines []string           `yaml:"default_pipelines"`
+	Table            []routingTableItem `yaml:"table"`
+}
+
+// routingTableItem mirrors a routing connector table entry
+type routingTableItem struct {
+	Context   string   `yaml:"context"`
+	Statement string   `yaml:"statement"`
+	Condition string   `yaml:"condition"`
+	Pipelines []string `yaml:"pipelines"`
+}
+
+// routeResult describes where a single record is routed
+type routeResult struct {
+	Locator   string
+	Name      string
+	Pipelines []string
+	Errors    []string
+}
+
+// compiledRoute holds the parsed statement of a routing table entry
+type compiledRoute struct {
+	context   string
+	pipelines []string
+	resource  *ottl.Statement[ottlresource.TransformContext]
+	span      *ottl.Statement[ottlspan.TransformContext]
+	log       *ottl.Statement[ottllog.TransformContext]
+	metric    *ottl.Statement[ottlmetric.TransformContext]
+	datapoint *ottl.Statement[ottldatapoint.TransformContext]
+}
+
+// router evaluates a routing table against records
+type router struct {
+	routes           []compiledRoute
+	defaultPipelines []string
+	errorMode        ottl.ErrorMode
+}
+
+// runRoute executes the route command
+func runRoute(cmd *cobra.Command, args []string) error {
+	configData, err := readInputFile(routeConfigFile)
+	if err != nil {
+		return fmt.Errorf("failed to read routing config: %w", err)
+	}
+
+	cfg, err := loadRoutingConfig(configData, routeConnector)
+	if err != nil {
+		return fmt.Errorf("invalid routing config: %w", err)
+	}
+
+	r, err := newRouter(cfg)
+	if err != nil {
+		return fmt.Errorf("invalid routing config: %w", err)
+	}
+
+	data, err := readInputFile(routeInputFile)
+	if err != nil {
+		return fmt.Errorf("failed to read input file: %w", err)
+	}
+
+	ctx, parsedData, err := detectContextType(data)
+	if err != nil {
+		return fmt.Errorf("failed to detect context type: %w", err)
+	}
+
+	results, groups, err := r.routeData(ctx, parsedData)
+	if err != nil {
+		return fmt.Errorf("routing failed: %w", err)
+	}
+
+	if routeOutputDir != "" {
+		return writeRouteGroups(routeOutputDir, groups)
+	}
+
+	writeRouteResults(os.Stdout, results)
+	return nil
+}
+
+// loadRoutingConfig reads a routing connector config, either standalone or from a collector config
+func loadRoutingConfig(data []byte, connectorID string) (routingConfig, error) {
+	var raw map[string]interface{}
+	if err := yaml.Unmarshal(data, &raw); err != nil {
+		return routingConfig{}, fmt.Errorf("cannot parse YAML: %w", err)
+	}
+
+	section := interface{}(raw)
+	if connectors, ok := raw["connectors"].(map[string]interface{}); ok {
+		selected, err := selectRoutingConnector(connectors, connectorID)
+		if err != nil {
+			return routingConfig{}, err
+		}
+		section = selected
+	} else if connectorID != "" {
+		return routingConfig{}, fmt.Errorf("--connector %s given but the config has no connectors section", connectorID)
+	}
+
+	// Round-trip the selected section through YAML to decode it into the typed config
+	sectionData, err := yaml.Marshal(section)
+	if err != nil {
+		return routingConfig{}, err
+	}
+	var cfg routingConfig
+	if err := yaml.Unmarshal(sectionData, &cfg); err != nil {
+		return routingConfig{}, fmt.Errorf("cannot decode routing config: %w", err)
+	}
+
+	return cfg, cfg.validate()
+}
+
+// selectRoutingConnector picks the routing connector settings from a collector connectors section
+func selectRoutingConnector(connectors map[string]interface{}, connectorID string) (interface{}, error) {
+	if connectorID != "" {
+		section, ok := connectors[connectorID]
+		if !ok {
+			return nil, fmt.Errorf("connector %s not found in config", connectorID)
+		}
+		return section, nil
+	}
+
+	var ids []string
+	for id := range connectors {
+		if id == "routing" || strings.HasPrefix(id, "routing/") {
+			ids = append(ids, id)
+		}
+	}
+	sort.Strings(ids)
+
+	switch len(ids) {
+	case 0:
+		return nil, fmt.Errorf("no routing connector found in config")
+	case 1:
+		return connectors[ids[0]], nil
+	default:
+		return nil, fmt.Errorf("multiple routing connectors found (%s), select one with --connector", strings.Join(ids, ", "))
+	}
+}
+
+// validate applies the same checks as the routing connector
+func (c routingConfig) validate() error {
+	if len(c.Table) == 0 {
+		return errors.New("the routing table is empty")
+	}
+
+	for i, item := range c.Table {
+		if item.Statement == "" && item.Condition == "" {
+			return fmt.Errorf("route %d: no condition or statement provided", i)
+		}
+		if item.Statement != "" && item.Condition != "" {
+			return fmt.Errorf("route %d: both condition and statement provided", i)
+		}
+		if len(item.Pipelines) == 0 {
+			return fmt.Errorf("route %d: no pipelines defined", i)
+		}
+
+		switch item.Context {
+		case "", "resource", "span", "metric", "datapoint", "log":
+		case "request":
+			return fmt.Errorf("route %d: the request context depends on request metadata and cannot be evaluated offline", i)
+		default:
+			return fmt.Errorf("route %d: invalid context: %s", i, item.Context)
+		}
+	}
+
+	return nil
+}
+
+// routeFunctions returns the functions the routing connector makes available to its statements
+func routeFunctions[K any]() map[string]ottl.Factory[K] {
+	funcs := ottlfuncs.StandardConverters[K]()
+
+	deleteKey := ottlfuncs.NewDeleteKeyFactory[K]()
+	funcs[deleteKey.Name()] = deleteKey
+
+	deleteMatchingKeys := ottlfuncs.NewDeleteMatchingKeysFactory[K]()
+	funcs[deleteMatchingKeys.Name()] = deleteMatchingKeys
+
+	route := ottl.NewFactory("route", nil, func(ottl.FunctionContext, ottl.Arguments) (ottl.ExprFunc[K], error) {
+		return func(context.Context, K) (any, error) {
+			return true, nil
+		}, nil
+	})
+	funcs[route.Name()] = route
+
+	return funcs
+}
+
+// newRouter parses the statements of every route in the table
+func newRouter(cfg routingConfig) (*router, error) {
+	r := &router{
+		defaultPipelines: cfg.DefaultPipelines,
+		errorMode:        ottl.PropagateError,
+	}
+	if cfg.ErrorMode != "" {
+		if err := r.errorMode.UnmarshalText([]byte(cfg.ErrorMode)); err != nil {
+			return nil, err
+		}
+	}
+
+	settings := componenttest.NewNopTelemetrySettings()
+	seen := map[string]bool{}
+
+	for i, item := range cfg.Table {
+		statement := item.Statement
+		if item.Condition != "" {
+			statement = fmt.Sprintf("route() where %s", item.Condition)
+		}
+
+		// Like the connector, only the first route with a given statement is used
+		key := item.Context + "|" + statement
+		if item.Context == "resource" {
+			key = "|" + statement
+		}
+		if seen[key] {
+			continue
+		}
+		seen[key] = true
+
+		route := compiledRoute{context: item.Context, pipelines: item.Pipelines}
+		var err error
+		switch item.Context {
+		case "", "resource":
+			route.context = "resource"
+			var parser ottl.Parser[ottlresource.TransformContext]
+			if parser, err = ottlresource.NewParser(routeFunctions[ottlresource.TransformContext](), settings); err == nil {
+				route.resource, err = parser.ParseStatement(statement)
+			}
+		case "span":
+			funcs := routeFunctions[ottlspan.TransformContext]()
+			isRootSpan := ottlfuncs.NewIsRootSpanFactory()
+			funcs[isRootSpan.Name()] = isRootSpan
+			var parser ottl.Parser[ottlspan.TransformContext]
+			if parser, err = ottlspan.NewParser(funcs, settings); err == nil {
+				route.span, err = parser.ParseStatement(statement)
+			}
+		case "log":
+			var parser ottl.Parser[ottllog.TransformContext]
+			if parser, err = ottllog.NewParser(routeFunctions[ottllog.TransformContext](), settings); err == nil {
+				route.log, err = parser.ParseStatement(statement)
+			}
+		case "metric":
+			var parser ottl.Parser[ottlmetric.TransformContext]
+			if parser, err = ottlmetric.NewParser(routeFunctions[ottlmetric.TransformContext](), settings); err == nil {
+				route.metric, err = parser.ParseStatement(statement)
+			}
+		case "datapoint":
+			var parser ottl.Parser[ottldatapoint.TransformContext]
+			if parser, err = ottldatapoint.NewParser(routeFunctions[ottldatapoint.TransformContext](), settings); err == nil {
+				route.datapoint, err = parser.ParseStatement(statement)
+			}
+		}
+		if err != nil {
+			return nil, fmt.Errorf("route %d: failed to parse statement '%s': %w", i, statement, err)
+		}
+
+		r.routes = append(r.routes, route)
+	}
+
+	return r, nil
+}
+
+// match records the outcome of evaluating one route. It returns true when the route matched.
+func (r *router) match(result *routeResult, route compiledRoute, matched bool, err error) (bool, error) {
+	if err != nil {
+		if r.errorMode == ottl.PropagateError {
+			return false, fmt.Errorf("%s: %w", result.Locator, err)
+		}
+		result.Errors = append(result.Errors, err.Error())
+		return false, nil
+	}
+	if matched {
+		result.Pipelines = route.pipelines
+	}
+	return matched, nil
+}
+
+// routeData routes every record of the parsed data, returning per-record results
+// and the data grouped by pipeline
+func (r *router) routeData(ctx contextType, data interface{}) ([]routeResult, map[string]interface{}, error) {
+	switch ctx {
+	case contextTypeSpan:
+		traces, ok := data.(ptrace.Traces)
+		if !ok {
+			return nil, nil, fmt.Errorf("expected ptrace.Traces but got %T", data)
+		}
+		return r.routeTraces(traces)
+	case contextTypeLog:
+		logs, ok := data.(plog.Logs)
+		if !ok {
+			return nil, nil, fmt.Errorf("expected plog.Logs but got %T", data)
+		}
+		return r.routeLogs(logs)
+	case contextTypeMetric, contextTypeDatapoint:
+		metrics, ok := data.(pmetric.Metrics)
+		if !ok {
+			return nil, nil, fmt.Errorf("expected pmetric.Metrics but got %T", data)
+		}
+		return r.routeMetrics(metrics)
+	default:
+		return nil, nil, fmt.Errorf("unsupported context type: %s", ctx)
+	}
+}
+
+// routeTraces routes every span in the resource or span context
+func (r *router) routeTraces(traces ptrace.Traces) ([]routeResult, map[string]interface{}, error) {
+	var results []routeResult
+	groups := map[string]ptrace.Traces{}
+	scopes := map[string]ptrace.ScopeSpans{}
+
+	resourceSpans := traces.ResourceSpans()
+	for i := 0; i < resourceSpans.Len(); i++ {
+		rs := resourceSpans.At(i)
+		scopeSpans := rs.ScopeSpans()
+
+		for j := 0; j < scopeSpans.Len(); j++ {
+			ss := scopeSpans.At(j)
+			spans := ss.Spans()
+
+			for k := 0; k < spans.Len(); k++ {
+				span := spans.At(k)
+				result := routeResult{
+					Locator:   fmt.Sprintf("resource[%d].scope[%d].span[%d]", i, j, k),
+					Name:      span.Name(),
+					Pipelines: r.defaultPipelines,
+				}
+
+				for _, route := range r.routes {
+					var matched bool
+					var err error
+					switch route.context {
+					case "resource":
+						_, matched, err = route.resource.Execute(context.Background(), ottlresource.NewTransformContext(rs.Resource(), rs))
+					case "span":
+						_, matched, err = route.span.Execute(context.Background(), ottlspan.NewTransformContext(span, ss.Scope(), rs.Resource(), ss, rs))
+					default:
+						// The connector ignores routes of other contexts for traces
+						continue
+					}
+					if ok, err := r.match(&result, route, matched, err); err != nil {
+						return nil, nil, err
+					} else if ok {
+						break
+					}
+				}
+
+				for _, pipeline := range result.Pipelines {
+					key := fmt.Sprintf("%s|%d|%d", pipeline, i, j)
+					group, ok := scopes[key]
+					if !ok {
+						if _, exists := groups[pipeline]; !exists {
+							groups[pipeline] = ptrace.NewTraces()
+						}
+						dest := groups[pipeline].ResourceSpans().AppendEmpty()
+						rs.Resource().CopyTo(dest.Resource())
+						dest.SetSchemaUrl(rs.SchemaUrl())
+						group = dest.ScopeSpans().AppendEmpty()
+						ss.Scope().CopyTo(group.Scope())
+						group.SetSchemaUrl(ss.SchemaUrl())
+						scopes[key] = group
+					}
+					span.CopyTo(group.Spans().AppendEmpty())
+				}
+				results = append(results, result)
+			}
+		}
+	}
+
+	out := make(map[string]interface{}, len(groups))
+	for pipeline, group := range groups {
+		out[pipeline] = group
+	}
+	return results, out, nil
+}
+
+// routeLogs routes every log record in the resource or log context
+func (r *router) routeLogs(logs plog.Logs) ([]routeResult, map[string]interface{}, error) {
+	var results []routeResult
+	groups := map[string]plog.Logs{}
+	scopes := map[string]plog.ScopeLogs{}
+
+	resourceLogs := logs.ResourceLogs()
+	for i := 0; i < resourceLogs.Len(); i++ {
+		rl := resourceLogs.At(i)
+		scopeLogs := rl.ScopeLogs()
+
+		for j := 0; j < scopeLogs.Len(); j++ {
+			sl := scopeLogs.At(j)
+			logRecords := sl.LogRecords()
+
+			for k := 0; k < logRecords.Len(); k++ {
+				logRecord := logRecords.At(k)
+				result := routeResult{
+					Locator:   fmt.Sprintf("resource[%d].scope[%d].log[%d]", i, j, k),
+					Name:      logRecord.SeverityText(),
+					Pipelines: r.defaultPipelines,
+				}
+
+				for _, route := range r.routes {
+					var matched bool
+					var err error
+					switch route.context {
+					case "resource":
+						_, matched, err = route.resource.Execute(context.Background(), ottlresource.NewTransformContext(rl.Resource(), rl))
+					case "log":
+						_, matched, err = route.log.Execute(context.Background(), ottllog.NewTransformContext(logRecord, sl.Scope(), rl.Resource(), sl, rl))
+					default:
+						// The connector ignores routes of other contexts for logs
+						continue
+					}
+					if ok, err := r.match(&result, route, matched, err); err != nil {
+						return nil, nil, err
+					} else if ok {
+						break
+					}
+				}
+
+				for _, pipeline := range result.Pipelines {
+					key := fmt.Sprintf("%s|%d|%d", pipeline, i, j)
+					group, ok := scopes[key]
+					if !ok {
+						if _, exists := groups[pipeline]; !exists {
+							groups[pipeline] = plog.NewLogs()
+						}
+						dest := groups[pipeline].ResourceLogs().AppendEmpty()
+						rl.Resource().CopyTo(dest.Resource())
+						dest.SetSchemaUrl(rl.SchemaUrl())
+						group = dest.ScopeLogs().AppendEmpty()
+						sl.Scope().CopyTo(group.Scope())
+						group.SetSchemaUrl(sl.SchemaUrl())
+						scopes[key] = group
+					}
+					logRecord.CopyTo(group.LogRecords().AppendEmpty())
+				}
+				results = append(results, result)
+			}
+		}
+	}
+
+	out := make(map[string]interface{}, len(groups))
+	for pipeline, group := range groups {
+		out[pipeline] = group
+	}
+	return results, out, nil
+}
+
+// routeMetrics routes every data point in the resource, metric or datapoint context
+func (r *router) routeMetrics(metrics pmetric.Metrics) ([]routeResult, map[string]interface{}, error) {
+	var results []routeResult
+	groups := map[string]pmetric.Metrics{}
+	scopes := map[string]pmetric.ScopeMetrics{}
+	descriptions := map[string]pmetric.Metric{}
+
+	resourceMetrics := metrics.ResourceMetrics()
+	for i := 0; i < resourceMetrics.Len(); i++ {
+		rm := resourceMetrics.At(i)
+		scopeMetrics := rm.ScopeMetrics()
+
+		for j := 0; j < scopeMetrics.Len(); j++ {
+			sm := scopeMetrics.At(j)
+			metricSlice := sm.Metrics()
+
+			for k := 0; k < metricSlice.Len(); k++ {
+				metric := metricSlice.At(k)
+
+				err := forEachDataPoint(metric, func(l int, dp any) error {
+					result := routeResult{
+						Locator:   fmt.Sprintf("resource[%d].scope[%d].metric[%d].datapoint[%d]", i, j, k, l),
+						Name:      metric.Name(),
+						Pipelines: r.defaultPipelines,
+					}
+
+					for _, route := range r.routes {
+						var matched bool
+						var err error
+						switch route.context {
+						case "resource":
+							_, matched, err = route.resource.Execute(context.Background(), ottlresource.NewTransformContext(rm.Resource(), rm))
+						case "metric":
+							_, matched, err = route.metric.Execute(context.Background(), ottlmetric.NewTransformContext(metric, metricSlice, sm.Scope(), rm.Resource(), sm, rm))
+						case "datapoint":
+							_, matched, err = route.datapoint.Execute(context.Background(), ottldatapoint.NewTransformContext(dp, metric, metricSlice, sm.Scope(), rm.Resource(), sm, rm))
+						default:
+							// The connector ignores routes of other contexts for metrics
+							continue
+						}
+						if ok, err := r.match(&result, route, matched, err); err != nil {
+							return err
+						} else if ok {
+							break
+						}
+					}
+
+					for _, pipeline := range result.Pipelines {
+						scopeKey := fmt.Sprintf("%s|%d|%d", pipeline, i, j)
+						group, ok := scopes[scopeKey]
+						if !ok {
+							if _, exists := groups[pipeline]; !exists {
+								groups[pipeline] = pmetric.NewMetrics()
+							}
+							dest := groups[pipeline].ResourceMetrics().AppendEmpty()
+							rm.Resource().CopyTo(dest.Resource())
+							dest.SetSchemaUrl(rm.SchemaUrl())
+							group = dest.ScopeMetrics().AppendEmpty()
+							sm.Scope().CopyTo(group.Scope())
+							group.SetSchemaUrl(sm.SchemaUrl())
+							scopes[scopeKey] = group
+						}
+
+						metricKey := fmt.Sprintf("%s|%d", scopeKey, k)
+						dest, ok := descriptions[metricKey]
+						if !ok {
+							dest = copyMetricDescription(metric, group.Metrics())
+							descriptions[metricKey] = dest
+						}
+						appendDataPoint(dest, dp)
+					}
+					results = append(results, result)
+					return nil
+				})
+				if err != nil {
+					return nil, nil, err
+				}
+			}
+		}
+	}
+
+	out := make(map[string]interface{}, len(groups))
+	for pipeline, group := range groups {
+		out[pipeline] = group
+	}
+	return results, out, nil
+}
+
+// forEachDataPoint calls fn for every data point of a metric, regardless of its type
+func forEachDataPoint(metric pmetric.Metric, fn func(index int, dp any) error) error {
+	switch metric.Type() {
+	case pmetric.MetricTypeGauge:
+		dataPoints := metric.Gauge().DataPoints()
+		for l := 0; l < dataPoints.Len(); l++ {
+			if err := fn(l, dataPoints.At(l)); err != nil {
+				return err
+			}
+		}
+	case pmetric.MetricTypeSum:
+		dataPoints := metric.Sum().DataPoints()
+		for l := 0; l < dataPoints.Len(); l++ {
+			if err := fn(l, dataPoints.At(l)); err != nil {
+				return err
+			}
+		}
+	case pmetric.MetricTypeHistogram:
+		dataPoints := metric.Histogram().DataPoints()
+		for l := 0; l < dataPoints.Len(); l++ {
+			if err := fn(l, dataPoints.At(l)); err != nil {
+				return err
+			}
+		}
+	case pmetric.MetricTypeExponentialHistogram:
+		dataPoints := metric.ExponentialHistogram().DataPoints()
+		for l := 0; l < dataPoints.Len(); l++ {
+			if err := fn(l, dataPoints.At(l)); err != nil {
+				return err
+			}
+		}
+	case pmetric.MetricTypeSummary:
+		dataPoints := metric.Summary().DataPoints()
+		for l := 0; l < dataPoints.Len(); l++ {
+			if err := fn(l, dataPoints.At(l)); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// copyMetricDescription appends a metric with the same metadata and type but no data points
+func copyMetricDescription(from pmetric.Metric, to pmetric.MetricSlice) pmetric.Metric {
+	m := to.AppendEmpty()
+	m.SetName(from.Name())
+	m.SetDescription(from.Description())
+	m.SetUnit(from.Unit())
+	from.Metadata().CopyTo(m.Metadata())
+
+	switch from.Type() {
+	case pmetric.MetricTypeGauge:
+		m.SetEmptyGauge()
+	case pmetric.MetricTypeSum:
+		m.SetEmptySum().SetAggregationTemporality(from.Sum().AggregationTemporality())
+		m.Sum().SetIsMonotonic(from.Sum().IsMonotonic())
+	case pmetric.MetricTypeHistogram:
+		m.SetEmptyHistogram().SetAggregationTemporality(from.Histogram().AggregationTemporality())
+	case pmetric.MetricTypeExponentialHistogram:
+		m.SetEmptyExponentialHistogram().SetAggregationTemporality(from.ExponentialHistogram().AggregationTemporality())
+	case pmetric.MetricTypeSummary:
+		m.SetEmptySummary()
+	}
+	return m
+}
+
+// appendDataPoint copies a data point into a metric created by copyMetricDescription
+func appendDataPoint(to pmetric.Metric, dp any) {
+	switch dp := dp.(type) {
+	case pmetric.NumberDataPoint:
+		if to.Type() == pmetric.MetricTypeSum {
+			dp.CopyTo(to.Sum().DataPoints().AppendEmpty())
+		} else {
+			dp.CopyTo(to.Gauge().DataPoints().AppendEmpty())
+		}
+	case pmetric.HistogramDataPoint:
+		dp.CopyTo(to.Histogram().DataPoints().AppendEmpty())
+	case pmetric.ExponentialHistogramDataPoint:
+		dp.CopyTo(to.ExponentialHistogram().DataPoints().AppendEmpty())
+	case pmetric.SummaryDataPoint:
+		dp.CopyTo(to.Summary().DataPoints().AppendEmpty())
+	}
+}
+
+// writeRouteResults prints one line per record with the pipelines it is routed to
+func writeRouteResults(w io.Writer, results []routeResult) {
+	for _, result := range results {
+		destination := "(dropped)"
+		if len(result.Pipelines) > 0 {
+			destination = strings.Join(result.Pipelines, ", ")
+		}
+		_, _ = fmt.Fprintf(w, "%s %q -> %s\n", result.Locator, result.Name, destination)
+		for _, e := range result.Errors {
+			_, _ = fmt.Fprintf(w, "  error (ignored): %s\n", e)
+		}
+	}
+}
+
+// writeRouteGroups writes the data routed to each pipeline to its own OTLP JSON file
+func writeRouteGroups(dir string, groups map[string]interface{}) error {
+	if err := os.MkdirAll(dir, 0o750); err != nil {
+		return fmt.Errorf("cannot create output directory: %w", err)
+	}
+
+	pipelines := make([]string, 0, len(groups))
+	for pipeline := range groups {
+		pipelines = append(pipelines, pipeline)
+	}
+	sort.Strings(pipelines)
+
+	for _, pipeline := range pipelines {
+		var jsonData []byte
+		var err error
+		switch data := groups[pipeline].(type) {
+		case ptrace.Traces:
+			jsonData, err = (&ptrace.JSONMarshaler{}).MarshalTraces(data)
+		case plog.Logs:
+			jsonData, err = (&plog.JSONMarshaler{}).MarshalLogs(data)
+		case pmetric.Metrics:
+			jsonData, err = (&pmetric.JSONMarshaler{}).MarshalMetrics(data)
+		default:
+			err = fmt.Errorf("unsupported data type %T", data)
+		}
+		if err != nil {
+			return fmt.Errorf("failed to marshal data for pipeline %s: %w", pipeline, err)
+		}
+
+		filename := filepath.Join(dir, strings.ReplaceAll(pipeline, "/", "_")+".json")
+		if err := os.WriteFile(filename, jsonData, 0o600); err != nil {
+			return fmt.Errorf("cannot write %s: %w", filename, err)
+		}
+		_, _ = fmt.Fprintf(os.Stderr, "%s: %s\n", pipeline, filename)
+	}
+
+	return nil
+}
@@ -0,0 +1,249 @@
+package main
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/pdata/plog"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+)
+
+func TestLoadRoutingConfig(t *testing.T) {
+	standalone := `
+default_pipelines: [logs/default]
+error_mode: ignore
+table:
+  - context: log
+    condition: severity_text == "ERROR"
+    pipelines: [logs/errors]
+`
+	collector := `
+connectors:
+  routing/tenants:
+    default_pipelines: [traces/default]
+    table:
+      - condition: resource.attributes["tenant"] == "acme"
+        pipelines: [traces/acme]
+  routing/other:
+    table:
+      - condition: "true"
+        pipelines: [traces/other]
+`
+
+	tests := []struct {
+		name        string
+		config      string
+		connector   string
+		expected    []string
+		shouldError bool
+	}{
+		{
+			name:     "standalone connector config",
+			config:   standalone,
+			expected: []string{"logs/errors"},
+		},
+		{
+			name:      "collector config with connector ID",
+			config:    collector,
+			connector: "routing/tenants",
+			expected:  []string{"traces/acme"},
+		},
+		{
+			name:        "collector config with several routing connectors",
+			config:      collector,
+			shouldError: true,
+		},
+		{
+			name:        "unknown connector ID",
+			config:      collector,
+			connector:   "routing/missing",
+			shouldError: true,
+		},
+		{
+			name:        "empty table",
+			config:      "default_pipelines: [logs/default]",
+			shouldError: true,
+		},
+		{
+			name:        "condition and statement",
+			config:      "table:\n  - condition: \"true\"\n    statement: route()\n    pipelines: [a]",
+			shouldError: true,
+		},
+		{
+			name:        "request context",
+			config:      "table:\n  - context: request\n    condition: request[\"X-Tenant\"] == \"acme\"\n    pipelines: [a]",
+			shouldError: true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			cfg, err := loadRoutingConfig([]byte(test.config), test.connector)
+
+			if test.shouldError {
+				assert.Error(t, err)
+				return
+			}
+
+			require.NoError(t, err)
+			require.NotEmpty(t, cfg.Table)
+			assert.Equal(t, test.expected, cfg.Table[0].Pipelines)
+		})
+	}
+}
+
+func TestNewRouter(t *testing.T) {
+	_, err := newRouter(routingConfig{
+		Table: []routingTableItem{{Context: "span", Condition: "invalid syntax ==", Pipelines: []string{"a"}}},
+	})
+	assert.Error(t, err)
+
+	_, err = newRouter(routingConfig{
+		ErrorMode: "unknown",
+		Table:     []routingTableItem{{Condition: "true", Pipelines: []string{"a"}}},
+	})
+	assert.Error(t, err)
+
+	r, err := newRouter(routingConfig{
+		Table: []routingTableItem{
+			{Condition: "true", Pipelines: []string{"a"}},
+			{Context: "resource", Condition: "true", Pipelines: []string{"b"}},
+			{Context: "span", Statement: "route() where IsRootSpan()", Pipelines: []string{"c"}},
+		},
+	})
+	require.NoError(t, err)
+	assert.Len(t, r.routes, 2, "duplicate resource statements should be ignored")
+}
+
+func TestRouteTraces(t *testing.T) {
+	traces, err := (&ptrace.JSONUnmarshaler{}).UnmarshalTraces(readTestData(t, "traces.json"))
+	require.NoError(t, err)
+
+	r, err := newRouter(routingConfig{
+		DefaultPipelines: []string{"traces/default"},
+		Table: []routingTableItem{
+			{Context: "log", Condition: "true", Pipelines: []string{"logs/ignored"}},
+			{Context: "span", Condition: `attributes["http.method"] == "POST"`, Pipelines: []string{"traces/post"}},
+			{Condition: `attributes["service.name"] == "test-service"`, Pipelines: []string{"traces/test", "traces/all"}},
+		},
+	})
+	require.NoError(t, err)
+
+	results, groups, err := r.routeData(contextTypeSpan, traces)
+	require.NoError(t, err)
+	require.Len(t, results, 1)
+	assert.Equal(t, "resource[0].scope[0].span[0]", results[0].Locator)
+	assert.Equal(t, "test-span", results[0].Name)
+	assert.Equal(t, []string{"traces/test", "traces/all"}, results[0].Pipelines)
+
+	require.Contains(t, groups, "traces/all")
+	assert.Equal(t, 1, groups["traces/all"].(ptrace.Traces).SpanCount())
+	assert.NotContains(t, groups, "traces/default")
+}
+
+func TestRouteLogs(t *testing.T) {
+	logs, err := (&plog.JSONUnmarshaler{}).UnmarshalLogs(readTestData(t, "logs.json"))
+	require.NoError(t, err)
+
+	r, err := newRouter(routingConfig{
+		Table: []routingTableItem{
+			{Context: "log", Condition: `severity_text == "ERROR"`, Pipelines: []string{"logs/errors"}},
+		},
+	})
+	require.NoError(t, err)
+
+	results, groups, err := r.routeData(contextTypeLog, logs)
+	require.NoError(t, err)
+	require.Len(t, results, 2)
+	assert.Empty(t, results[0].Pipelines, "records without a matching route and no default are dropped")
+	assert.Equal(t, []string{"logs/errors"}, results[1].Pipelines)
+
+	require.Len(t, groups, 1)
+	assert.Equal(t, 1, groups["logs/errors"].(plog.Logs).LogRecordCount())
+}
+
+func TestRouteMetrics(t *testing.T) {
+	metrics, err := (&pmetric.JSONUnmarshaler{}).UnmarshalMetrics(readTestData(t, "metrics.json"))
+	require.NoError(t, err)
+
+	r, err := newRouter(routingConfig{
+		DefaultPipelines: []string{"metrics/default"},
+		Table: []routingTableItem{
+			{Context: "metric", Condition: `name == "cpu_usage_percent"`, Pipelines: []string{"metrics/cpu"}},
+			{Context: "datapoint", Condition: `attributes["method"] == "GET"`, Pipelines: []string{"metrics/get"}},
+		},
+	})
+	require.NoError(t, err)
+
+	results, groups, err := r.routeData(contextTypeMetric, metrics)
+	require.NoError(t, err)
+
+	total := 0
+	for _, group := range groups {
+		total += group.(pmetric.Metrics).DataPointCount()
+	}
+	assert.Equal(t, metrics.DataPointCount(), total, "every data point should be routed exactly once")
+	assert.Len(t, results, metrics.DataPointCount())
+
+	require.Contains(t, groups, "metrics/cpu")
+	cpu := groups["metrics/cpu"].(pmetric.Metrics)
+	assert.Equal(t, "cpu_usage_percent", cpu.ResourceMetrics().At(0).ScopeMetrics().At(0).Metrics().At(0).Name())
+
+	require.Contains(t, groups, "metrics/get")
+	get := groups["metrics/get"].(pmetric.Metrics).ResourceMetrics().At(0).ScopeMetrics().At(0).Metrics().At(0)
+	assert.Equal(t, pmetric.MetricTypeSum, get.Type())
+	assert.True(t, get.Sum().IsMonotonic())
+}
+
+func TestRouteErrorMode(t *testing.T) {
+	logs, err := (&plog.JSONUnmarshaler{}).UnmarshalLogs(readTestData(t, "logs.json"))
+	require.NoError(t, err)
+
+	table := []routingTableItem{
+		{Context: "log", Condition: `ParseJSON(body)["code"] == 1`, Pipelines: []string{"logs/numeric"}},
+	}
+
+	r, err := newRouter(routingConfig{Table: table})
+	require.NoError(t, err)
+	_, _, err = r.routeData(contextTypeLog, logs)
+	assert.Error(t, err, "errors should propagate by default")
+
+	r, err = newRouter(routingConfig{ErrorMode: "ignore", DefaultPipelines: []string{"logs/default"}, Table: table})
+	require.NoError(t, err)
+	results, _, err := r.routeData(contextTypeLog, logs)
+	require.NoError(t, err)
+	assert.NotEmpty(t, results[0].Errors)
+	assert.Equal(t, []string{"logs/default"}, results[0].Pipelines)
+}
+
+func TestWriteRouteResults(t *testing.T) {
+	var buf bytes.Buffer
+	writeRouteResults(&buf, []routeResult{
+		{Locator: "resource[0].scope[0].span[0]", Name: "a", Pipelines: []string{"traces/a", "traces/b"}},
+		{Locator: "resource[0].scope[0].span[1]", Name: "b", Errors: []string{"boom"}},
+	})
+
+	assert.Equal(t, `resource[0].scope[0].span[0] "a" -> traces/a, traces/b
+resource[0].scope[0].span[1] "b" -> (dropped)
+  error (ignored): boom
+`, buf.String())
+}
+
+func TestWriteRouteGroups(t *testing.T) {
+	traces, err := (&ptrace.JSONUnmarshaler{}).UnmarshalTraces(readTestData(t, "traces.json"))
+	require.NoError(t, err)
+
+	dir := t.TempDir()
+	require.NoError(t, writeRouteGroups(dir, map[string]interface{}{"traces/acme": traces}))
+
+	data, err := os.ReadFile(filepath.Join(dir, "traces_acme.json"))
+	require.NoError(t, err)
+	routed, err := (&ptrace.JSONUnmarshaler{}).UnmarshalTraces(data)
+	require.NoError(t, err)
+	assert.Equal(t, traces.SpanCount(), routed.SpanCount())
+}
@@ -0,0 +1,562 @@
+// Copyright 2025 Dose de Telemetria GmbH
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"bytes"
+	"compress/gzip"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"os"
+	"os/signal"
+	"strings"
+	"sync"
+	"syscall"
+	"time"
+
+	"github.com/spf13/cobra"
+	"go.opentelemetry.io/collector/pdata/plog"
+	"go.opentelemetry.io/collector/pdata/plog/plogotlp"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.opentelemetry.io/collector/pdata/pmetric/pmetricotlp"
+	"go.opentelemetry.io/collector/pdata/pprofile"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+	"go.opentelemetry.io/collector/pdata/ptrace/ptraceotlp"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+var serveCmd = &cobra.Command{
+	Use:   "serve",
+	Short: "Receive OTLP, apply an OTTL statement and pass the result on",
+	Long: `Reads an OTTL statement from stdin and listens for OTLP/HTTP (and optionally
+OTLP/gRPC) requests. The statement is applied to every request, and the result is
+forwarded to another OTLP/HTTP endpoint or written as OTLP JSON lines to a file or
+stdout.
+
+Without --context, each signal uses its default context (span, log or metric), and
+signals whose context the statement does not parse for are passed on unchanged.
+With --context, only the matching signal is transformed and the other signals are
+passed on unchanged.`,
+	Example: `  # Print transformed spans sent by a local app to localhost:4318
+  echo 'set(span.attributes["env"], "dev")' | ottl serve --context span
+
+  # Sit between the app and a local collector listening on port 14318
+  echo 'set(log.severity_text, "WARN") where log.severity_number == 13' | ottl serve --forward http://localhost:14318
+
+  # Also accept gRPC and write the results to a file
+  echo 'set(metric.unit, "ms")' | ottl serve --grpc localhost:4317 --output-file out.jsonl`,
+	Args: cobra.NoArgs,
+	RunE: runServe,
+}
+
+var serveHTTPAddr string
+var serveGRPCAddr string
+var serveContext string
+var serveForward string
+var serveOutputFile string
+
+func init() {
+	serveCmd.Flags().StringVar(&serveHTTPAddr, "http", "localhost:4318", "Address for the OTLP/HTTP receiver")
+	serveCmd.Flags().StringVar(&serveGRPCAddr, "grpc", "", "Address for the OTLP/gRPC receiver (disabled when empty)")
+	serveCmd.Flags().StringVar(&serveContext, "context", "", "Only transform data of this OTTL context (span, log, metric, datapoint; profiles are not received)")
+	serveCmd.Flags().StringVar(&serveForward, "forward", "", "OTLP/HTTP endpoint to forward results to, e.g. http://localhost:14318")
+	serveCmd.Flags().StringVarP(&serveOutputFile, "output-file", "o", "", "Append results as OTLP JSON lines to this file instead of stdout")
+	serveCmd.MarkFlagsMutuallyExclusive("forward", "output-file")
+	rootCmd.AddCommand(serveCmd)
+}
+
+// maxOTLPRequestSize limits the size of a decompressed OTLP request body
+const maxOTLPRequestSize = 20 << 20
+
+// otlpSink receives the transformed data of every request
+type otlpSink interface {
+	export(ctx context.Context, data interface{}) error
+}
+
+// otlpReceiver applies an OTTL statement to received OTLP data and passes it to a sink
+type otlpReceiver struct {
+	statement string
+	context   contextType
+	contexts  []contextType // contexts the statement parses for, set by validate
+	sink      otlpSink
+	errors    io.Writer
+}
+
+// runServe executes the serve command
+func runServe(cmd *cobra.Command, args []string) error {
+	statement, err := readStdin()
+	if err != nil {
+		return fmt.Errorf("failed to read OTTL statement from stdin: %w", err)
+	}
+
+	ctx := contextTypeUnknown
+	if serveContext != "" {
+		if ctx = parseContextFlag(serveContext); ctx == contextTypeUnknown {
+			return fmt.Errorf("invalid context flag: %s (valid: %s)", serveContext, validContexts())
+		}
+		if ctx == contextTypeProfile {
+			return errors.New("the profile context cannot be served: the receiver accepts traces, logs and metrics")
+		}
+	}
+
+	receiver := &otlpReceiver{statement: statement, context: ctx, errors: os.Stderr}
+	if err := receiver.validate(); err != nil {
+		return err
+	}
+	if signals := receiver.passedThrough(); len(signals) > 0 {
+		_, _ = fmt.Fprintf(os.Stderr, "The statement does not apply to %s, which are passed on unchanged\n", strings.Join(signals, " and "))
+	}
+
+	switch {
+	case serveForward != "":
+		receiver.sink = newForwardSink(serveForward)
+	case serveOutputFile != "":
+		file, err := os.OpenFile(serveOutputFile, os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0o600) // #nosec G304 - User-provided file path is expected for CLI tool
+		if err != nil {
+			return fmt.Errorf("cannot open output file: %w", err)
+		}
+		defer func() { _ = file.Close() }()
+		receiver.sink = &writerSink{w: file}
+	default:
+		receiver.sink = &writerSink{w: os.Stdout}
+	}
+
+	runCtx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	httpListener, err := net.Listen("tcp", serveHTTPAddr)
+	if err != nil {
+		return fmt.Errorf("cannot listen on %s: %w", serveHTTPAddr, err)
+	}
+	httpServer := &http.Server{
+		Handler:           receiver.httpHandler(),
+		ReadHeaderTimeout: 10 * time.Second,
+	}
+	errs := make(chan error, 2)
+	go func() { errs <- httpServer.Serve(httpListener) }()
+	_, _ = fmt.Fprintf(os.Stderr, "OTLP/HTTP receiver listening on %s\n", httpListener.Addr())
+
+	if serveGRPCAddr != "" {
+		grpcListener, err := net.Listen("tcp", serveGRPCAddr)
+		if err != nil {
+			_ = httpServer.Close()
+			return fmt.Errorf("cannot listen on %s: %w", serveGRPCAddr, err)
+		}
+		grpcServer := receiver.grpcServer()
+		defer grpcServer.GracefulStop()
+		go func() { errs <- grpcServer.Serve(grpcListener) }()
+		_, _ = fmt.Fprintf(os.Stderr, "OTLP/gRPC receiver listening on %s\n", grpcListener.Addr())
+	}
+
+	select {
+	case <-runCtx.Done():
+	case err := <-errs:
+		if err != nil && !errors.Is(err, http.ErrServerClosed) {
+			return fmt.Errorf("receiver failed: %w", err)
+		}
+	}
+
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	return httpServer.Shutdown(shutdownCtx)
+}
+
+// validate parses the statement against empty data so syntax errors surface before serving,
+// and records the contexts it parses for. Without --context, signals whose default context the
+// statement does not parse for are passed on unchanged.
+func (r *otlpReceiver) validate() error {
+	if r.context != contextTypeUnknown {
+		if err := applyTransformation(r.statement, r.context, emptyData(r.context)); err != nil {
+			return fmt.Errorf("invalid statement: %w", err)
+		}
+		r.contexts = []contextType{r.context}
+		return nil
+	}
+
+	var errs []error
+	r.contexts = nil
+	for _, ctx := range []contextType{contextTypeSpan, contextTypeLog, contextTypeMetric} {
+		if err := applyTransformation(r.statement, ctx, emptyData(ctx)); err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		r.contexts = append(r.contexts, ctx)
+	}
+	if len(r.contexts) == 0 {
+		return fmt.Errorf("invalid statement for every signal: %w", errors.Join(errs...))
+	}
+	return nil
+}
+
+// passedThrough returns the signals the statement does not apply to
+func (r *otlpReceiver) passedThrough() []string {
+	var signals []string
+	for _, signal := range []string{"traces", "logs", "metrics"} {
+		if r.contextFor(emptyData(contextForSignal(signal))) == contextTypeUnknown {
+			signals = append(signals, signal)
+		}
+	}
+	return signals
+}
+
+// emptyData returns empty OTLP data for a context
+func emptyData(ctx contextType) interface{} {
+	switch ctx {
+	case contextTypeSpan:
+		return ptrace.NewTraces()
+	case contextTypeLog:
+		return plog.NewLogs()
+	case contextTypeProfile:
+		return pprofile.NewProfiles()
+	default:
+		return pmetric.NewMetrics()
+	}
+}
+
+// contextFor returns the context the statement applies to for the given data,
+// or contextTypeUnknown when the data is passed on unchanged
+func (r *otlpReceiver) contextFor(data interface{}) contextType {
+	var signalContexts []contextType
+	switch data.(type) {
+	case ptrace.Traces:
+		signalContexts = []contextType{contextTypeSpan}
+	case plog.Logs:
+		signalContexts = []contextType{contextTypeLog}
+	case pmetric.Metrics:
+		signalContexts = []contextType{contextTypeMetric, contextTypeDatapoint}
+	}
+
+	for _, ctx := range signalContexts {
+		for _, valid := range r.contexts {
+			if ctx == valid {
+				return ctx
+			}
+		}
+	}
+	return contextTypeUnknown
+}
+
+// process transforms the data of one request and passes it to the sink
+func (r *otlpReceiver) process(ctx context.Context, data interface{}) error {
+	if target := r.contextFor(data); target != contextTypeUnknown {
+		if err := applyTransformation(r.statement, target, data); err != nil {
+			return fmt.Errorf("transformation failed: %w", err)
+		}
+	}
+	return r.sink.export(ctx, data)
+}
+
+// httpHandler returns the OTLP/HTTP handler for the /v1/traces, /v1/logs and /v1/metrics paths
+func (r *otlpReceiver) httpHandler() http.Handler {
+	mux := http.NewServeMux()
+	for _, signal := range []string{"traces", "logs", "metrics"} {
+		mux.HandleFunc("/v1/"+signal, func(w http.ResponseWriter, req *http.Request) {
+			r.handleHTTP(signal, w, req)
+		})
+	}
+	return mux
+}
+
+// handleHTTP decodes an OTLP/HTTP export request, processes it and writes the response
+func (r *otlpReceiver) handleHTTP(signal string, w http.ResponseWriter, req *http.Request) {
+	if req.Method != http.MethodPost {
+		http.Error(w, "method not allowed, use POST", http.StatusMethodNotAllowed)
+		return
+	}
+
+	contentType := req.Header.Get("Content-Type")
+	if i := strings.Index(contentType, ";"); i >= 0 {
+		contentType = contentType[:i]
+	}
+	jsonEncoding := false
+	switch contentType {
+	case "application/json":
+		jsonEncoding = true
+	case "application/x-protobuf":
+	default:
+		http.Error(w, fmt.Sprintf("unsupported content type %q", contentType), http.StatusUnsupportedMediaType)
+		return
+	}
+
+	var body io.Reader = req.Body
+	switch req.Header.Get("Content-Encoding") {
+	case "":
+	case "gzip":
+		gz, err := gzip.NewReader(req.Body)
+		if err != nil {
+			http.Error(w, fmt.Sprintf("invalid gzip body: %v", err), http.StatusBadRequest)
+			return
+		}
+		defer func() { _ = gz.Close() }()
+		body = gz
+	default:
+		http.Error(w, "unsupported content encoding", http.StatusUnsupportedMediaType)
+		return
+	}
+
+	payload, err := io.ReadAll(io.LimitReader(body, maxOTLPRequestSize+1))
+	if err != nil {
+		http.Error(w, fmt.Sprintf("cannot read body: %v", err), http.StatusBadRequest)
+		return
+	}
+	if len(payload) > maxOTLPRequestSize {
+		http.Error(w, "request too large", http.StatusRequestEntityTooLarge)
+		return
+	}
+
+	data, err := decodeOTLPRequest(signal, payload, jsonEncoding)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	if err := r.process(req.Context(), data); err != nil {
+		_, _ = fmt.Fprintf(r.errors, "error: %s request: %v\n", signal, err)
+		http.Error(w, err.Error(), statusForError(err))
+		return
+	}
+
+	response, err := encodeOTLPResponse(signal, jsonEncoding)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	w.Header().Set("Content-Type", contentType)
+	_, _ = w.Write(response)
+}
+
+// errForward marks errors of the forward sink, which are reported as unavailable so clients retry
+var errForward = errors.New("forwarding failed")
+
+// statusForError maps a processing error to an HTTP status code
+func statusForError(err error) int {
+	if errors.Is(err, errForward) {
+		return http.StatusServiceUnavailable
+	}
+	return http.StatusBadRequest
+}
+
+// decodeOTLPRequest decodes the body of an OTLP export request of a signal
+func decodeOTLPRequest(signal string, payload []byte, jsonEncoding bool) (interface{}, error) {
+	unmarshal := func(proto, json func([]byte) error) error {
+		if jsonEncoding {
+			return json(payload)
+		}
+		return proto(payload)
+	}
+
+	switch signal {
+	case "traces":
+		req := ptraceotlp.NewExportRequest()
+		if err := unmarshal(req.UnmarshalProto, req.UnmarshalJSON); err != nil {
+			return nil, fmt.Errorf("invalid OTLP traces request: %w", err)
+		}
+		return req.Traces(), nil
+	case "logs":
+		req := plogotlp.NewExportRequest()
+		if err := unmarshal(req.UnmarshalProto, req.UnmarshalJSON); err != nil {
+			return nil, fmt.Errorf("invalid OTLP logs request: %w", err)
+		}
+		return req.Logs(), nil
+	case "metrics":
+		req := pmetricotlp.NewExportRequest()
+		if err := unmarshal(req.UnmarshalProto, req.UnmarshalJSON); err != nil {
+			return nil, fmt.Errorf("invalid OTLP metrics request: %w", err)
+		}
+		return req.Metrics(), nil
+	default:
+		return nil, fmt.Errorf("unsupported signal %q", signal)
+	}
+}
+
+// encodeOTLPResponse encodes an empty (successful) OTLP export response of a signal
+func encodeOTLPResponse(signal string, jsonEncoding bool) ([]byte, error) {
+	marshal := func(proto, json func() ([]byte, error)) ([]byte, error) {
+		if jsonEncoding {
+			return json()
+		}
+		return proto()
+	}
+
+	switch signal {
+	case "traces":
+		resp := ptraceotlp.NewExportResponse()
+		return marshal(resp.MarshalProto, resp.MarshalJSON)
+	case "logs":
+		resp := plogotlp.NewExportResponse()
+		return marshal(resp.MarshalProto, resp.MarshalJSON)
+	case "metrics":
+		resp := pmetricotlp.NewExportResponse()
+		return marshal(resp.MarshalProto, resp.MarshalJSON)
+	default:
+		return nil, fmt.Errorf("unsupported signal %q", signal)
+	}
+}
+
+// grpcServer returns a gRPC server with the OTLP trace, log and metric services
+func (r *otlpReceiver) grpcServer() *grpc.Server {
+	server := grpc.NewServer()
+	ptraceotlp.RegisterGRPCServer(server, &grpcTracesServer{receiver: r})
+	plogotlp.RegisterGRPCServer(server, &grpcLogsServer{receiver: r})
+	pmetricotlp.RegisterGRPCServer(server, &grpcMetricsServer{receiver: r})
+	return server
+}
+
+// grpcStatus converts a processing error to a gRPC status error
+func (r *otlpReceiver) grpcStatus(signal string, err error) error {
+	_, _ = fmt.Fprintf(r.errors, "error: %s request: %v\n", signal, err)
+	if errors.Is(err, errForward) {
+		return status.Error(codes.Unavailable, err.Error())
+	}
+	return status.Error(codes.InvalidArgument, err.Error())
+}
+
+// grpcTracesServer implements the OTLP trace service
+type grpcTracesServer struct {
+	ptraceotlp.UnimplementedGRPCServer
+	receiver *otlpReceiver
+}
+
+// Export processes an OTLP/gRPC traces request
+func (s *grpcTracesServer) Export(ctx context.Context, req ptraceotlp.ExportRequest) (ptraceotlp.ExportResponse, error) {
+	if err := s.receiver.process(ctx, req.Traces()); err != nil {
+		return ptraceotlp.NewExportResponse(), s.receiver.grpcStatus("traces", err)
+	}
+	return ptraceotlp.NewExportResponse(), nil
+}
+
+// grpcLogsServer implements the OTLP logs service
+type grpcLogsServer struct {
+	plogotlp.UnimplementedGRPCServer
+	receiver *otlpReceiver
+}
+
+// Export processes an OTLP/gRPC logs request
+func (s *grpcLogsServer) Export(ctx context.Context, req plogotlp.ExportRequest) (plogotlp.ExportResponse, error) {
+	if err := s.receiver.process(ctx, req.Logs()); err != nil {
+		return plogotlp.NewExportResponse(), s.receiver.grpcStatus("logs", err)
+	}
+	return plogotlp.NewExportResponse(), nil
+}
+
+// grpcMetricsServer implements the OTLP metrics service
+type grpcMetricsServer struct {
+	pmetricotlp.UnimplementedGRPCServer
+	receiver *otlpReceiver
+}
+
+// Export processes an OTLP/gRPC metrics request
+func (s *grpcMetricsServer) Export(ctx context.Context, req pmetricotlp.ExportRequest) (pmetricotlp.ExportResponse, error) {
+	if err := s.receiver.process(ctx, req.Metrics()); err != nil {
+		return pmetricotlp.NewExportResponse(), s.receiver.grpcStatus("metrics", err)
+	}
+	return pmetricotlp.NewExportResponse(), nil
+}
+
+// writerSink writes every result as a line of OTLP JSON
+type writerSink struct {
+	mu sync.Mutex
+	w  io.Writer
+}
+
+// export writes the data followed by a newline
+func (s *writerSink) export(_ context.Context, data interface{}) error {
+	jsonData, err := marshalOTLPJSON(data)
+	if err != nil {
+		return err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	_, err = s.w.Write(append(jsonData, '\n'))
+	return err
+}
+
+// forwardSink sends every result to an OTLP/HTTP endpoint as protobuf
+type forwardSink struct {
+	endpoint string
+	client   *http.Client
+}
+
+// newForwardSink creates a sink forwarding to the base URL of an OTLP/HTTP endpoint
+func newForwardSink(endpoint string) *forwardSink {
+	return &forwardSink{
+		endpoint: strings.TrimSuffix(endpoint, "/"),
+		client:   &http.Client{Timeout: 30 * time.Second},
+	}
+}
+
+// export posts the data to the signal path of the endpoint
+func (s *forwardSink) export(ctx context.Context, data interface{}) error {
+	var path string
+	var payload []byte
+	var err error
+	switch data := data.(type) {
+	case ptrace.Traces:
+		path = "/v1/traces"
+		payload, err = ptraceotlp.NewExportRequestFromTraces(data).MarshalProto()
+	case plog.Logs:
+		path = "/v1/logs"
+		payload, err = plogotlp.NewExportRequestFromLogs(data).MarshalProto()
+	case pmetric.Metrics:
+		path = "/v1/metrics"
+		payload, err = pmetricotlp.NewExportRequestFromMetrics(data).MarshalProto()
+	default:
+		return fmt.Errorf("unsupported data type %T", data)
+	}
+	if err != nil {
+		return fmt.Errorf("failed to marshal request: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, s.endpoint+path, bytes.NewReader(payload))
+	if err != nil {
+		return fmt.Errorf("%w: %v", errForward, err)
+	}
+	req.Header.Set("Content-Type", "application/x-protobuf")
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("%w: %v", errForward, err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+	_, _ = io.Copy(io.Discard, resp.Body)
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return fmt.Errorf("%w: %s returned %s", errForward, s.endpoint+path, resp.Status)
+	}
+	return nil
+}
+
+// marshalOTLPJSON marshals traces, logs, metrics or profiles to OTLP JSON
+func marshalOTLPJSON(data interface{}) ([]byte, error) {
+	switch data := data.(type) {
+	case ptrace.Traces:
+		return (&ptrace.JSONMarshaler{}).MarshalTraces(data)
+	case plog.Logs:
+		return (&plog.JSONMarshaler{}).MarshalLogs(data)
+	case pmetric.Metrics:
+		return (&pmetric.JSONMarshaler{}).MarshalMetrics(data)
+	case pprofile.Profiles:
+		return (&pprofile.JSONMarshaler{}).MarshalProfiles(data)
+	default:
+		return nil, fmt.Errorf("unsupported data type %T", data)
+	}
+}
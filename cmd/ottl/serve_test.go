@@ -0,0 +1,246 @@
+package main
+
+import (
+	"bytes"
+	"compress/gzip"
+	"context"
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/pdata/plog"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+	"go.opentelemetry.io/collector/pdata/ptrace/ptraceotlp"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+)
+
+// recordingSink keeps the data of every export
+type recordingSink struct {
+	mu   sync.Mutex
+	data []interface{}
+}
+
+func (s *recordingSink) export(_ context.Context, data interface{}) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.data = append(s.data, data)
+	return nil
+}
+
+func TestOTLPReceiverValidate(t *testing.T) {
+	tests := []struct {
+		name        string
+		statement   string
+		context     contextType
+		contexts    []contextType
+		shouldError bool
+	}{
+		{name: "valid for several signals", statement: `set(attributes["env"], "dev")`, contexts: []contextType{contextTypeSpan, contextTypeLog}},
+		{name: "valid for one signal", statement: `set(span.name, "x")`, contexts: []contextType{contextTypeSpan}},
+		{name: "forced context", statement: `set(log.severity_text, "WARN")`, context: contextTypeLog, contexts: []contextType{contextTypeLog}},
+		{name: "wrong forced context", statement: `set(span.name, "x")`, context: contextTypeLog, shouldError: true},
+		{name: "syntax error", statement: `set(attributes["env"]`, shouldError: true},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			r := &otlpReceiver{statement: test.statement, context: test.context}
+			err := r.validate()
+
+			if test.shouldError {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, test.contexts, r.contexts)
+			}
+		})
+	}
+}
+
+func TestOTLPReceiverPassesThroughOtherSignals(t *testing.T) {
+	sink := &recordingSink{}
+	r := &otlpReceiver{statement: `set(log.severity_text, "WARN")`, sink: sink, errors: io.Discard}
+	require.NoError(t, r.validate())
+	assert.Equal(t, []string{"traces", "metrics"}, r.passedThrough())
+
+	traces, err := (&ptrace.JSONUnmarshaler{}).UnmarshalTraces(readTestData(t, "traces.json"))
+	require.NoError(t, err)
+	require.NoError(t, r.process(context.Background(), traces))
+	logs, err := (&plog.JSONUnmarshaler{}).UnmarshalLogs(readTestData(t, "logs.json"))
+	require.NoError(t, err)
+	require.NoError(t, r.process(context.Background(), logs))
+
+	require.Len(t, sink.data, 2)
+	assert.Equal(t, traces, sink.data[0])
+	assert.Equal(t, "WARN", sink.data[1].(plog.Logs).ResourceLogs().At(0).ScopeLogs().At(0).LogRecords().At(0).SeverityText())
+}
+
+func TestOTLPReceiverHTTP(t *testing.T) {
+	sink := &recordingSink{}
+	var errs bytes.Buffer
+	r := &otlpReceiver{statement: `set(attributes["env"], "dev")`, context: contextTypeSpan, sink: sink, errors: &errs}
+	require.NoError(t, r.validate())
+	server := httptest.NewServer(r.httpHandler())
+	defer server.Close()
+
+	// JSON request
+	resp, err := http.Post(server.URL+"/v1/traces", "application/json", bytes.NewReader(readTestData(t, "traces.json")))
+	require.NoError(t, err)
+	_ = resp.Body.Close()
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.Equal(t, "application/json", resp.Header.Get("Content-Type"))
+
+	// Gzipped protobuf request
+	traces, err := (&ptrace.JSONUnmarshaler{}).UnmarshalTraces(readTestData(t, "traces.json"))
+	require.NoError(t, err)
+	payload, err := ptraceotlp.NewExportRequestFromTraces(traces).MarshalProto()
+	require.NoError(t, err)
+	var compressed bytes.Buffer
+	gz := gzip.NewWriter(&compressed)
+	_, err = gz.Write(payload)
+	require.NoError(t, err)
+	require.NoError(t, gz.Close())
+
+	req, err := http.NewRequest(http.MethodPost, server.URL+"/v1/traces", &compressed)
+	require.NoError(t, err)
+	req.Header.Set("Content-Type", "application/x-protobuf")
+	req.Header.Set("Content-Encoding", "gzip")
+	resp, err = http.DefaultClient.Do(req)
+	require.NoError(t, err)
+	_ = resp.Body.Close()
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+
+	// Logs are passed on unchanged when the context is span
+	resp, err = http.Post(server.URL+"/v1/logs", "application/json", bytes.NewReader(readTestData(t, "logs.json")))
+	require.NoError(t, err)
+	_ = resp.Body.Close()
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+
+	require.Len(t, sink.data, 3)
+	for _, data := range sink.data[:2] {
+		env, ok := data.(ptrace.Traces).ResourceSpans().At(0).ScopeSpans().At(0).Spans().At(0).Attributes().Get("env")
+		require.True(t, ok)
+		assert.Equal(t, "dev", env.Str())
+	}
+	_, ok := sink.data[2].(plog.Logs).ResourceLogs().At(0).ScopeLogs().At(0).LogRecords().At(0).Attributes().Get("env")
+	assert.False(t, ok)
+	assert.Empty(t, errs.String())
+}
+
+func TestOTLPReceiverHTTPErrors(t *testing.T) {
+	var errs bytes.Buffer
+	r := &otlpReceiver{statement: `set(span.attributes["n"], 1 / 0)`, sink: &recordingSink{}, errors: &errs}
+	require.NoError(t, r.validate())
+	server := httptest.NewServer(r.httpHandler())
+	defer server.Close()
+
+	tests := []struct {
+		name        string
+		method      string
+		path        string
+		contentType string
+		body        string
+		status      int
+	}{
+		{name: "wrong method", method: http.MethodGet, path: "/v1/traces", status: http.StatusMethodNotAllowed},
+		{name: "unsupported content type", method: http.MethodPost, path: "/v1/traces", contentType: "text/plain", status: http.StatusUnsupportedMediaType},
+		{name: "invalid body", method: http.MethodPost, path: "/v1/traces", contentType: "application/json", body: "{", status: http.StatusBadRequest},
+		{name: "transformation error", method: http.MethodPost, path: "/v1/traces", contentType: "application/json", body: string(readTestData(t, "traces.json")), status: http.StatusBadRequest},
+		{name: "unknown path", method: http.MethodPost, path: "/v1/profiles", contentType: "application/json", status: http.StatusNotFound},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			req, err := http.NewRequest(test.method, server.URL+test.path, strings.NewReader(test.body))
+			require.NoError(t, err)
+			req.Header.Set("Content-Type", test.contentType)
+			resp, err := http.DefaultClient.Do(req)
+			require.NoError(t, err)
+			_ = resp.Body.Close()
+			assert.Equal(t, test.status, resp.StatusCode)
+		})
+	}
+
+	assert.Contains(t, errs.String(), "error: traces request: transformation failed")
+}
+
+func TestForwardSink(t *testing.T) {
+	// A stand-in for a local collector
+	var received []ptrace.Traces
+	collector := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		assert.Equal(t, "/v1/traces", req.URL.Path)
+		assert.Equal(t, "application/x-protobuf", req.Header.Get("Content-Type"))
+		body, err := io.ReadAll(req.Body)
+		require.NoError(t, err)
+		exportReq := ptraceotlp.NewExportRequest()
+		require.NoError(t, exportReq.UnmarshalProto(body))
+		received = append(received, exportReq.Traces())
+	}))
+	defer collector.Close()
+
+	r := &otlpReceiver{statement: `set(span.name, "renamed")`, sink: newForwardSink(collector.URL + "/"), errors: io.Discard}
+	require.NoError(t, r.validate())
+	traces, err := (&ptrace.JSONUnmarshaler{}).UnmarshalTraces(readTestData(t, "traces.json"))
+	require.NoError(t, err)
+	require.NoError(t, r.process(context.Background(), traces))
+
+	require.Len(t, received, 1)
+	assert.Equal(t, "renamed", received[0].ResourceSpans().At(0).ScopeSpans().At(0).Spans().At(0).Name())
+
+	collector.Config.Handler = http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusInternalServerError)
+	})
+	err = r.process(context.Background(), traces)
+	require.Error(t, err)
+	assert.ErrorIs(t, err, errForward)
+	assert.Equal(t, http.StatusServiceUnavailable, statusForError(err))
+}
+
+func TestWriterSink(t *testing.T) {
+	var buf bytes.Buffer
+	sink := &writerSink{w: &buf}
+	traces, err := (&ptrace.JSONUnmarshaler{}).UnmarshalTraces(readTestData(t, "traces.json"))
+	require.NoError(t, err)
+
+	require.NoError(t, sink.export(context.Background(), traces))
+	require.NoError(t, sink.export(context.Background(), traces))
+
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	require.Len(t, lines, 2)
+	written, err := (&ptrace.JSONUnmarshaler{}).UnmarshalTraces([]byte(lines[1]))
+	require.NoError(t, err)
+	assert.Equal(t, traces.SpanCount(), written.SpanCount())
+}
+
+func TestOTLPReceiverGRPC(t *testing.T) {
+	sink := &recordingSink{}
+	r := &otlpReceiver{statement: `set(attributes["env"], "dev")`, sink: sink, errors: io.Discard}
+	require.NoError(t, r.validate())
+
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	server := r.grpcServer()
+	go func() { _ = server.Serve(listener) }()
+	defer server.Stop()
+
+	conn, err := grpc.NewClient(listener.Addr().String(), grpc.WithTransportCredentials(insecure.NewCredentials()))
+	require.NoError(t, err)
+	defer func() { _ = conn.Close() }()
+
+	traces, err := (&ptrace.JSONUnmarshaler{}).UnmarshalTraces(readTestData(t, "traces.json"))
+	require.NoError(t, err)
+	_, err = ptraceotlp.NewGRPCClient(conn).Export(context.Background(), ptraceotlp.NewExportRequestFromTraces(traces))
+	require.NoError(t, err)
+
+	require.Len(t, sink.data, 1)
+	env, ok := sink.data[0].(ptrace.Traces).ResourceSpans().At(0).ScopeSpans().At(0).Spans().At(0).Attributes().Get("env")
+	require.True(t, ok)
+	assert.Equal(t, "dev", env.Str())
+}
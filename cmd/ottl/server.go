@@ -0,0 +1,276 @@
+// Copyright 2025 Dose de Telemetria GmbH
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"os"
+	"os/signal"
+	"sort"
+	"syscall"
+	"time"
+	"unicode"
+
+	"github.com/spf13/cobra"
+	"github.com/telemetrydrops/ottl-cli/pkg/functions"
+)
+
+var serverCmd = &cobra.Command{
+	Use:   "server",
+	Short: "Serve an HTTP API for transforming OTLP data",
+	Long: `Starts an HTTP API with the same semantics as the transform command, for
+playground pages and other tools that cannot run the CLI.
+
+Endpoints:
+  POST /transform   apply statements to OTLP JSON data
+  POST /validate    check statements for a context
+  GET  /functions   list the functions available to each context`,
+	Example: `  ottl server --http :8080
+
+  curl -s localhost:8080/transform -d '{
+    "statements": ["set(span.attributes[\"env\"], \"dev\")"],
+    "data": {"resourceSpans": [...]}
+  }'`,
+	Args: cobra.NoArgs,
+	RunE: runServer,
+}
+
+var serverHTTPAddr string
+var serverMaxBodySize int64
+var serverRequestTimeout time.Duration
+var serverAllowOrigin string
+
+func init() {
+	serverCmd.Flags().StringVar(&serverHTTPAddr, "http", "localhost:8080", "Address to listen on")
+	serverCmd.Flags().Int64Var(&serverMaxBodySize, "max-body-size", 4<<20, "Maximum request body size in bytes")
+	serverCmd.Flags().DurationVar(&serverRequestTimeout, "request-timeout", 10*time.Second, "Maximum time to handle a request")
+	serverCmd.Flags().StringVar(&serverAllowOrigin, "allow-origin", "", "Value of the Access-Control-Allow-Origin header, for pages served from another origin")
+	rootCmd.AddCommand(serverCmd)
+}
+
+// apiError describes a failed request or statement
+type apiError struct {
+	Statement string `json:"statement,omitempty"`
+	Message   string `json:"message"`
+}
+
+// transformRequest is the body of POST /transform
+type transformRequest struct {
+	Statements []string        `json:"statements"`
+	Context    string          `json:"context,omitempty"`
+	Signal     string          `json:"signal,omitempty"`
+	Data       json.RawMessage `json:"data"`
+}
+
+// transformResponse is the body returned by POST /transform
+type transformResponse struct {
+	Context string          `json:"context,omitempty"`
+	Data    json.RawMessage `json:"data,omitempty"`
+	Errors  []apiError      `json:"errors"`
+}
+
+// validateRequest is the body of POST /validate
+type validateRequest struct {
+	Statements []string `json:"statements"`
+	Context    string   `json:"context"`
+}
+
+// validateResponse is the body returned by POST /validate
+type validateResponse struct {
+	Valid  bool       `json:"valid"`
+	Errors []apiError `json:"errors"`
+}
+
+// contextFunctions lists the function names available to a context
+type contextFunctions struct {
+	Editors    []string `json:"editors"`
+	Converters []string `json:"converters"`
+}
+
+// playgroundAPI serves the HTTP API of the server command
+type playgroundAPI struct {
+	maxBodySize    int64
+	requestTimeout time.Duration
+	allowOrigin    string
+}
+
+// runServer executes the server command
+func runServer(cmd *cobra.Command, args []string) error {
+	api := &playgroundAPI{maxBodySize: serverMaxBodySize, requestTimeout: serverRequestTimeout, allowOrigin: serverAllowOrigin}
+
+	listener, err := net.Listen("tcp", serverHTTPAddr)
+	if err != nil {
+		return fmt.Errorf("cannot listen on %s: %w", serverHTTPAddr, err)
+	}
+	server := &http.Server{
+		Handler:           api.handler(),
+		ReadHeaderTimeout: 5 * time.Second,
+		ReadTimeout:       serverRequestTimeout,
+		WriteTimeout:      serverRequestTimeout + 5*time.Second,
+		IdleTimeout:       time.Minute,
+	}
+
+	runCtx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	errs := make(chan error, 1)
+	go func() { errs <- server.Serve(listener) }()
+	_, _ = fmt.Fprintf(os.Stderr, "OTTL API listening on http://%s\n", listener.Addr())
+
+	select {
+	case <-runCtx.Done():
+	case err := <-errs:
+		if !errors.Is(err, http.ErrServerClosed) {
+			return fmt.Errorf("server failed: %w", err)
+		}
+	}
+
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	return server.Shutdown(shutdownCtx)
+}
+
+// handler returns the API routes wrapped with the request timeout and CORS headers
+func (api *playgroundAPI) handler() http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc("POST /transform", api.handleTransform)
+	mux.HandleFunc("POST /validate", api.handleValidate)
+	mux.HandleFunc("GET /functions", api.handleFunctions)
+
+	timeout := http.TimeoutHandler(mux, api.requestTimeout, `{"errors":[{"message":"request timed out"}]}`)
+	return http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		if api.allowOrigin != "" {
+			w.Header().Set("Access-Control-Allow-Origin", api.allowOrigin)
+			w.Header().Set("Access-Control-Allow-Methods", "GET, POST, OPTIONS")
+			w.Header().Set("Access-Control-Allow-Headers", "Content-Type")
+			if req.Method == http.MethodOptions {
+				w.WriteHeader(http.StatusNoContent)
+				return
+			}
+		}
+		timeout.ServeHTTP(w, req)
+	})
+}
+
+// decodeBody decodes a size-limited JSON request body, writing an error response on failure
+func (api *playgroundAPI) decodeBody(w http.ResponseWriter, req *http.Request, v interface{}) bool {
+	decoder := json.NewDecoder(http.MaxBytesReader(w, req.Body, api.maxBodySize))
+	decoder.DisallowUnknownFields()
+	if err := decoder.Decode(v); err != nil {
+		var maxBytesErr *http.MaxBytesError
+		if errors.As(err, &maxBytesErr) {
+			writeJSON(w, http.StatusRequestEntityTooLarge, transformResponse{Errors: []apiError{{Message: fmt.Sprintf("request body exceeds %d bytes", api.maxBodySize)}}})
+			return false
+		}
+		writeJSON(w, http.StatusBadRequest, transformResponse{Errors: []apiError{{Message: fmt.Sprintf("invalid request body: %v", err)}}})
+		return false
+	}
+	return true
+}
+
+// handleTransform applies the statements in order, as the transform command does for a single statement
+func (api *playgroundAPI) handleTransform(w http.ResponseWriter, req *http.Request) {
+	var body transformRequest
+	if !api.decodeBody(w, req, &body) {
+		return
+	}
+	if len(body.Statements) == 0 {
+		writeJSON(w, http.StatusBadRequest, transformResponse{Errors: []apiError{{Message: "no statements"}}})
+		return
+	}
+
+	ctx, data, err := parseInput(body.Data, body.Context, body.Signal)
+	if err != nil {
+		writeJSON(w, http.StatusBadRequest, transformResponse{Errors: []apiError{{Message: err.Error()}}})
+		return
+	}
+
+	for _, statement := range body.Statements {
+		if err := applyTransformation(statement, ctx, data); err != nil {
+			writeJSON(w, http.StatusUnprocessableEntity, transformResponse{
+				Context: ctx.String(),
+				Errors:  []apiError{{Statement: statement, Message: err.Error()}},
+			})
+			return
+		}
+	}
+
+	output, err := marshalOTLPJSON(data)
+	if err != nil {
+		writeJSON(w, http.StatusInternalServerError, transformResponse{Errors: []apiError{{Message: err.Error()}}})
+		return
+	}
+	writeJSON(w, http.StatusOK, transformResponse{Context: ctx.String(), Data: output, Errors: []apiError{}})
+}
+
+// handleValidate parses every statement for the requested context and reports all errors
+func (api *playgroundAPI) handleValidate(w http.ResponseWriter, req *http.Request) {
+	var body validateRequest
+	if !api.decodeBody(w, req, &body) {
+		return
+	}
+
+	ctx := parseContextFlag(body.Context)
+	if ctx == contextTypeUnknown {
+		writeJSON(w, http.StatusBadRequest, validateResponse{Errors: []apiError{{Message: fmt.Sprintf("invalid context %q (valid: %s)", body.Context, validContexts())}}})
+		return
+	}
+
+	response := validateResponse{Valid: true, Errors: []apiError{}}
+	for _, statement := range body.Statements {
+		if err := applyTransformation(statement, ctx, emptyData(ctx)); err != nil {
+			response.Valid = false
+			response.Errors = append(response.Errors, apiError{Statement: statement, Message: err.Error()})
+		}
+	}
+	writeJSON(w, http.StatusOK, response)
+}
+
+// handleFunctions lists the editors and converters of every context
+func (api *playgroundAPI) handleFunctions(w http.ResponseWriter, _ *http.Request) {
+	writeJSON(w, http.StatusOK, map[string]contextFunctions{
+		contextTypeSpan.String():      splitFunctionNames(sortedKeys(functions.Span)),
+		contextTypeLog.String():       splitFunctionNames(sortedKeys(functions.Log)),
+		contextTypeMetric.String():    splitFunctionNames(sortedKeys(functions.Metric)),
+		contextTypeDatapoint.String(): splitFunctionNames(sortedKeys(functions.DataPoint)),
+		contextTypeProfile.String():   splitFunctionNames(sortedKeys(functions.Profile)),
+	})
+}
+
+// splitFunctionNames separates editors, which start with a lowercase letter, from converters
+func splitFunctionNames(names []string) contextFunctions {
+	result := contextFunctions{Editors: []string{}, Converters: []string{}}
+	sort.Strings(names)
+	for _, name := range names {
+		if name != "" && unicode.IsLower([]rune(name)[0]) {
+			result.Editors = append(result.Editors, name)
+		} else {
+			result.Converters = append(result.Converters, name)
+		}
+	}
+	return result
+}
+
+// writeJSON writes a JSON response with the given status code
+func writeJSON(w http.ResponseWriter, statusCode int, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(statusCode)
+	_ = json.NewEncoder(w).Encode(v)
+}
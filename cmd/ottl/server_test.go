@@ -0,0 +1,140 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+)
+
+func newTestAPI() *playgroundAPI {
+	return &playgroundAPI{maxBodySize: 1 << 20, requestTimeout: 5 * time.Second, allowOrigin: "*"}
+}
+
+func postJSON(t *testing.T, handler http.Handler, path string, body interface{}) *httptest.ResponseRecorder {
+	t.Helper()
+	payload, err := json.Marshal(body)
+	require.NoError(t, err)
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, path, bytes.NewReader(payload)))
+	return rec
+}
+
+func TestServerTransform(t *testing.T) {
+	handler := newTestAPI().handler()
+	rec := postJSON(t, handler, "/transform", transformRequest{
+		Statements: []string{`set(span.attributes["env"], "dev")`, `set(span.name, "renamed")`},
+		Data:       readTestData(t, "traces.json"),
+	})
+	require.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, "*", rec.Header().Get("Access-Control-Allow-Origin"))
+
+	var response transformResponse
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &response))
+	assert.Equal(t, "span", response.Context)
+	assert.Empty(t, response.Errors)
+
+	traces, err := (&ptrace.JSONUnmarshaler{}).UnmarshalTraces(response.Data)
+	require.NoError(t, err)
+	span := traces.ResourceSpans().At(0).ScopeSpans().At(0).Spans().At(0)
+	assert.Equal(t, "renamed", span.Name())
+	env, ok := span.Attributes().Get("env")
+	require.True(t, ok)
+	assert.Equal(t, "dev", env.Str())
+}
+
+func TestServerTransformErrors(t *testing.T) {
+	api := newTestAPI()
+	api.maxBodySize = 4096
+	handler := api.handler()
+
+	tests := []struct {
+		name   string
+		body   string
+		status int
+	}{
+		{name: "invalid json", body: "{", status: http.StatusBadRequest},
+		{name: "unknown field", body: `{"statement": "x"}`, status: http.StatusBadRequest},
+		{name: "no statements", body: `{"data": {}}`, status: http.StatusBadRequest},
+		{name: "invalid data", body: `{"statements": ["set(span.name, \"x\")"], "data": "oops"}`, status: http.StatusBadRequest},
+		{name: "invalid context", body: `{"statements": ["set(span.name, \"x\")"], "context": "trace", "data": {"resourceSpans": []}}`, status: http.StatusBadRequest},
+		{name: "statement error", body: `{"statements": ["set(log.body, \"x\")"], "data": ` + string(readTestData(t, "traces.json")) + `}`, status: http.StatusUnprocessableEntity},
+		{name: "too large", body: `{"statements": ["` + strings.Repeat("x", 5000) + `"]}`, status: http.StatusRequestEntityTooLarge},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			rec := httptest.NewRecorder()
+			handler.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/transform", strings.NewReader(test.body)))
+			assert.Equal(t, test.status, rec.Code)
+
+			var response transformResponse
+			require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &response))
+			require.Len(t, response.Errors, 1)
+			assert.NotEmpty(t, response.Errors[0].Message)
+		})
+	}
+}
+
+func TestServerValidate(t *testing.T) {
+	handler := newTestAPI().handler()
+	rec := postJSON(t, handler, "/validate", validateRequest{
+		Context:    "log",
+		Statements: []string{`set(log.severity_text, "WARN")`, `set(span.name, "x")`, `set(log.body`},
+	})
+	require.Equal(t, http.StatusOK, rec.Code)
+
+	var response validateResponse
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &response))
+	assert.False(t, response.Valid)
+	require.Len(t, response.Errors, 2)
+	assert.Equal(t, `set(span.name, "x")`, response.Errors[0].Statement)
+	assert.Equal(t, `set(log.body`, response.Errors[1].Statement)
+
+	rec = postJSON(t, handler, "/validate", validateRequest{Statements: []string{`set(log.body, "x")`}})
+	assert.Equal(t, http.StatusBadRequest, rec.Code, "context is required")
+	assert.Contains(t, rec.Body.String(), "(valid: span, log, metric, datapoint, profile)")
+
+	rec = postJSON(t, handler, "/validate", validateRequest{Context: "profile", Statements: []string{`set(profile.attributes["env"], "prod")`}})
+	require.Equal(t, http.StatusOK, rec.Code)
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &response))
+	assert.True(t, response.Valid)
+}
+
+func TestServerFunctions(t *testing.T) {
+	handler := newTestAPI().handler()
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/functions", nil))
+	require.Equal(t, http.StatusOK, rec.Code)
+
+	var response map[string]contextFunctions
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &response))
+	assert.ElementsMatch(t, []string{"span", "log", "metric", "datapoint", "profile"}, sortedKeys(response))
+	assert.Contains(t, response["span"].Editors, "set")
+	assert.Contains(t, response["span"].Converters, "SHA256")
+	assert.NotContains(t, response["span"].Editors, "SHA256")
+}
+
+func TestServerRouting(t *testing.T) {
+	handler := newTestAPI().handler()
+
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, httptest.NewRequest(http.MethodOptions, "/transform", nil))
+	assert.Equal(t, http.StatusNoContent, rec.Code)
+	assert.Contains(t, rec.Header().Get("Access-Control-Allow-Methods"), "POST")
+
+	rec = httptest.NewRecorder()
+	handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/transform", nil))
+	assert.Equal(t, http.StatusMethodNotAllowed, rec.Code)
+
+	rec = httptest.NewRecorder()
+	handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/missing", nil))
+	assert.Equal(t, http.StatusNotFound, rec.Code)
+}
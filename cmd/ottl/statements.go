@@ -0,0 +1,225 @@
+// Copyright 2025 Dose de Telemetria GmbH
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// sourceStatement is a statement of a .ottl file. Statements start at the beginning of a
+// line and continue on the following indented lines, so long conditions can be wrapped, and
+// on any following line while a bracket or a string literal is open. Otherwise blank lines
+// and lines starting with # are not part of any statement, and comments at the beginning of
+// a line end the statement before them.
+type sourceStatement struct {
+	text     string
+	line     int      // zero-based line of the first character in the file
+	comments []string // comment lines directly above the statement
+}
+
+// splitStatements returns the statements of a .ottl file in order
+func splitStatements(source string) []sourceStatement {
+	var statements []sourceStatement
+	var current *sourceStatement
+	var comments []string
+	flush := func() {
+		if current != nil {
+			current.text = strings.TrimRight(current.text, " \t\r\n")
+			statements = append(statements, *current)
+			current = nil
+		}
+	}
+
+	for i, line := range strings.Split(source, "\n") {
+		trimmed := strings.TrimSpace(line)
+		open := current != nil && statementOpen(current.text)
+		switch {
+		case open:
+			current.text += "\n" + strings.TrimRight(line, "\r")
+		case trimmed == "":
+			flush()
+			comments = nil
+		case strings.HasPrefix(trimmed, "#"):
+			// Indented comments belong to the open statement; others end it and belong to the next one
+			if current != nil && (line[0] == ' ' || line[0] == '\t') {
+				continue
+			}
+			flush()
+			comments = append(comments, trimmed)
+		case current != nil && (line[0] == ' ' || line[0] == '\t'):
+			current.text += "\n" + strings.TrimRight(line, "\r")
+		default:
+			flush()
+			current = &sourceStatement{text: strings.TrimRight(line, "\r"), line: i, comments: comments}
+			comments = nil
+		}
+	}
+	flush()
+	return statements
+}
+
+// statementOpen reports whether a statement has unclosed brackets or an unterminated string
+// literal, so it continues on the next line
+func statementOpen(text string) bool {
+	depth := 0
+	inString, escaped := false, false
+	for _, r := range text {
+		switch {
+		case escaped:
+			escaped = false
+		case inString && r == '\\':
+			escaped = true
+		case r == '"':
+			inString = !inString
+		case inString:
+		case r == '(' || r == '[' || r == '{':
+			depth++
+		case r == ')' || r == ']' || r == '}':
+			depth--
+		}
+	}
+	return inString || depth > 0
+}
+
+var (
+	stringLiteralPattern = regexp.MustCompile(`"(?:[^"\\]|\\.)*"`)
+	contextPrefixPattern = regexp.MustCompile(`\b(span|log|metric|datapoint|profile)\.`)
+)
+
+// allContexts lists the contexts the CLI can transform, in auto-detection order
+var allContexts = []contextType{contextTypeSpan, contextTypeLog, contextTypeMetric, contextTypeDatapoint, contextTypeProfile}
+
+// statementContexts returns the contexts a statement may be written for, judging by the
+// context names its paths start with. Statements with unqualified paths may be for any context.
+func statementContexts(statement string) []contextType {
+	code := stringLiteralPattern.ReplaceAllString(statement, `""`)
+	found := make(map[string]bool)
+	for _, match := range contextPrefixPattern.FindAllStringSubmatch(code, -1) {
+		found[match[1]] = true
+	}
+
+	switch {
+	case found["datapoint"]:
+		return []contextType{contextTypeDatapoint}
+	case found["metric"]:
+		return []contextType{contextTypeMetric, contextTypeDatapoint}
+	case found["span"]:
+		return []contextType{contextTypeSpan}
+	case found["log"]:
+		return []contextType{contextTypeLog}
+	case found["profile"]:
+		return []contextType{contextTypeProfile}
+	default:
+		return allContexts
+	}
+}
+
+// checkStatement parses a statement for each of its possible contexts and returns the first
+// context that accepts it. When none does, it returns the error of the most likely context.
+func checkStatement(statement string) (contextType, error) {
+	contexts := statementContexts(statement)
+	var firstErr error
+	for _, ctx := range contexts {
+		err := applyTransformation(statement, ctx, emptyData(ctx))
+		if err == nil {
+			return ctx, nil
+		}
+		if firstErr == nil {
+			firstErr = err
+		}
+	}
+	return contexts[0], firstErr
+}
+
+var (
+	syntaxErrorPattern       = regexp.MustCompile(`(\d+):(\d+): unexpected token "((?:[^"\\]|\\.)*)"`)
+	positionErrorPattern     = regexp.MustCompile(`(\d+):(\d+): `)
+	undefinedFunctionPattern = regexp.MustCompile(`undefined function "(\w+)"|names must start with an? \w+ letter but got '(\w+)'`)
+	invalidPathPattern       = regexp.MustCompile(`from path "([^"]+)"`)
+)
+
+// errorSpan is the part of a statement an error refers to, with a zero-based line and
+// rune column relative to the start of the statement
+type errorSpan struct {
+	line   int
+	column int
+	length int
+}
+
+// locateError finds the part of a statement that a parse error refers to. Errors without
+// a recognizable position refer to the first line of the statement.
+func locateError(statement string, err error) errorSpan {
+	message := err.Error()
+	lines := strings.Split(statement, "\n")
+
+	if match := syntaxErrorPattern.FindStringSubmatch(message); match != nil {
+		span := positionSpan(match[1], match[2])
+		span.length = len([]rune(match[3]))
+		return clampSpan(span, lines)
+	}
+	if match := positionErrorPattern.FindStringSubmatch(message); match != nil {
+		span := positionSpan(match[1], match[2])
+		span.length = 1
+		return clampSpan(span, lines)
+	}
+	if match := undefinedFunctionPattern.FindStringSubmatch(message); match != nil {
+		name := match[1] + match[2]
+		if span, ok := findInStatement(lines, name+"("); ok {
+			span.length = len(name)
+			return span
+		}
+	}
+	if match := invalidPathPattern.FindStringSubmatch(message); match != nil {
+		path, _, _ := strings.Cut(match[1], "[")
+		if span, ok := findInStatement(lines, path); ok {
+			return span
+		}
+	}
+	return errorSpan{length: len([]rune(lines[0]))}
+}
+
+// positionSpan converts the one-based line and column of a parser error
+func positionSpan(line, column string) errorSpan {
+	l, _ := strconv.Atoi(line)
+	c, _ := strconv.Atoi(column)
+	return errorSpan{line: max(l-1, 0), column: max(c-1, 0)}
+}
+
+// clampSpan keeps a span inside the statement, so errors at the end of input point at its last character
+func clampSpan(span errorSpan, lines []string) errorSpan {
+	span.line = min(span.line, len(lines)-1)
+	lineLength := len([]rune(lines[span.line]))
+	if span.column >= lineLength {
+		span.column = max(lineLength-1, 0)
+		span.length = 1
+	}
+	span.length = max(min(span.length, lineLength-span.column), 1)
+	return span
+}
+
+// findInStatement returns the span of the first occurrence of text outside string literals
+func findInStatement(lines []string, text string) (errorSpan, bool) {
+	for i, line := range lines {
+		code := stringLiteralPattern.ReplaceAllStringFunc(line, func(s string) string {
+			return strings.Repeat(" ", len(s))
+		})
+		if index := strings.Index(code, text); index >= 0 {
+			return errorSpan{line: i, column: len([]rune(line[:index])), length: len([]rune(text))}, true
+		}
+	}
+	return errorSpan{}, false
+}
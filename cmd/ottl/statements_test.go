@@ -0,0 +1,112 @@
+package main
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSplitStatements(t *testing.T) {
+	source := "# rename spans\n" +
+		"set(span.name, \"a\")\n" +
+		"set(span.attributes[\"env\"], \"dev\")\n" +
+		"    where span.name == \"a\"\n" +
+		"\n" +
+		"  # indented comment\n" +
+		"delete_key(span.attributes, \"x\")\r\n" +
+		"# comment ending the statement\n" +
+		"delete_key(span.attributes, \"y\")\n"
+
+	statements := splitStatements(source)
+	assert.Equal(t, []sourceStatement{
+		{text: `set(span.name, "a")`, line: 1, comments: []string{"# rename spans"}},
+		{text: "set(span.attributes[\"env\"], \"dev\")\n    where span.name == \"a\"", line: 2},
+		{text: `delete_key(span.attributes, "x")`, line: 6, comments: []string{"# indented comment"}},
+		{text: `delete_key(span.attributes, "y")`, line: 8, comments: []string{"# comment ending the statement"}},
+	}, statements)
+
+	assert.Empty(t, splitStatements("# nothing\n\n"))
+
+	// Unindented lines continue a statement with an open bracket or string literal
+	wrapped := splitStatements("set(attributes[\"a\"],\n\n\"b\")\nset(span.name, \"multi\n# line\")\nset(span.name, \"\\\"(\")\n")
+	assert.Equal(t, []sourceStatement{
+		{text: "set(attributes[\"a\"],\n\n\"b\")", line: 0},
+		{text: "set(span.name, \"multi\n# line\")", line: 3},
+		{text: `set(span.name, "\"(")`, line: 5},
+	}, wrapped)
+}
+
+func TestStatementContexts(t *testing.T) {
+	tests := []struct {
+		statement string
+		expected  []contextType
+	}{
+		{statement: `set(span.name, "x")`, expected: []contextType{contextTypeSpan}},
+		{statement: `set(log.body, "span.name")`, expected: []contextType{contextTypeLog}},
+		{statement: `set(metric.unit, "s")`, expected: []contextType{contextTypeMetric, contextTypeDatapoint}},
+		{statement: `set(profile.attributes["env"], "dev")`, expected: []contextType{contextTypeProfile}},
+		{statement: `set(datapoint.attributes["m"], metric.name)`, expected: []contextType{contextTypeDatapoint}},
+		{statement: `set(attributes["env"], "dev")`, expected: allContexts},
+		{statement: `set(resource.attributes["env"], "dev")`, expected: allContexts},
+	}
+
+	for _, test := range tests {
+		assert.Equal(t, test.expected, statementContexts(test.statement), test.statement)
+	}
+}
+
+func TestCheckStatement(t *testing.T) {
+	ctx, err := checkStatement(`set(log.severity_text, "WARN")`)
+	require.NoError(t, err)
+	assert.Equal(t, contextTypeLog, ctx)
+
+	ctx, err = checkStatement(`set(datapoint.value_double, 1.0)`)
+	require.NoError(t, err)
+	assert.Equal(t, contextTypeDatapoint, ctx)
+
+	ctx, err = checkStatement(`set(profile.attributes["env"], "prod")`)
+	require.NoError(t, err)
+	assert.Equal(t, contextTypeProfile, ctx)
+
+	ctx, err = checkStatement(`set(span.nme, "x")`)
+	require.Error(t, err)
+	assert.Equal(t, contextTypeSpan, ctx)
+}
+
+func TestLocateError(t *testing.T) {
+	tests := []struct {
+		name      string
+		statement string
+		expected  errorSpan
+	}{
+		{
+			name:      "unexpected token",
+			statement: "set(span.name,\n  \"x\" where",
+			expected:  errorSpan{line: 1, column: 6, length: 5},
+		},
+		{
+			name:      "unexpected end of statement",
+			statement: `set(span.name`,
+			expected:  errorSpan{line: 0, column: 12, length: 1},
+		},
+		{
+			name:      "undefined function",
+			statement: `set(span.attributes["Uper("], Uper(span.name))`,
+			expected:  errorSpan{line: 0, column: 30, length: 4},
+		},
+		{
+			name:      "invalid path",
+			statement: `set(span.attributes["span.nme"], span.nme)`,
+			expected:  errorSpan{line: 0, column: 33, length: 8},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			_, err := checkStatement(test.statement)
+			require.Error(t, err)
+			assert.Equal(t, test.expected, locateError(test.statement, err), err.Error())
+		})
+	}
+}
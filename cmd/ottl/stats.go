@@ -0,0 +1,418 @@
+// Copyright 2025 Dose de Telemetria GmbH
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/spf13/cobra"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/plog"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.opentelemetry.io/collector/pdata/pprofile"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+)
+
+var statsCmd = &cobra.Command{
+	Use:   "stats",
+	Short: "Summarize the shape of OTLP data",
+	Long: `Reports what the OTLP data in the specified input file contains: the number
+of resources, scopes and records of each signal, the most frequent span names,
+metric names and log severities, the values of resource attributes, and for
+every attribute key the number of records that have it, its number of distinct
+values and the types of its values.
+
+Collector captures mixing several signals are summarized signal by signal.`,
+	Example: `  # Summarize a sample
+  ottl stats --input-file traces.json
+
+  # Show every distinct value instead of the 10 most frequent
+  ottl stats -i capture.json --top 0`,
+	Args: cobra.NoArgs,
+	RunE: runStats,
+}
+
+var statsInputFile string
+var statsTop int
+
+func init() {
+	statsCmd.Flags().StringVarP(&statsInputFile, "input-file", "i", "", "Path to OTLP JSON input file (required)")
+	statsCmd.Flags().IntVar(&statsTop, "top", 10, "Number of most frequent values listed per count, 0 for all")
+	_ = statsCmd.MarkFlagRequired("input-file")
+	rootCmd.AddCommand(statsCmd)
+}
+
+// valueCounts counts occurrences of values
+type valueCounts map[string]int
+
+// top returns the values by decreasing count, then in order, limited to n values when n > 0
+func (c valueCounts) top(n int) []string {
+	values := sortedKeys(c)
+	sort.SliceStable(values, func(i, j int) bool { return c[values[i]] > c[values[j]] })
+	if n > 0 && len(values) > n {
+		values = values[:n]
+	}
+	return values
+}
+
+// keyStats describes the values of an attribute key
+type keyStats struct {
+	records int // number of maps that have the key
+	values  valueCounts
+	types   valueCounts
+}
+
+// attributeStats describes the keys of the attribute maps at a path, such as span.attributes
+type attributeStats struct {
+	path string
+	keys map[string]*keyStats
+}
+
+func (a *attributeStats) add(attributes pcommon.Map) {
+	attributes.Range(func(k string, v pcommon.Value) bool {
+		stats, ok := a.keys[k]
+		if !ok {
+			stats = &keyStats{values: valueCounts{}, types: valueCounts{}}
+			a.keys[k] = stats
+		}
+		stats.records++
+		stats.values[formatEvalValue(v)]++
+		stats.types[v.Type().String()]++
+		return true
+	})
+}
+
+// namedCounts is a titled count of values, such as the span names
+type namedCounts struct {
+	title  string
+	counts valueCounts
+}
+
+// signalStats summarizes the data of a signal
+type signalStats struct {
+	signal         string
+	records        string   // name of the records, such as "spans"
+	totals         []string // names of the totals, in order
+	total          map[string]int
+	counts         []*namedCounts
+	resourceValues map[string]valueCounts // records by resource attribute key and value
+	attributes     []*attributeStats
+}
+
+func newSignalStats(signal string, totals, counts, attributePaths []string) *signalStats {
+	s := &signalStats{signal: signal, totals: totals, total: map[string]int{}, resourceValues: map[string]valueCounts{}}
+	if len(totals) > 2 {
+		s.records = totals[2] // the totals start with resources and scopes
+	}
+	for _, title := range counts {
+		s.counts = append(s.counts, &namedCounts{title: title, counts: valueCounts{}})
+	}
+	for _, path := range attributePaths {
+		s.attributes = append(s.attributes, &attributeStats{path: path, keys: map[string]*keyStats{}})
+	}
+	return s
+}
+
+// count counts a value of the counts with a title
+func (s *signalStats) count(title, value string) {
+	for _, c := range s.counts {
+		if c.title == title {
+			c.counts[value]++
+			return
+		}
+	}
+}
+
+// addAttributes adds a map to the attribute stats of a path
+func (s *signalStats) addAttributes(path string, attributes pcommon.Map) {
+	for _, a := range s.attributes {
+		if a.path == path {
+			a.add(attributes)
+			return
+		}
+	}
+}
+
+// addRecord counts a record of the signal, by the values of the attributes of its resource
+func (s *signalStats) addRecord(resource pcommon.Resource) {
+	s.total[s.records]++
+	resource.Attributes().Range(func(k string, v pcommon.Value) bool {
+		if s.resourceValues[k] == nil {
+			s.resourceValues[k] = valueCounts{}
+		}
+		s.resourceValues[k][formatEvalValue(v)]++
+		return true
+	})
+}
+
+// addResource counts a resource and its scopes
+func (s *signalStats) addResource(resource pcommon.Resource, scopes int) {
+	s.total["resources"]++
+	s.total["scopes"] += scopes
+	s.addAttributes("resource.attributes", resource.Attributes())
+}
+
+// runStats executes the stats command
+func runStats(cmd *cobra.Command, args []string) error {
+	data, err := readInputFile(statsInputFile)
+	if err != nil {
+		return fmt.Errorf("failed to read input file: %w", err)
+	}
+
+	signals, err := parseSignals(data)
+	if err != nil {
+		return err
+	}
+
+	for i, signal := range signals {
+		if i > 0 {
+			fmt.Fprintln(os.Stdout)
+		}
+		if err := writeStats(os.Stdout, collectStats(signal), statsTop); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// parseSignals returns the data of each signal in the input, which holds several only
+// when it is a collector capture
+func parseSignals(data []byte) ([]interface{}, error) {
+	capture, ok, err := parseCapture(data)
+	if err != nil {
+		return nil, err
+	}
+	if !ok {
+		_, parsedData, err := detectContextType(data)
+		if err != nil {
+			return nil, fmt.Errorf("failed to detect context type: %w", err)
+		}
+		return []interface{}{parsedData}, nil
+	}
+
+	var signals []interface{}
+	for _, ctx := range capture.contexts() {
+		signal, _ := capture.data(ctx)
+		signals = append(signals, signal)
+	}
+	if len(signals) == 0 {
+		return nil, fmt.Errorf("unable to detect data type from input")
+	}
+	return signals, nil
+}
+
+// collectStats summarizes traces, logs, metrics or profiles
+func collectStats(data interface{}) *signalStats {
+	switch d := data.(type) {
+	case ptrace.Traces:
+		return traceStats(d)
+	case plog.Logs:
+		return logStats(d)
+	case pmetric.Metrics:
+		return metricStats(d)
+	case pprofile.Profiles:
+		return profileStats(d)
+	default:
+		return newSignalStats(fmt.Sprintf("%T", data), nil, nil, nil)
+	}
+}
+
+func traceStats(traces ptrace.Traces) *signalStats {
+	s := newSignalStats("traces",
+		[]string{"resources", "scopes", "spans", "span events", "span links"},
+		[]string{"span names", "span kinds", "span status codes", "span event names"},
+		[]string{"resource.attributes", "instrumentation_scope.attributes", "span.attributes", "spanevent.attributes"})
+	for i := 0; i < traces.ResourceSpans().Len(); i++ {
+		rs := traces.ResourceSpans().At(i)
+		s.addResource(rs.Resource(), rs.ScopeSpans().Len())
+		for j := 0; j < rs.ScopeSpans().Len(); j++ {
+			ss := rs.ScopeSpans().At(j)
+			s.addAttributes("instrumentation_scope.attributes", ss.Scope().Attributes())
+			for k := 0; k < ss.Spans().Len(); k++ {
+				span := ss.Spans().At(k)
+				s.addRecord(rs.Resource())
+				s.total["span events"] += span.Events().Len()
+				s.total["span links"] += span.Links().Len()
+				s.count("span names", strconv.Quote(span.Name()))
+				s.count("span kinds", span.Kind().String())
+				s.count("span status codes", span.Status().Code().String())
+				s.addAttributes("span.attributes", span.Attributes())
+				for l := 0; l < span.Events().Len(); l++ {
+					event := span.Events().At(l)
+					s.count("span event names", strconv.Quote(event.Name()))
+					s.addAttributes("spanevent.attributes", event.Attributes())
+				}
+			}
+		}
+	}
+	return s
+}
+
+func logStats(logs plog.Logs) *signalStats {
+	s := newSignalStats("logs",
+		[]string{"resources", "scopes", "log records"},
+		[]string{"severities", "event names", "body types"},
+		[]string{"resource.attributes", "instrumentation_scope.attributes", "log.attributes"})
+	for i := 0; i < logs.ResourceLogs().Len(); i++ {
+		rl := logs.ResourceLogs().At(i)
+		s.addResource(rl.Resource(), rl.ScopeLogs().Len())
+		for j := 0; j < rl.ScopeLogs().Len(); j++ {
+			sl := rl.ScopeLogs().At(j)
+			s.addAttributes("instrumentation_scope.attributes", sl.Scope().Attributes())
+			for k := 0; k < sl.LogRecords().Len(); k++ {
+				logRecord := sl.LogRecords().At(k)
+				s.addRecord(rl.Resource())
+				s.count("severities", fmt.Sprintf("%q (%d)", logRecord.SeverityText(), logRecord.SeverityNumber()))
+				if logRecord.EventName() != "" {
+					s.count("event names", strconv.Quote(logRecord.EventName()))
+				}
+				s.count("body types", logRecord.Body().Type().String())
+				s.addAttributes("log.attributes", logRecord.Attributes())
+			}
+		}
+	}
+	return s
+}
+
+func metricStats(metrics pmetric.Metrics) *signalStats {
+	s := newSignalStats("metrics",
+		[]string{"resources", "scopes", "metrics", "data points"},
+		[]string{"metric names", "metric types"},
+		[]string{"resource.attributes", "instrumentation_scope.attributes", "datapoint.attributes"})
+	for i := 0; i < metrics.ResourceMetrics().Len(); i++ {
+		rm := metrics.ResourceMetrics().At(i)
+		s.addResource(rm.Resource(), rm.ScopeMetrics().Len())
+		for j := 0; j < rm.ScopeMetrics().Len(); j++ {
+			sm := rm.ScopeMetrics().At(j)
+			s.addAttributes("instrumentation_scope.attributes", sm.Scope().Attributes())
+			for k := 0; k < sm.Metrics().Len(); k++ {
+				metric := sm.Metrics().At(k)
+				s.addRecord(rm.Resource())
+				s.count("metric names", strconv.Quote(metric.Name()))
+				s.count("metric types", metric.Type().String())
+				_ = forEachDataPoint(metric, func(_ int, dp any) error {
+					s.total["data points"]++
+					if attributes, ok := dataPointAttributes(dp); ok {
+						s.addAttributes("datapoint.attributes", attributes)
+					}
+					return nil
+				})
+			}
+		}
+	}
+	return s
+}
+
+func profileStats(profiles pprofile.Profiles) *signalStats {
+	s := newSignalStats("profiles",
+		[]string{"resources", "scopes", "profiles", "samples"},
+		[]string{"sample types"},
+		[]string{"resource.attributes", "instrumentation_scope.attributes", "profile.attributes"})
+	dictionary := profiles.ProfilesDictionary()
+	for i := 0; i < profiles.ResourceProfiles().Len(); i++ {
+		rp := profiles.ResourceProfiles().At(i)
+		s.addResource(rp.Resource(), rp.ScopeProfiles().Len())
+		for j := 0; j < rp.ScopeProfiles().Len(); j++ {
+			sp := rp.ScopeProfiles().At(j)
+			s.addAttributes("instrumentation_scope.attributes", sp.Scope().Attributes())
+			for k := 0; k < sp.Profiles().Len(); k++ {
+				profile := sp.Profiles().At(k)
+				s.addRecord(rp.Resource())
+				s.total["samples"] += profile.Sample().Len()
+				for l := 0; l < profile.SampleType().Len(); l++ {
+					s.count("sample types", profileString(dictionary, profile.SampleType().At(l).TypeStrindex()))
+				}
+				s.addAttributes("profile.attributes", pprofile.FromAttributeIndices(dictionary.AttributeTable(), profile))
+			}
+		}
+	}
+	return s
+}
+
+// profileString returns a string of the string table of a profiles dictionary, quoted
+func profileString(dictionary pprofile.ProfilesDictionary, index int32) string {
+	if index < 0 || int(index) >= dictionary.StringTable().Len() {
+		return fmt.Sprintf("<string %d>", index)
+	}
+	return strconv.Quote(dictionary.StringTable().At(int(index)))
+}
+
+// writeStats writes the stats of a signal as text, listing at most top values per count
+func writeStats(w io.Writer, s *signalStats, top int) error {
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	fmt.Fprintf(tw, "%s\n", capitalize(s.signal))
+	for _, name := range s.totals {
+		fmt.Fprintf(tw, "  %s\t%d\n", name, s.total[name])
+	}
+
+	for _, c := range s.counts {
+		if len(c.counts) == 0 {
+			continue
+		}
+		fmt.Fprintf(tw, "\n%s (%s)\n", capitalize(c.title), distinctCount(len(c.counts), top))
+		writeValueCounts(tw, c.counts, top)
+	}
+
+	for _, key := range sortedKeys(s.resourceValues) {
+		values := s.resourceValues[key]
+		fmt.Fprintf(tw, "\n%s by resource attribute %s (%s)\n", capitalize(s.records), key, distinctCount(len(values), top))
+		writeValueCounts(tw, values, top)
+	}
+
+	for _, a := range s.attributes {
+		if len(a.keys) == 0 {
+			continue
+		}
+		fmt.Fprintf(tw, "\nKeys of %s\n", a.path)
+		fmt.Fprintf(tw, "  key\tpresent\tdistinct\ttypes\n")
+		for _, key := range sortedKeys(a.keys) {
+			stats := a.keys[key]
+			types := make([]string, 0, len(stats.types))
+			for _, typ := range stats.types.top(0) {
+				types = append(types, fmt.Sprintf("%s %d", typ, stats.types[typ]))
+			}
+			fmt.Fprintf(tw, "  %s\t%d\t%d\t%s\n", key, stats.records, len(stats.values), strings.Join(types, ", "))
+		}
+	}
+	return tw.Flush()
+}
+
+// writeValueCounts writes the most frequent values with their counts
+func writeValueCounts(w io.Writer, counts valueCounts, top int) {
+	for _, value := range counts.top(top) {
+		fmt.Fprintf(w, "  %d\t%s\n", counts[value], value)
+	}
+}
+
+// distinctCount describes how many distinct values there are, and how many are listed
+func distinctCount(distinct, top int) string {
+	if top > 0 && distinct > top {
+		return fmt.Sprintf("%d distinct, top %d", distinct, top)
+	}
+	return fmt.Sprintf("%d distinct", distinct)
+}
+
+func capitalize(s string) string {
+	if s == "" {
+		return s
+	}
+	return strings.ToUpper(s[:1]) + s[1:]
+}
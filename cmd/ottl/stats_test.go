@@ -0,0 +1,113 @@
+package main
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/pdata/plog"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+)
+
+func TestCollectStatsTraces(t *testing.T) {
+	_, data, err := detectContextType(readTestData(t, "debug-exporter.log"))
+	require.NoError(t, err)
+
+	s := collectStats(data)
+	assert.Equal(t, "traces", s.signal)
+	assert.Equal(t, map[string]int{"resources": 1, "scopes": 1, "spans": 2, "span events": 1, "span links": 0}, s.total)
+	assert.Equal(t, valueCounts{`"checkout"`: 2}, s.resourceValues["service.name"])
+	assert.Equal(t, valueCounts{"4": 2}, s.resourceValues["host.cpu.count"])
+	assert.Equal(t, valueCounts{"Server": 1, "Client": 1}, s.counts[1].counts)
+
+	spanAttributes := s.attributes[2]
+	require.Equal(t, "span.attributes", spanAttributes.path)
+	status := spanAttributes.keys["http.response.status_code"]
+	require.NotNil(t, status)
+	assert.Equal(t, 1, status.records)
+	assert.Equal(t, valueCounts{"Int": 1}, status.types)
+}
+
+func TestCollectStatsLogsAndMetrics(t *testing.T) {
+	logs := plog.NewLogs()
+	rl := logs.ResourceLogs().AppendEmpty()
+	rl.Resource().Attributes().PutStr("service.name", "api")
+	records := rl.ScopeLogs().AppendEmpty().LogRecords()
+	for _, status := range []any{"200", int64(200), int64(500)} {
+		record := records.AppendEmpty()
+		record.SetSeverityText("INFO")
+		record.SetSeverityNumber(plog.SeverityNumberInfo)
+		require.NoError(t, record.Attributes().FromRaw(map[string]any{"status": status}))
+	}
+
+	s := collectStats(logs)
+	assert.Equal(t, 3, s.total["log records"])
+	assert.Equal(t, valueCounts{`"api"`: 3}, s.resourceValues["service.name"])
+	assert.Equal(t, valueCounts{`"INFO" (9)`: 3}, s.counts[0].counts)
+	status := s.attributes[2].keys["status"]
+	assert.Equal(t, 3, status.records)
+	assert.Equal(t, valueCounts{`"200"`: 1, "200": 1, "500": 1}, status.values)
+	assert.Equal(t, valueCounts{"Str": 1, "Int": 2}, status.types)
+
+	metrics, err := (&pmetric.JSONUnmarshaler{}).UnmarshalMetrics(readTestData(t, "metrics.json"))
+	require.NoError(t, err)
+	s = collectStats(metrics)
+	assert.Equal(t, map[string]int{"resources": 1, "scopes": 1, "metrics": 3, "data points": 3}, s.total)
+	assert.Equal(t, valueCounts{"Gauge": 1, "Sum": 1, "Histogram": 1}, s.counts[1].counts)
+	assert.Len(t, s.attributes[2].keys, 2)
+}
+
+func TestParseSignals(t *testing.T) {
+	var input []byte
+	input = append(input, ndjsonLine(t, "logs.json")...)
+	input = append(input, ndjsonLine(t, "traces.json")...)
+
+	signals, err := parseSignals(input)
+	require.NoError(t, err)
+	require.Len(t, signals, 2)
+	assert.Equal(t, "traces", collectStats(signals[0]).signal)
+	assert.Equal(t, "logs", collectStats(signals[1]).signal)
+
+	signals, err = parseSignals(readTestData(t, "profiles.json"))
+	require.NoError(t, err)
+	require.Len(t, signals, 1)
+	assert.Equal(t, "profiles", collectStats(signals[0]).signal)
+
+	_, err = parseSignals([]byte(`{"hello":"world"}`))
+	assert.Error(t, err)
+}
+
+func TestWriteStats(t *testing.T) {
+	logs := plog.NewLogs()
+	rl := logs.ResourceLogs().AppendEmpty()
+	rl.Resource().Attributes().PutStr("service.name", "api")
+	records := rl.ScopeLogs().AppendEmpty().LogRecords()
+	for _, severity := range []string{"INFO", "WARN", "INFO", "ERROR"} {
+		record := records.AppendEmpty()
+		record.SetSeverityText(severity)
+		record.Body().SetStr("message")
+	}
+
+	var out bytes.Buffer
+	require.NoError(t, writeStats(&out, collectStats(logs), 2))
+	assert.Equal(t, `Logs
+  resources    1
+  scopes       1
+  log records  4
+
+Severities (3 distinct, top 2)
+  2  "INFO" (0)
+  1  "ERROR" (0)
+
+Body types (1 distinct)
+  4  Str
+
+Log records by resource attribute service.name (1 distinct)
+  4  "api"
+
+Keys of resource.attributes
+  key           present  distinct  types
+  service.name  1        1         Str 1
+`, out.String())
+}
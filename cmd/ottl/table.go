@@ -0,0 +1,471 @@
+// Copyright 2025 Dose de Telemetria GmbH
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"encoding/csv"
+	"fmt"
+	"io"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/plog"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.opentelemetry.io/collector/pdata/pprofile"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+)
+
+// recordTable holds records flattened into rows, with columns named after the OTTL paths
+// of the values they hold, such as span.name or resource.attributes["service.name"]
+type recordTable struct {
+	ctx        contextType
+	attributes map[string][]string // attribute columns by map path, in order of appearance
+	seen       map[string]bool     // attribute columns
+	rows       []map[string]string
+}
+
+// scopeFields are the columns of the instrumentation scope fields
+var scopeFields = []string{"instrumentation_scope.name", "instrumentation_scope.version"}
+
+// recordFields are the columns of the fields of each kind of record
+var recordFields = map[contextType][]string{
+	contextTypeSpan: {
+		"span.trace_id", "span.span_id", "span.parent_span_id", "span.name", "span.kind",
+		"span.start_time", "span.end_time", "span.status.code", "span.status.message",
+	},
+	contextTypeLog: {
+		"log.time", "log.observed_time", "log.severity_number", "log.severity_text",
+		"log.event_name", "log.body", "log.trace_id", "log.span_id",
+	},
+	contextTypeMetric: {
+		"metric.name", "metric.description", "metric.unit", "metric.type",
+		"metric.aggregation_temporality", "metric.is_monotonic",
+	},
+	contextTypeDatapoint: {
+		"metric.name", "metric.type", "datapoint.start_time", "datapoint.time",
+		"datapoint.value_int", "datapoint.value_double", "datapoint.count", "datapoint.sum",
+	},
+	contextTypeProfile: {
+		"profile.profile_id", "profile.time", "profile.duration",
+	},
+}
+
+// recordAttributes are the attribute maps of each kind of record
+var recordAttributes = map[contextType]string{
+	contextTypeSpan:      "span.attributes",
+	contextTypeLog:       "log.attributes",
+	contextTypeDatapoint: "datapoint.attributes",
+	contextTypeProfile:   "profile.attributes",
+}
+
+func newRecordTable(ctx contextType) *recordTable {
+	return &recordTable{ctx: ctx, attributes: map[string][]string{}, seen: map[string]bool{}}
+}
+
+// flattenRecords flattens the records of the data into a table, one row per record of the context
+func flattenRecords(ctx contextType, data interface{}) (*recordTable, error) {
+	table := newRecordTable(ctx)
+	switch d := data.(type) {
+	case ptrace.Traces:
+		for i := 0; i < d.ResourceSpans().Len(); i++ {
+			rs := d.ResourceSpans().At(i)
+			for j := 0; j < rs.ScopeSpans().Len(); j++ {
+				ss := rs.ScopeSpans().At(j)
+				for k := 0; k < ss.Spans().Len(); k++ {
+					row := table.newRow(rs.Resource(), ss.Scope())
+					table.addSpan(row, ss.Spans().At(k))
+				}
+			}
+		}
+	case plog.Logs:
+		for i := 0; i < d.ResourceLogs().Len(); i++ {
+			rl := d.ResourceLogs().At(i)
+			for j := 0; j < rl.ScopeLogs().Len(); j++ {
+				sl := rl.ScopeLogs().At(j)
+				for k := 0; k < sl.LogRecords().Len(); k++ {
+					row := table.newRow(rl.Resource(), sl.Scope())
+					table.addLogRecord(row, sl.LogRecords().At(k))
+				}
+			}
+		}
+	case pmetric.Metrics:
+		for i := 0; i < d.ResourceMetrics().Len(); i++ {
+			rm := d.ResourceMetrics().At(i)
+			for j := 0; j < rm.ScopeMetrics().Len(); j++ {
+				sm := rm.ScopeMetrics().At(j)
+				for k := 0; k < sm.Metrics().Len(); k++ {
+					metric := sm.Metrics().At(k)
+					if ctx == contextTypeMetric {
+						table.addMetric(table.newRow(rm.Resource(), sm.Scope()), metric)
+						continue
+					}
+					_ = forEachDataPoint(metric, func(_ int, dp any) error {
+						table.addDataPoint(table.newRow(rm.Resource(), sm.Scope()), metric, dp)
+						return nil
+					})
+				}
+			}
+		}
+	case pprofile.Profiles:
+		dictionary := d.ProfilesDictionary()
+		for i := 0; i < d.ResourceProfiles().Len(); i++ {
+			rp := d.ResourceProfiles().At(i)
+			for j := 0; j < rp.ScopeProfiles().Len(); j++ {
+				sp := rp.ScopeProfiles().At(j)
+				for k := 0; k < sp.Profiles().Len(); k++ {
+					row := table.newRow(rp.Resource(), sp.Scope())
+					table.addProfile(row, dictionary, sp.Profiles().At(k))
+				}
+			}
+		}
+	default:
+		return nil, fmt.Errorf("unsupported data type %T", data)
+	}
+	return table, nil
+}
+
+// newRow adds a row holding the resource and instrumentation scope of a record
+func (t *recordTable) newRow(resource pcommon.Resource, scope pcommon.InstrumentationScope) map[string]string {
+	row := map[string]string{
+		"instrumentation_scope.name":    scope.Name(),
+		"instrumentation_scope.version": scope.Version(),
+	}
+	t.setAttributes(row, "resource.attributes", resource.Attributes())
+	t.setAttributes(row, "instrumentation_scope.attributes", scope.Attributes())
+	t.rows = append(t.rows, row)
+	return row
+}
+
+// setAttributes sets a column for each entry of a map, adding the columns not seen yet
+func (t *recordTable) setAttributes(row map[string]string, path string, attributes pcommon.Map) {
+	attributes.Range(func(k string, v pcommon.Value) bool {
+		column := fmt.Sprintf("%s[%q]", path, k)
+		if !t.seen[column] {
+			t.seen[column] = true
+			t.attributes[path] = append(t.attributes[path], column)
+		}
+		row[column] = v.AsString()
+		return true
+	})
+}
+
+func (t *recordTable) addSpan(row map[string]string, span ptrace.Span) {
+	row["span.trace_id"] = traceIDString(span.TraceID())
+	row["span.span_id"] = spanIDString(span.SpanID())
+	row["span.parent_span_id"] = spanIDString(span.ParentSpanID())
+	row["span.name"] = span.Name()
+	row["span.kind"] = span.Kind().String()
+	row["span.start_time"] = timestampString(span.StartTimestamp())
+	row["span.end_time"] = timestampString(span.EndTimestamp())
+	row["span.status.code"] = span.Status().Code().String()
+	row["span.status.message"] = span.Status().Message()
+	t.setAttributes(row, "span.attributes", span.Attributes())
+}
+
+func (t *recordTable) addLogRecord(row map[string]string, logRecord plog.LogRecord) {
+	row["log.time"] = timestampString(logRecord.Timestamp())
+	row["log.observed_time"] = timestampString(logRecord.ObservedTimestamp())
+	row["log.severity_number"] = strconv.Itoa(int(logRecord.SeverityNumber()))
+	row["log.severity_text"] = logRecord.SeverityText()
+	row["log.event_name"] = logRecord.EventName()
+	row["log.body"] = logRecord.Body().AsString()
+	row["log.trace_id"] = traceIDString(logRecord.TraceID())
+	row["log.span_id"] = spanIDString(logRecord.SpanID())
+	t.setAttributes(row, "log.attributes", logRecord.Attributes())
+}
+
+func (t *recordTable) addMetric(row map[string]string, metric pmetric.Metric) {
+	row["metric.name"] = metric.Name()
+	row["metric.description"] = metric.Description()
+	row["metric.unit"] = metric.Unit()
+	row["metric.type"] = metric.Type().String()
+	switch metric.Type() {
+	case pmetric.MetricTypeSum:
+		row["metric.aggregation_temporality"] = metric.Sum().AggregationTemporality().String()
+		row["metric.is_monotonic"] = strconv.FormatBool(metric.Sum().IsMonotonic())
+	case pmetric.MetricTypeHistogram:
+		row["metric.aggregation_temporality"] = metric.Histogram().AggregationTemporality().String()
+	case pmetric.MetricTypeExponentialHistogram:
+		row["metric.aggregation_temporality"] = metric.ExponentialHistogram().AggregationTemporality().String()
+	}
+}
+
+func (t *recordTable) addDataPoint(row map[string]string, metric pmetric.Metric, dp any) {
+	row["metric.name"] = metric.Name()
+	row["metric.type"] = metric.Type().String()
+	switch dp := dp.(type) {
+	case pmetric.NumberDataPoint:
+		row["datapoint.start_time"] = timestampString(dp.StartTimestamp())
+		row["datapoint.time"] = timestampString(dp.Timestamp())
+		switch dp.ValueType() {
+		case pmetric.NumberDataPointValueTypeInt:
+			row["datapoint.value_int"] = strconv.FormatInt(dp.IntValue(), 10)
+		case pmetric.NumberDataPointValueTypeDouble:
+			row["datapoint.value_double"] = formatFloat(dp.DoubleValue())
+		}
+		t.setAttributes(row, "datapoint.attributes", dp.Attributes())
+	case pmetric.HistogramDataPoint:
+		row["datapoint.start_time"] = timestampString(dp.StartTimestamp())
+		row["datapoint.time"] = timestampString(dp.Timestamp())
+		row["datapoint.count"] = strconv.FormatUint(dp.Count(), 10)
+		if dp.HasSum() {
+			row["datapoint.sum"] = formatFloat(dp.Sum())
+		}
+		t.setAttributes(row, "datapoint.attributes", dp.Attributes())
+	case pmetric.ExponentialHistogramDataPoint:
+		row["datapoint.start_time"] = timestampString(dp.StartTimestamp())
+		row["datapoint.time"] = timestampString(dp.Timestamp())
+		row["datapoint.count"] = strconv.FormatUint(dp.Count(), 10)
+		if dp.HasSum() {
+			row["datapoint.sum"] = formatFloat(dp.Sum())
+		}
+		t.setAttributes(row, "datapoint.attributes", dp.Attributes())
+	case pmetric.SummaryDataPoint:
+		row["datapoint.start_time"] = timestampString(dp.StartTimestamp())
+		row["datapoint.time"] = timestampString(dp.Timestamp())
+		row["datapoint.count"] = strconv.FormatUint(dp.Count(), 10)
+		row["datapoint.sum"] = formatFloat(dp.Sum())
+		t.setAttributes(row, "datapoint.attributes", dp.Attributes())
+	}
+}
+
+func (t *recordTable) addProfile(row map[string]string, dictionary pprofile.ProfilesDictionary, profile pprofile.Profile) {
+	if !profile.ProfileID().IsEmpty() {
+		row["profile.profile_id"] = profile.ProfileID().String()
+	}
+	row["profile.time"] = timestampString(profile.Time())
+	row["profile.duration"] = time.Duration(profile.Duration()).String()
+	t.setAttributes(row, "profile.attributes", pprofile.FromAttributeIndices(dictionary.AttributeTable(), profile))
+}
+
+// columns returns all the columns of the table: resource attributes, scope fields and
+// attributes, then record fields and attributes. Attribute columns are sorted by key.
+func (t *recordTable) columns() []string {
+	sorted := func(path string) []string {
+		columns := slices.Clone(t.attributes[path])
+		sort.Strings(columns)
+		return columns
+	}
+
+	columns := sorted("resource.attributes")
+	columns = append(columns, scopeFields...)
+	columns = append(columns, sorted("instrumentation_scope.attributes")...)
+	columns = append(columns, recordFields[t.ctx]...)
+	if path, ok := recordAttributes[t.ctx]; ok {
+		columns = append(columns, sorted(path)...)
+	}
+	return columns
+}
+
+// attributeGroups returns the maps whose entries are columns of the table
+func (t *recordTable) attributeGroups() []string {
+	return attributeGroups(t.ctx)
+}
+
+// attributeGroups returns the maps whose entries are columns of the tables of a context
+func attributeGroups(ctx contextType) []string {
+	groups := []string{"resource.attributes", "instrumentation_scope.attributes"}
+	if path, ok := recordAttributes[ctx]; ok {
+		groups = append(groups, path)
+	}
+	return groups
+}
+
+// checkColumns reports the selectors of --columns that are neither a field, a map nor an entry
+// of a map of any of the contexts, so that typos fail before the statements run. Which entries
+// of a map are columns depends on the records, so entries are only checked by selectColumns.
+func checkColumns(selectors []string, contexts []contextType) error {
+	for _, selector := range selectors {
+		selector = quoteColumnKey(selector)
+		if !slices.ContainsFunc(contexts, func(ctx contextType) bool { return isColumnSelector(ctx, selector) }) {
+			if len(contexts) == 1 {
+				return unknownColumnError(contexts[0], selector)
+			}
+			return fmt.Errorf("unknown column %q: it is not a field or an attribute map of any context", selector)
+		}
+	}
+	return nil
+}
+
+// columnContexts returns the contexts --columns may refer to before the input is read: the
+// context of --context or --signal, or else any context the input may be detected as
+func columnContexts(contextFlag, signalFlag string) []contextType {
+	if ctx := parseContextFlag(contextFlag); ctx != contextTypeUnknown {
+		return []contextType{ctx}
+	}
+	if ctx := contextForSignal(strings.ToLower(signalFlag)); ctx != contextTypeUnknown {
+		return []contextType{ctx}
+	}
+	return []contextType{contextTypeSpan, contextTypeLog, contextTypeMetric, contextTypeProfile}
+}
+
+// isColumnSelector reports whether a selector names a field, a map or an entry of a map of a context
+func isColumnSelector(ctx contextType, selector string) bool {
+	groups := attributeGroups(ctx)
+	return slices.Contains(scopeFields, selector) || slices.Contains(recordFields[ctx], selector) ||
+		slices.Contains(groups, selector) || slices.Contains(groups, selector[:max(strings.Index(selector, "["), 0)])
+}
+
+// unknownColumnError reports a selector of --columns that names no column of a context
+func unknownColumnError(ctx contextType, selector string) error {
+	fields := append(slices.Clone(scopeFields), recordFields[ctx]...)
+	return fmt.Errorf("unknown %s column %q (valid: %s, or entries of %s)",
+		ctx, selector, strings.Join(fields, ", "), strings.Join(attributeGroups(ctx), ", "))
+}
+
+// selectColumns resolves the selectors of --columns. A selector is a column, or a map such as
+// span.attributes that selects the columns of all its entries.
+func (t *recordTable) selectColumns(selectors []string) ([]string, error) {
+	all := t.columns()
+	if len(selectors) == 0 {
+		return all, nil
+	}
+
+	fields := append(slices.Clone(scopeFields), recordFields[t.ctx]...)
+	groups := t.attributeGroups()
+	var selected []string
+	for _, selector := range selectors {
+		selector = quoteColumnKey(selector)
+		switch {
+		case slices.Contains(fields, selector):
+			selected = append(selected, selector)
+		case slices.Contains(groups, selector):
+			for _, column := range all {
+				if strings.HasPrefix(column, selector+"[") {
+					selected = append(selected, column)
+				}
+			}
+		case slices.Contains(groups, selector[:max(strings.Index(selector, "["), 0)]):
+			// Entries no record has are kept as empty columns, as they may just be rare
+			selected = append(selected, selector)
+		default:
+			return nil, unknownColumnError(t.ctx, selector)
+		}
+	}
+	return selected, nil
+}
+
+// quoteColumnKey quotes the key of a map entry column given without quotes, as in
+// span.attributes[http.method]
+func quoteColumnKey(column string) string {
+	open := strings.Index(column, "[")
+	if open < 0 || !strings.HasSuffix(column, "]") || column[open+1] == '"' {
+		return column
+	}
+	return fmt.Sprintf("%s[%q]", column[:open], column[open+1:len(column)-1])
+}
+
+// parseColumns splits the value of --columns on the commas that are not within brackets,
+// so that keys of map entries such as span.attributes["a,b"] may contain commas
+func parseColumns(value string) []string {
+	var columns []string
+	depth, inString, start := 0, false, 0
+	for i := 0; i < len(value); i++ {
+		switch c := value[i]; {
+		case inString && c == '\\':
+			i++
+		case c == '"':
+			inString = !inString
+		case inString:
+		case c == '[':
+			depth++
+		case c == ']':
+			depth--
+		case c == ',' && depth == 0:
+			columns = appendColumn(columns, value[start:i])
+			start = i + 1
+		}
+	}
+	return appendColumn(columns, value[start:])
+}
+
+func appendColumn(columns []string, column string) []string {
+	column = strings.TrimSpace(column)
+	if column == "" {
+		return columns
+	}
+	return append(columns, column)
+}
+
+// writeTable writes the table as aligned text, one line per row
+func writeTable(w io.Writer, table *recordTable, columns []string) error {
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	cells := slices.Clone(columns)
+	fmt.Fprintln(tw, strings.Join(cells, "\t"))
+	for _, row := range table.rows {
+		for i, column := range columns {
+			cells[i] = tableCell(row[column])
+		}
+		fmt.Fprintln(tw, strings.Join(cells, "\t"))
+	}
+	return tw.Flush()
+}
+
+// tableCell escapes the characters that would break the alignment of a table
+func tableCell(value string) string {
+	if value == "" {
+		return "-"
+	}
+	return strings.NewReplacer("\t", `\t`, "\n", `\n`, "\r", `\r`).Replace(value)
+}
+
+// writeCSV writes the table as CSV with a header row
+func writeCSV(w io.Writer, table *recordTable, columns []string) error {
+	writer := csv.NewWriter(w)
+	if err := writer.Write(columns); err != nil {
+		return err
+	}
+	record := make([]string, len(columns))
+	for _, row := range table.rows {
+		for i, column := range columns {
+			record[i] = row[column]
+		}
+		if err := writer.Write(record); err != nil {
+			return err
+		}
+	}
+	writer.Flush()
+	return writer.Error()
+}
+
+func traceIDString(id pcommon.TraceID) string {
+	if id.IsEmpty() {
+		return ""
+	}
+	return id.String()
+}
+
+func spanIDString(id pcommon.SpanID) string {
+	if id.IsEmpty() {
+		return ""
+	}
+	return id.String()
+}
+
+func timestampString(ts pcommon.Timestamp) string {
+	if ts == 0 {
+		return ""
+	}
+	return ts.AsTime().UTC().Format(time.RFC3339Nano)
+}
+
+func formatFloat(f float64) string {
+	return strconv.FormatFloat(f, 'g', -1, 64)
+}
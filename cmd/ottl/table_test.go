@@ -0,0 +1,189 @@
+package main
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFlattenRecordsColumns(t *testing.T) {
+	tests := []struct {
+		name     string
+		file     string
+		ctx      contextType
+		rows     int
+		expected []string
+	}{
+		{
+			name: "spans",
+			file: "traces.json",
+			ctx:  contextTypeSpan,
+			rows: 1,
+			expected: []string{
+				`resource.attributes["service.name"]`, "instrumentation_scope.name", "instrumentation_scope.version",
+				"span.trace_id", "span.span_id", "span.parent_span_id", "span.name", "span.kind",
+				"span.start_time", "span.end_time", "span.status.code", "span.status.message",
+				`span.attributes["http.method"]`, `span.attributes["http.status_code"]`,
+			},
+		},
+		{
+			name: "metrics",
+			file: "metrics.json",
+			ctx:  contextTypeMetric,
+			rows: 3,
+			expected: []string{
+				`resource.attributes["service.name"]`, "instrumentation_scope.name", "instrumentation_scope.version",
+				"metric.name", "metric.description", "metric.unit", "metric.type",
+				"metric.aggregation_temporality", "metric.is_monotonic",
+			},
+		},
+		{
+			name: "data points",
+			file: "metrics.json",
+			ctx:  contextTypeDatapoint,
+			rows: 3,
+			expected: []string{
+				`resource.attributes["service.name"]`, "instrumentation_scope.name", "instrumentation_scope.version",
+				"metric.name", "metric.type", "datapoint.start_time", "datapoint.time",
+				"datapoint.value_int", "datapoint.value_double", "datapoint.count", "datapoint.sum",
+				`datapoint.attributes["endpoint"]`, `datapoint.attributes["method"]`,
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, data, err := parseInput(readTestData(t, tt.file), tt.ctx.String(), "")
+			require.NoError(t, err)
+
+			table, err := flattenRecords(tt.ctx, data)
+			require.NoError(t, err)
+			assert.Len(t, table.rows, tt.rows)
+			assert.Equal(t, tt.expected, table.columns())
+		})
+	}
+}
+
+func TestFlattenRecordsValues(t *testing.T) {
+	_, data, err := parseInput(readTestData(t, "logs.json"), "log", "")
+	require.NoError(t, err)
+	table, err := flattenRecords(contextTypeLog, data)
+	require.NoError(t, err)
+	require.Len(t, table.rows, 2)
+
+	row := table.rows[1]
+	assert.Equal(t, "test-service", row[`resource.attributes["service.name"]`])
+	assert.Equal(t, "ERROR", row["log.severity_text"])
+	assert.Equal(t, "17", row["log.severity_number"])
+	assert.Equal(t, "An error occurred", row["log.body"])
+
+	_, data, err = parseInput(readTestData(t, "profiles.json"), "profile", "")
+	require.NoError(t, err)
+	table, err = flattenRecords(contextTypeProfile, data)
+	require.NoError(t, err)
+	require.Len(t, table.rows, 1)
+	assert.Equal(t, "0102030405060708090a0b0c0d0e0f10", table.rows[0]["profile.profile_id"])
+	assert.Equal(t, "main", table.rows[0][`profile.attributes["thread.name"]`])
+}
+
+func TestSelectColumns(t *testing.T) {
+	_, data, err := parseInput(readTestData(t, "traces.json"), "span", "")
+	require.NoError(t, err)
+	table, err := flattenRecords(contextTypeSpan, data)
+	require.NoError(t, err)
+
+	tests := []struct {
+		name      string
+		columns   string
+		expected  []string
+		expectErr string
+	}{
+		{
+			name:     "fields and map",
+			columns:  "span.name, span.attributes",
+			expected: []string{"span.name", `span.attributes["http.method"]`, `span.attributes["http.status_code"]`},
+		},
+		{
+			name:     "entries with and without quotes",
+			columns:  `span.attributes[http.method],resource.attributes["service.name"]`,
+			expected: []string{`span.attributes["http.method"]`, `resource.attributes["service.name"]`},
+		},
+		{
+			name:     "entry no record has",
+			columns:  `span.kind,span.attributes["a,b"]`,
+			expected: []string{"span.kind", `span.attributes["a,b"]`},
+		},
+		{
+			name:      "unknown field",
+			columns:   "span.nme",
+			expectErr: `unknown span column "span.nme"`,
+		},
+		{
+			name:      "map of another context",
+			columns:   `log.attributes["env"]`,
+			expectErr: "unknown span column",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			columns, err := table.selectColumns(parseColumns(tt.columns))
+			if tt.expectErr != "" {
+				assert.ErrorContains(t, err, tt.expectErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, columns)
+		})
+	}
+}
+
+func TestWriteTableAndCSV(t *testing.T) {
+	table := newRecordTable(contextTypeLog)
+	table.rows = []map[string]string{
+		{"log.severity_text": "INFO", "log.body": "first\tline\nsecond, line"},
+		{"log.severity_text": "WARN"},
+	}
+	columns := []string{"log.severity_text", "log.body"}
+
+	var text bytes.Buffer
+	require.NoError(t, writeTable(&text, table, columns))
+	assert.Equal(t, "log.severity_text  log.body\n"+
+		`INFO               first\tline\nsecond, line`+"\n"+
+		"WARN               -\n", text.String())
+
+	var csv bytes.Buffer
+	require.NoError(t, writeCSV(&csv, table, columns))
+	assert.Equal(t, "log.severity_text,log.body\n"+
+		"INFO,\"first\tline\nsecond, line\"\n"+
+		"WARN,\n", csv.String())
+}
+
+func TestRunTransformOutputFormatFlags(t *testing.T) {
+	defer func() { outputFormat, columnsFlag = "json", "" }()
+
+	outputFormat = "yaml"
+	assert.ErrorContains(t, runTransform(nil, nil), `invalid --output-format "yaml"`)
+
+	outputFormat, columnsFlag = "json", "span.name"
+	assert.ErrorContains(t, runTransform(nil, nil), "--columns requires --output-format table or csv")
+
+	// Unknown columns fail before the input is read
+	outputFormat, columnsFlag = "table", "bogus"
+	err := runTransform(nil, nil)
+	assert.EqualError(t, err, `unknown column "bogus": it is not a field or an attribute map of any context`)
+	assert.Equal(t, 3, exitCode(err))
+}
+
+func TestCheckColumns(t *testing.T) {
+	anyContext := columnContexts("", "")
+	assert.NoError(t, checkColumns([]string{"log.body", `span.attributes[http.method]`, "resource.attributes"}, anyContext))
+	assert.ErrorContains(t, checkColumns([]string{"span.nme"}, anyContext), `unknown column "span.nme"`)
+
+	assert.Equal(t, []contextType{contextTypeDatapoint}, columnContexts("datapoint", "metrics"))
+	assert.Equal(t, []contextType{contextTypeLog}, columnContexts("", "logs"))
+	assert.ErrorContains(t, checkColumns([]string{"log.body"}, columnContexts("span", "")), `unknown span column "log.body"`)
+	assert.NoError(t, checkColumns([]string{`datapoint.attributes["x"]`}, columnContexts("datapoint", "")))
+}
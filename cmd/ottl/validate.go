@@ -0,0 +1,702 @@
+// Copyright 2025 Dose de Telemetria GmbH
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// schemaIssue is a difference between OTLP JSON input and the OTLP schema
+type schemaIssue struct {
+	Line    int    `json:"line,omitempty"` // line of file exporter NDJSON, or zero
+	Path    string `json:"path"`
+	Message string `json:"message"`
+}
+
+func (i schemaIssue) String() string {
+	if i.Line > 0 {
+		return fmt.Sprintf("line %d: %s: %s", i.Line, i.Path, i.Message)
+	}
+	return i.Path + ": " + i.Message
+}
+
+// maxReportedIssues bounds the issues listed in the message of a schemaValidationError
+const maxReportedIssues = 20
+
+// schemaValidationError is returned by --strict when the input does not match the OTLP schema
+type schemaValidationError struct {
+	issues []schemaIssue
+}
+
+func (e *schemaValidationError) Error() string {
+	var b strings.Builder
+	b.WriteString(e.summary() + ":")
+	for i, issue := range e.issues {
+		if i == maxReportedIssues {
+			fmt.Fprintf(&b, "\n  ... and %d more", len(e.issues)-maxReportedIssues)
+			break
+		}
+		b.WriteString("\n  " + issue.String())
+	}
+	return b.String()
+}
+
+func (e *schemaValidationError) summary() string {
+	if len(e.issues) == 1 {
+		return "input does not match the OTLP schema (1 issue)"
+	}
+	return fmt.Sprintf("input does not match the OTLP schema (%d issues)", len(e.issues))
+}
+
+// validateInput validates input against the OTLP schema for --strict, which reports what the
+// OTLP JSON unmarshalers would silently ignore or default, such as misspelled fields. The signal
+// flag value is used when the signal of a request cannot be detected.
+func validateInput(data []byte, signalFlag string) error {
+	if isDebugText(data) {
+		return errors.New("strict validation is not available for debug exporter output")
+	}
+
+	var issues []schemaIssue
+	if isNDJSON(data) {
+		for i, line := range bytes.Split(data, []byte("\n")) {
+			line = bytes.TrimSpace(line)
+			if len(line) == 0 {
+				continue
+			}
+			lineIssues, err := validateOTLPRequest(line, signalFlag)
+			if err != nil {
+				return fmt.Errorf("line %d: %w", i+1, err)
+			}
+			for _, issue := range lineIssues {
+				issue.Line = i + 1
+				issues = append(issues, issue)
+			}
+		}
+	} else {
+		var err error
+		if issues, err = validateOTLPRequest(data, signalFlag); err != nil {
+			return err
+		}
+	}
+
+	if len(issues) > 0 {
+		return &schemaValidationError{issues: issues}
+	}
+	return nil
+}
+
+// validateOTLPRequest validates an OTLP JSON request against the schema of its signal
+func validateOTLPRequest(data []byte, signalFlag string) ([]schemaIssue, error) {
+	signal, err := sniffSignal(data)
+	if err != nil {
+		if signalFlag == "" {
+			return nil, err
+		}
+		signal = strings.ToLower(signalFlag)
+	}
+	return validateOTLPJSON(data, signal)
+}
+
+// schemaKind is the kind of JSON value a field of the OTLP schema holds
+type schemaKind int
+
+const (
+	schemaObject schemaKind = iota
+	schemaArray
+	schemaString
+	schemaBool
+	schemaInt    // a signed integer of bits bits, as a number or a decimal string
+	schemaUint   // an unsigned integer of bits bits, as a number or a decimal string
+	schemaDouble // a number, or a string such as "NaN"
+	schemaEnum   // an enum value, as a number or the name of the value
+	schemaID     // an ID of size bytes, in hex
+	schemaBytes  // bytes in base64
+)
+
+// schemaType describes a JSON value of the OTLP schema
+type schemaType struct {
+	kind   schemaKind
+	name   string                 // what the value is, such as "span", used in messages
+	fields map[string]*schemaType // fields of objects, by camelCase name
+	elem   *schemaType            // elements of arrays
+	bits   int                    // size of integers
+	size   int                    // size of IDs in bytes
+	enum   []string               // names of enum values, by number
+	check  func(v *schemaValidator, path string, object map[string]any)
+}
+
+func objectType(name string, fields map[string]*schemaType) *schemaType {
+	return &schemaType{kind: schemaObject, name: name, fields: fields}
+}
+
+func arrayOf(elem *schemaType) *schemaType {
+	return &schemaType{kind: schemaArray, elem: elem}
+}
+
+func enumOf(name string, values ...string) *schemaType {
+	return &schemaType{kind: schemaEnum, name: name, enum: values}
+}
+
+var (
+	stringType    = &schemaType{kind: schemaString}
+	boolType      = &schemaType{kind: schemaBool}
+	int32Type     = &schemaType{kind: schemaInt, bits: 32}
+	int64Type     = &schemaType{kind: schemaInt, bits: 64}
+	uint32Type    = &schemaType{kind: schemaUint, bits: 32}
+	uint64Type    = &schemaType{kind: schemaUint, bits: 64}
+	doubleType    = &schemaType{kind: schemaDouble}
+	bytesType     = &schemaType{kind: schemaBytes}
+	traceIDType   = &schemaType{kind: schemaID, name: "trace ID", size: 16}
+	spanIDType    = &schemaType{kind: schemaID, name: "span ID", size: 8}
+	timestampType = uint64Type
+)
+
+// anyValueType and keyValueType are recursive, so their fields are set in init
+var (
+	anyValueType   = objectType("value", nil)
+	keyValueType   = objectType("attribute", nil)
+	attributesType = arrayOf(keyValueType)
+)
+
+// anyValueFields are the fields of a value, of which one at most is set
+var anyValueFields = []string{"stringValue", "boolValue", "intValue", "doubleValue", "bytesValue", "arrayValue", "kvlistValue"}
+
+var resourceType = objectType("resource", map[string]*schemaType{
+	"attributes":             attributesType,
+	"droppedAttributesCount": uint32Type,
+	"entityRefs": arrayOf(objectType("entity reference", map[string]*schemaType{
+		"schemaUrl":       stringType,
+		"type":            stringType,
+		"idKeys":          arrayOf(stringType),
+		"descriptionKeys": arrayOf(stringType),
+	})),
+})
+
+var scopeType = objectType("instrumentation scope", map[string]*schemaType{
+	"name":                   stringType,
+	"version":                stringType,
+	"attributes":             attributesType,
+	"droppedAttributesCount": uint32Type,
+})
+
+var tracesSchema = objectType("traces request", map[string]*schemaType{
+	"resourceSpans": arrayOf(objectType("resource spans", map[string]*schemaType{
+		"resource":  resourceType,
+		"schemaUrl": stringType,
+		"scopeSpans": arrayOf(objectType("scope spans", map[string]*schemaType{
+			"scope":     scopeType,
+			"schemaUrl": stringType,
+			"spans": arrayOf(objectType("span", map[string]*schemaType{
+				"traceId":                traceIDType,
+				"spanId":                 spanIDType,
+				"traceState":             stringType,
+				"parentSpanId":           spanIDType,
+				"flags":                  uint32Type,
+				"name":                   stringType,
+				"kind":                   enumOf("span kind", "SPAN_KIND_UNSPECIFIED", "SPAN_KIND_INTERNAL", "SPAN_KIND_SERVER", "SPAN_KIND_CLIENT", "SPAN_KIND_PRODUCER", "SPAN_KIND_CONSUMER"),
+				"startTimeUnixNano":      timestampType,
+				"endTimeUnixNano":        timestampType,
+				"attributes":             attributesType,
+				"droppedAttributesCount": uint32Type,
+				"events": arrayOf(objectType("span event", map[string]*schemaType{
+					"timeUnixNano":           timestampType,
+					"name":                   stringType,
+					"attributes":             attributesType,
+					"droppedAttributesCount": uint32Type,
+				})),
+				"droppedEventsCount": uint32Type,
+				"links": arrayOf(objectType("span link", map[string]*schemaType{
+					"traceId":                traceIDType,
+					"spanId":                 spanIDType,
+					"traceState":             stringType,
+					"attributes":             attributesType,
+					"droppedAttributesCount": uint32Type,
+					"flags":                  uint32Type,
+				})),
+				"droppedLinksCount": uint32Type,
+				"status": objectType("status", map[string]*schemaType{
+					"message": stringType,
+					"code":    enumOf("status code", "STATUS_CODE_UNSET", "STATUS_CODE_OK", "STATUS_CODE_ERROR"),
+				}),
+			})),
+		})),
+	})),
+})
+
+var logsSchema = objectType("logs request", map[string]*schemaType{
+	"resourceLogs": arrayOf(objectType("resource logs", map[string]*schemaType{
+		"resource":  resourceType,
+		"schemaUrl": stringType,
+		"scopeLogs": arrayOf(objectType("scope logs", map[string]*schemaType{
+			"scope":     scopeType,
+			"schemaUrl": stringType,
+			"logRecords": arrayOf(objectType("log record", map[string]*schemaType{
+				"timeUnixNano":           timestampType,
+				"observedTimeUnixNano":   timestampType,
+				"severityNumber":         enumOf("severity number", severityNumberNames()...),
+				"severityText":           stringType,
+				"body":                   anyValueType,
+				"attributes":             attributesType,
+				"droppedAttributesCount": uint32Type,
+				"flags":                  uint32Type,
+				"traceId":                traceIDType,
+				"spanId":                 spanIDType,
+				"eventName":              stringType,
+			})),
+		})),
+	})),
+})
+
+var temporalityType = enumOf("aggregation temporality",
+	"AGGREGATION_TEMPORALITY_UNSPECIFIED", "AGGREGATION_TEMPORALITY_DELTA", "AGGREGATION_TEMPORALITY_CUMULATIVE")
+
+var exemplarsType = arrayOf(&schemaType{kind: schemaObject, name: "exemplar", fields: map[string]*schemaType{
+	"filteredAttributes": attributesType,
+	"timeUnixNano":       timestampType,
+	"asDouble":           doubleType,
+	"asInt":              int64Type,
+	"spanId":             spanIDType,
+	"traceId":            traceIDType,
+}, check: checkSingleValue})
+
+var numberDataPointType = &schemaType{kind: schemaObject, name: "number data point", fields: map[string]*schemaType{
+	"attributes":        attributesType,
+	"startTimeUnixNano": timestampType,
+	"timeUnixNano":      timestampType,
+	"asDouble":          doubleType,
+	"asInt":             int64Type,
+	"exemplars":         exemplarsType,
+	"flags":             uint32Type,
+}, check: checkSingleValue}
+
+var histogramDataPointType = &schemaType{kind: schemaObject, name: "histogram data point", fields: map[string]*schemaType{
+	"attributes":        attributesType,
+	"startTimeUnixNano": timestampType,
+	"timeUnixNano":      timestampType,
+	"count":             uint64Type,
+	"sum":               doubleType,
+	"bucketCounts":      arrayOf(uint64Type),
+	"explicitBounds":    arrayOf(doubleType),
+	"exemplars":         exemplarsType,
+	"flags":             uint32Type,
+	"min":               doubleType,
+	"max":               doubleType,
+}, check: checkHistogramBuckets}
+
+var bucketsType = objectType("buckets", map[string]*schemaType{
+	"offset":       int32Type,
+	"bucketCounts": arrayOf(uint64Type),
+})
+
+var exponentialHistogramDataPointType = objectType("exponential histogram data point", map[string]*schemaType{
+	"attributes":        attributesType,
+	"startTimeUnixNano": timestampType,
+	"timeUnixNano":      timestampType,
+	"count":             uint64Type,
+	"sum":               doubleType,
+	"scale":             int32Type,
+	"zeroCount":         uint64Type,
+	"positive":          bucketsType,
+	"negative":          bucketsType,
+	"flags":             uint32Type,
+	"exemplars":         exemplarsType,
+	"min":               doubleType,
+	"max":               doubleType,
+	"zeroThreshold":     doubleType,
+})
+
+var summaryDataPointType = objectType("summary data point", map[string]*schemaType{
+	"attributes":        attributesType,
+	"startTimeUnixNano": timestampType,
+	"timeUnixNano":      timestampType,
+	"count":             uint64Type,
+	"sum":               doubleType,
+	"quantileValues": arrayOf(objectType("quantile value", map[string]*schemaType{
+		"quantile": doubleType,
+		"value":    doubleType,
+	})),
+	"flags": uint32Type,
+})
+
+// dataPointTypes are the data points of each metric type, to tell which type a misplaced
+// data point field belongs to
+var dataPointTypes = []*schemaType{numberDataPointType, histogramDataPointType, exponentialHistogramDataPointType, summaryDataPointType}
+
+// metricDataFields are the fields of a metric holding its data, of which exactly one is set
+var metricDataFields = []string{"gauge", "sum", "histogram", "exponentialHistogram", "summary"}
+
+var metricsSchema = objectType("metrics request", map[string]*schemaType{
+	"resourceMetrics": arrayOf(objectType("resource metrics", map[string]*schemaType{
+		"resource":  resourceType,
+		"schemaUrl": stringType,
+		"scopeMetrics": arrayOf(objectType("scope metrics", map[string]*schemaType{
+			"scope":     scopeType,
+			"schemaUrl": stringType,
+			"metrics": arrayOf(&schemaType{kind: schemaObject, name: "metric", fields: map[string]*schemaType{
+				"name":        stringType,
+				"description": stringType,
+				"unit":        stringType,
+				"gauge": objectType("gauge", map[string]*schemaType{
+					"dataPoints": arrayOf(numberDataPointType),
+				}),
+				"sum": objectType("sum", map[string]*schemaType{
+					"dataPoints":             arrayOf(numberDataPointType),
+					"aggregationTemporality": temporalityType,
+					"isMonotonic":            boolType,
+				}),
+				"histogram": objectType("histogram", map[string]*schemaType{
+					"dataPoints":             arrayOf(histogramDataPointType),
+					"aggregationTemporality": temporalityType,
+				}),
+				"exponentialHistogram": objectType("exponential histogram", map[string]*schemaType{
+					"dataPoints":             arrayOf(exponentialHistogramDataPointType),
+					"aggregationTemporality": temporalityType,
+				}),
+				"summary": objectType("summary", map[string]*schemaType{
+					"dataPoints": arrayOf(summaryDataPointType),
+				}),
+				"metadata": attributesType,
+			}, check: checkMetricData}),
+		})),
+	})),
+})
+
+func init() {
+	anyValueType.fields = map[string]*schemaType{
+		"stringValue": stringType,
+		"boolValue":   boolType,
+		"intValue":    int64Type,
+		"doubleValue": doubleType,
+		"bytesValue":  bytesType,
+		"arrayValue":  objectType("array value", map[string]*schemaType{"values": arrayOf(anyValueType)}),
+		"kvlistValue": objectType("kvlist value", map[string]*schemaType{"values": attributesType}),
+	}
+	anyValueType.check = checkSingleAnyValue
+	keyValueType.fields = map[string]*schemaType{
+		"key":   stringType,
+		"value": anyValueType,
+	}
+}
+
+// severityNumberNames returns the names of the severity numbers, such as SEVERITY_NUMBER_WARN2
+func severityNumberNames() []string {
+	names := []string{"SEVERITY_NUMBER_UNSPECIFIED"}
+	for _, level := range []string{"TRACE", "DEBUG", "INFO", "WARN", "ERROR", "FATAL"} {
+		names = append(names, "SEVERITY_NUMBER_"+level)
+		for i := 2; i <= 4; i++ {
+			names = append(names, fmt.Sprintf("SEVERITY_NUMBER_%s%d", level, i))
+		}
+	}
+	return names
+}
+
+// schemaValidator collects the issues of a JSON document
+type schemaValidator struct {
+	issues []schemaIssue
+}
+
+func (v *schemaValidator) report(path, format string, args ...any) {
+	v.issues = append(v.issues, schemaIssue{Path: path, Message: fmt.Sprintf(format, args...)})
+}
+
+// validateOTLPJSON validates an OTLP JSON request of a signal against the OTLP schema
+func validateOTLPJSON(data []byte, signal string) ([]schemaIssue, error) {
+	var schema *schemaType
+	switch signal {
+	case "traces":
+		schema = tracesSchema
+	case "logs":
+		schema = logsSchema
+	case "metrics":
+		schema = metricsSchema
+	default:
+		return nil, fmt.Errorf("strict validation is not available for %s", signal)
+	}
+
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+	var document any
+	if err := decoder.Decode(&document); err != nil {
+		return nil, fmt.Errorf("invalid JSON: %w", err)
+	}
+
+	v := &schemaValidator{}
+	v.validate("$", document, schema)
+	return v.issues, nil
+}
+
+// validate validates a JSON value against a type of the schema
+func (v *schemaValidator) validate(path string, value any, typ *schemaType) {
+	if value == nil {
+		return // null stands for the default value
+	}
+
+	switch typ.kind {
+	case schemaObject:
+		object, ok := value.(map[string]any)
+		if !ok {
+			v.report(path, "expected an object, got %s", jsonKind(value))
+			return
+		}
+		for _, key := range sortedKeys(object) {
+			fieldPath := path + "." + key
+			field, ok := typ.fields[camelCase(key)]
+			if !ok {
+				v.report(fieldPath, "unknown field of %s%s", typ.name, misplacedFieldHint(typ, camelCase(key)))
+				continue
+			}
+			v.validate(fieldPath, object[key], field)
+		}
+		if typ.check != nil {
+			typ.check(v, path, object)
+		}
+	case schemaArray:
+		array, ok := value.([]any)
+		if !ok {
+			v.report(path, "expected an array, got %s", jsonKind(value))
+			return
+		}
+		for i, elem := range array {
+			v.validate(fmt.Sprintf("%s[%d]", path, i), elem, typ.elem)
+		}
+	case schemaString:
+		if _, ok := value.(string); !ok {
+			v.report(path, "expected a string, got %s", jsonKind(value))
+		}
+	case schemaBool:
+		if _, ok := value.(bool); !ok {
+			v.report(path, "expected a boolean, got %s", jsonKind(value))
+		}
+	case schemaInt, schemaUint:
+		v.validateInteger(path, value, typ)
+	case schemaDouble:
+		v.validateDouble(path, value)
+	case schemaEnum:
+		v.validateEnum(path, value, typ)
+	case schemaID:
+		v.validateID(path, value, typ)
+	case schemaBytes:
+		s, ok := value.(string)
+		if !ok {
+			v.report(path, "expected base64 bytes, got %s", jsonKind(value))
+		} else if _, err := base64.StdEncoding.DecodeString(s); err != nil {
+			v.report(path, "invalid base64 bytes %q", s)
+		}
+	}
+}
+
+// validateInteger validates an integer given as a number or a decimal string
+func (v *schemaValidator) validateInteger(path string, value any, typ *schemaType) {
+	var text string
+	switch value := value.(type) {
+	case json.Number:
+		text = value.String()
+	case string:
+		text = value
+	default:
+		v.report(path, "expected an integer, got %s", jsonKind(value))
+		return
+	}
+
+	var err error
+	if typ.kind == schemaInt {
+		_, err = strconv.ParseInt(text, 10, typ.bits)
+	} else {
+		_, err = strconv.ParseUint(text, 10, typ.bits)
+	}
+	if err != nil {
+		signed := "an unsigned"
+		if typ.kind == schemaInt {
+			signed = "a signed"
+		}
+		v.report(path, "%s is not %s %d-bit integer", text, signed, typ.bits)
+	}
+}
+
+// validateDouble validates a number, which may be a string such as "NaN" or "Infinity"
+func (v *schemaValidator) validateDouble(path string, value any) {
+	switch value := value.(type) {
+	case json.Number:
+		if _, err := value.Float64(); err != nil {
+			v.report(path, "%s is not a number", value)
+		}
+	case string:
+		if _, err := strconv.ParseFloat(value, 64); err != nil {
+			v.report(path, "%q is not a number", value)
+		}
+	default:
+		v.report(path, "expected a number, got %s", jsonKind(value))
+	}
+}
+
+// validateEnum validates an enum value given as a number or a name
+func (v *schemaValidator) validateEnum(path string, value any, typ *schemaType) {
+	switch value := value.(type) {
+	case json.Number:
+		n, err := value.Int64()
+		if err != nil || n < 0 || n >= int64(len(typ.enum)) {
+			v.report(path, "%s is not a %s (valid: 0 to %d)", value, typ.name, len(typ.enum)-1)
+		}
+	case string:
+		for _, name := range typ.enum {
+			if value == name {
+				return
+			}
+		}
+		v.report(path, "unknown %s %q, which is read as %s", typ.name, value, typ.enum[0])
+	default:
+		v.report(path, "expected a %s, got %s", typ.name, jsonKind(value))
+	}
+}
+
+// validateID validates an ID in hex, which is empty when unset
+func (v *schemaValidator) validateID(path string, value any, typ *schemaType) {
+	s, ok := value.(string)
+	if !ok {
+		v.report(path, "expected a %s in hex, got %s", typ.name, jsonKind(value))
+		return
+	}
+	if s == "" {
+		return
+	}
+	if len(s) != typ.size*2 {
+		v.report(path, "invalid %s %q: expected %d hex digits, got %d", typ.name, s, typ.size*2, len(s))
+		return
+	}
+	if _, err := hex.DecodeString(s); err != nil {
+		v.report(path, "invalid %s %q: not hex", typ.name, s)
+	}
+}
+
+// checkSingleAnyValue reports values with several of their fields set, of which only the last is kept
+func checkSingleAnyValue(v *schemaValidator, path string, object map[string]any) {
+	var set []string
+	for _, field := range anyValueFields {
+		if fieldKey(object, field) != "" {
+			set = append(set, field)
+		}
+	}
+	if len(set) > 1 {
+		v.report(path, "value has several types set: %s", strings.Join(set, ", "))
+	}
+}
+
+// checkSingleValue reports data points and exemplars with both an int and a double value
+func checkSingleValue(v *schemaValidator, path string, object map[string]any) {
+	if fieldKey(object, "asInt") != "" && fieldKey(object, "asDouble") != "" {
+		v.report(path, "both asInt and asDouble are set")
+	}
+}
+
+// checkHistogramBuckets reports bucket counts that do not match the explicit bounds
+func checkHistogramBuckets(v *schemaValidator, path string, object map[string]any) {
+	counts, _ := object[fieldKey(object, "bucketCounts")].([]any)
+	bounds, _ := object[fieldKey(object, "explicitBounds")].([]any)
+	if len(counts) > 0 && len(counts) != len(bounds)+1 {
+		v.report(path, "%d bucket counts for %d explicit bounds, expected %d", len(counts), len(bounds), len(bounds)+1)
+	}
+}
+
+// checkMetricData reports metrics without data or with the data of several types
+func checkMetricData(v *schemaValidator, path string, object map[string]any) {
+	var set []string
+	for _, field := range metricDataFields {
+		if key := fieldKey(object, field); key != "" && object[key] != nil {
+			set = append(set, field)
+		}
+	}
+	switch {
+	case len(set) == 0:
+		v.report(path, "metric has no data: expected one of %s", strings.Join(metricDataFields, ", "))
+	case len(set) > 1:
+		v.report(path, "metric has data of several types: %s", strings.Join(set, ", "))
+	}
+}
+
+// misplacedFieldHint names the data points a field belongs to, when it is a field of another
+// type of data point than the one it was found in
+func misplacedFieldHint(typ *schemaType, field string) string {
+	isDataPoint := false
+	for _, dataPoint := range dataPointTypes {
+		isDataPoint = isDataPoint || dataPoint == typ
+	}
+	if !isDataPoint {
+		return ""
+	}
+	var owners []string
+	for _, dataPoint := range dataPointTypes {
+		if _, ok := dataPoint.fields[field]; ok {
+			owners = append(owners, dataPoint.name+"s")
+		}
+	}
+	if len(owners) == 0 {
+		return ""
+	}
+	return fmt.Sprintf(" (it is a field of %s: is the metric of the right type?)", strings.Join(owners, " and "))
+}
+
+// fieldKey returns the key of a field in an object, which may be in camelCase or snake_case,
+// or "" when the object does not have the field
+func fieldKey(object map[string]any, field string) string {
+	if _, ok := object[field]; ok {
+		return field
+	}
+	if snake := snakeCase(field); snake != field {
+		if _, ok := object[snake]; ok {
+			return snake
+		}
+	}
+	return ""
+}
+
+// camelCase converts a snake_case field name, which the OTLP JSON unmarshalers accept too,
+// to the camelCase of the schema
+func camelCase(name string) string {
+	if !strings.Contains(name, "_") {
+		return name
+	}
+	parts := strings.Split(name, "_")
+	for i := 1; i < len(parts); i++ {
+		if parts[i] != "" {
+			parts[i] = strings.ToUpper(parts[i][:1]) + parts[i][1:]
+		}
+	}
+	return strings.Join(parts, "")
+}
+
+// jsonKind describes the kind of a decoded JSON value
+func jsonKind(value any) string {
+	switch value := value.(type) {
+	case string:
+		return fmt.Sprintf("string %q", value)
+	case json.Number:
+		return "number " + value.String()
+	case bool:
+		return fmt.Sprintf("boolean %t", value)
+	case map[string]any:
+		return "an object"
+	case []any:
+		return "an array"
+	default:
+		return fmt.Sprintf("%T", value)
+	}
+}
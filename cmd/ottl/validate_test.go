@@ -0,0 +1,133 @@
+package main
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestValidateOTLPJSON(t *testing.T) {
+	tests := []struct {
+		name     string
+		signal   string
+		input    string
+		expected []schemaIssue
+	}{
+		{
+			name:   "valid span in snake case",
+			signal: "traces",
+			input: `{"resource_spans":[{"scope_spans":[{"spans":[{"trace_id":"5b8efff798038103d269b633813fc60c","span_id":"eee19b7ec3c1b174",
+				"parent_span_id":"","kind":"SPAN_KIND_SERVER","start_time_unix_nano":"1","end_time_unix_nano":2,"status":null}]}]}]}`,
+		},
+		{
+			name:   "unknown field",
+			signal: "traces",
+			input:  `{"resourceSpans":[{"scopeSpans":[{"spans":[{"traceID":"5b8efff798038103d269b633813fc60c"}]}]}]}`,
+			expected: []schemaIssue{
+				{Path: "$.resourceSpans[0].scopeSpans[0].spans[0].traceID", Message: "unknown field of span"},
+			},
+		},
+		{
+			name:   "invalid IDs",
+			signal: "traces",
+			input:  `{"resourceSpans":[{"scopeSpans":[{"spans":[{"traceId":"5b8e","spanId":"zzzzzzzzzzzzzzzz","parentSpanId":12}]}]}]}`,
+			expected: []schemaIssue{
+				{Path: "$.resourceSpans[0].scopeSpans[0].spans[0].parentSpanId", Message: "expected a span ID in hex, got number 12"},
+				{Path: "$.resourceSpans[0].scopeSpans[0].spans[0].spanId", Message: `invalid span ID "zzzzzzzzzzzzzzzz": not hex`},
+				{Path: "$.resourceSpans[0].scopeSpans[0].spans[0].traceId", Message: `invalid trace ID "5b8e": expected 32 hex digits, got 4`},
+			},
+		},
+		{
+			name:   "wrong value types",
+			signal: "traces",
+			input:  `{"resourceSpans":[{"scopeSpans":[{"spans":[{"name":7,"startTimeUnixNano":"-1","droppedAttributesCount":"x","events":{}}]}]}]}`,
+			expected: []schemaIssue{
+				{Path: "$.resourceSpans[0].scopeSpans[0].spans[0].droppedAttributesCount", Message: "x is not an unsigned 32-bit integer"},
+				{Path: "$.resourceSpans[0].scopeSpans[0].spans[0].events", Message: "expected an array, got an object"},
+				{Path: "$.resourceSpans[0].scopeSpans[0].spans[0].name", Message: "expected a string, got number 7"},
+				{Path: "$.resourceSpans[0].scopeSpans[0].spans[0].startTimeUnixNano", Message: "-1 is not an unsigned 64-bit integer"},
+			},
+		},
+		{
+			name:   "enums",
+			signal: "traces",
+			input:  `{"resourceSpans":[{"scopeSpans":[{"spans":[{"kind":"SPAN_KIND_SERVR","status":{"code":3}}]}]}]}`,
+			expected: []schemaIssue{
+				{Path: "$.resourceSpans[0].scopeSpans[0].spans[0].kind", Message: `unknown span kind "SPAN_KIND_SERVR", which is read as SPAN_KIND_UNSPECIFIED`},
+				{Path: "$.resourceSpans[0].scopeSpans[0].spans[0].status.code", Message: "3 is not a status code (valid: 0 to 2)"},
+			},
+		},
+		{
+			name:   "values",
+			signal: "logs",
+			input: `{"resourceLogs":[{"scopeLogs":[{"logRecords":[{"severityNumber":"SEVERITY_NUMBER_WARN2","body":{"stringValue":"a","intValue":"1"},
+				"attributes":[{"key":"k","value":{"bytesValue":"%%"}},{"key":"l","value":{"arrayValue":{"values":[{"str":"x"}]}}}]}]}]}]}`,
+			expected: []schemaIssue{
+				{Path: "$.resourceLogs[0].scopeLogs[0].logRecords[0].attributes[0].value.bytesValue", Message: `invalid base64 bytes "%%"`},
+				{Path: "$.resourceLogs[0].scopeLogs[0].logRecords[0].attributes[1].value.arrayValue.values[0].str", Message: "unknown field of value"},
+				{Path: "$.resourceLogs[0].scopeLogs[0].logRecords[0].body", Message: "value has several types set: stringValue, intValue"},
+			},
+		},
+		{
+			name:   "metric data",
+			signal: "metrics",
+			input: `{"resourceMetrics":[{"scopeMetrics":[{"metrics":[{"name":"a"},{"name":"b","gauge":{},"sum":{}},
+				{"name":"c","gauge":{"dataPoints":[{"asInt":"1","asDouble":1.5,"bucketCounts":["1"]}]}}]}]}]}`,
+			expected: []schemaIssue{
+				{Path: "$.resourceMetrics[0].scopeMetrics[0].metrics[0]", Message: "metric has no data: expected one of gauge, sum, histogram, exponentialHistogram, summary"},
+				{Path: "$.resourceMetrics[0].scopeMetrics[0].metrics[1]", Message: "metric has data of several types: gauge, sum"},
+				{Path: "$.resourceMetrics[0].scopeMetrics[0].metrics[2].gauge.dataPoints[0].bucketCounts", Message: "unknown field of number data point (it is a field of histogram data points: is the metric of the right type?)"},
+				{Path: "$.resourceMetrics[0].scopeMetrics[0].metrics[2].gauge.dataPoints[0]", Message: "both asInt and asDouble are set"},
+			},
+		},
+		{
+			name:   "histogram buckets",
+			signal: "metrics",
+			input: `{"resourceMetrics":[{"scopeMetrics":[{"metrics":[{"name":"h","histogram":{"aggregationTemporality":2,
+				"dataPoints":[{"bucketCounts":["1","2"],"explicitBounds":[1,2],"sum":"NaN"}]}}]}]}]}`,
+			expected: []schemaIssue{
+				{Path: "$.resourceMetrics[0].scopeMetrics[0].metrics[0].histogram.dataPoints[0]", Message: "2 bucket counts for 2 explicit bounds, expected 3"},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			issues, err := validateOTLPJSON([]byte(tt.input), tt.signal)
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, issues)
+		})
+	}
+}
+
+func TestValidateOTLPJSONTestData(t *testing.T) {
+	for _, signal := range []string{"traces", "logs", "metrics"} {
+		issues, err := validateOTLPJSON(readTestData(t, signal+".json"), signal)
+		require.NoError(t, err)
+		assert.Empty(t, issues, signal)
+	}
+
+	_, err := validateOTLPJSON(readTestData(t, "profiles.json"), "profiles")
+	assert.EqualError(t, err, "strict validation is not available for profiles")
+}
+
+func TestValidateInput(t *testing.T) {
+	ndjson := string(ndjsonLine(t, "logs.json")) + `{"resourceSpans":[{"scopeSpans":[{"spans":[{"nme":"x"}]}]}]}` + "\n"
+	err := validateInput([]byte(ndjson), "")
+	var validationErr *schemaValidationError
+	require.True(t, errors.As(err, &validationErr))
+	assert.Equal(t, []schemaIssue{
+		{Line: 2, Path: "$.resourceSpans[0].scopeSpans[0].spans[0].nme", Message: "unknown field of span"},
+	}, validationErr.issues)
+	assert.Equal(t, "input does not match the OTLP schema (1 issue):\n  line 2: $.resourceSpans[0].scopeSpans[0].spans[0].nme: unknown field of span", err.Error())
+
+	// The signal flag value is used when the request has no field of a signal
+	err = validateInput([]byte(`{"resourceSpan":[]}`), "traces")
+	require.True(t, errors.As(err, &validationErr))
+	assert.Equal(t, "$.resourceSpan", validationErr.issues[0].Path)
+
+	assert.NoError(t, validateInput(readTestData(t, "traces.json"), ""))
+	assert.EqualError(t, validateInput(readTestData(t, "debug-exporter.log"), ""), "strict validation is not available for debug exporter output")
+}
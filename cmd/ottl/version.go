@@ -0,0 +1,143 @@
+// Copyright 2025 Dose de Telemetria GmbH
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"fmt"
+	"io"
+	"runtime"
+	"runtime/debug"
+	"sort"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"go.opentelemetry.io/collector/featuregate"
+)
+
+// version is the CLI version, set at build time via -ldflags "-X main.version=..."
+var version = "dev"
+
+const (
+	ottlModulePath  = "github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl"
+	pdataModulePath = "go.opentelemetry.io/collector/pdata"
+)
+
+var versionCmd = &cobra.Command{
+	Use:   "version",
+	Short: "Print the CLI, OTTL and pdata versions",
+	Long: `Prints the CLI version together with the OTTL and pdata module versions
+embedded in this binary, and the list of enabled feature gates. Use it to
+confirm the CLI matches the collector distribution you are testing against.`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		writeVersionInfo(cmd.OutOrStdout(), collectVersionInfo())
+		return nil
+	},
+}
+
+// versionInfo holds the versions reported by the version command
+type versionInfo struct {
+	CLI          string
+	Go           string
+	OTTL         string
+	Pdata        string
+	FeatureGates []string
+}
+
+func init() {
+	rootCmd.Version = version
+	cobra.AddTemplateFunc("versionInfo", func() string {
+		var sb strings.Builder
+		writeVersionInfo(&sb, collectVersionInfo())
+		return sb.String()
+	})
+	rootCmd.SetVersionTemplate(`{{versionInfo}}`)
+	rootCmd.AddCommand(versionCmd)
+}
+
+// collectVersionInfo gathers version details from the build info and the feature gate registry
+func collectVersionInfo() versionInfo {
+	vi := versionInfo{
+		CLI:          version,
+		Go:           runtime.Version(),
+		OTTL:         "unknown",
+		Pdata:        "unknown",
+		FeatureGates: enabledFeatureGates(featuregate.GlobalRegistry()),
+	}
+
+	info, ok := debug.ReadBuildInfo()
+	if !ok {
+		return vi
+	}
+
+	// Binaries built with "go install module@version" carry the version in the build info
+	if vi.CLI == "dev" && info.Main.Version != "" && info.Main.Version != "(devel)" {
+		vi.CLI = info.Main.Version
+	}
+	if v := moduleVersion(info, ottlModulePath); v != "" {
+		vi.OTTL = v
+	}
+	if v := moduleVersion(info, pdataModulePath); v != "" {
+		vi.Pdata = v
+	}
+
+	return vi
+}
+
+// moduleVersion returns the resolved version of a dependency, honoring replace directives
+func moduleVersion(info *debug.BuildInfo, path string) string {
+	for _, dep := range info.Deps {
+		if dep.Path != path {
+			continue
+		}
+		if dep.Replace != nil {
+			if dep.Replace.Version != "" {
+				return fmt.Sprintf("%s (replaced by %s)", dep.Replace.Version, dep.Replace.Path)
+			}
+			return fmt.Sprintf("replaced by %s", dep.Replace.Path)
+		}
+		return dep.Version
+	}
+	return ""
+}
+
+// enabledFeatureGates returns the sorted IDs of all enabled gates in the registry
+func enabledFeatureGates(reg *featuregate.Registry) []string {
+	var gates []string
+	reg.VisitAll(func(g *featuregate.Gate) {
+		if g.IsEnabled() {
+			gates = append(gates, g.ID())
+		}
+	})
+	sort.Strings(gates)
+	return gates
+}
+
+// writeVersionInfo prints version details in a human-readable form
+func writeVersionInfo(w io.Writer, vi versionInfo) {
+	_, _ = fmt.Fprintf(w, "ottl version %s\n", vi.CLI)
+	_, _ = fmt.Fprintf(w, "  go:    %s\n", vi.Go)
+	_, _ = fmt.Fprintf(w, "  ottl:  %s\n", vi.OTTL)
+	_, _ = fmt.Fprintf(w, "  pdata: %s\n", vi.Pdata)
+
+	if len(vi.FeatureGates) == 0 {
+		_, _ = fmt.Fprintln(w, "  feature gates: none enabled")
+		return
+	}
+	_, _ = fmt.Fprintln(w, "  feature gates:")
+	for _, id := range vi.FeatureGates {
+		_, _ = fmt.Fprintf(w, "    %s\n", id)
+	}
+}
@@ -0,0 +1,85 @@
+package main
+
+import (
+	"bytes"
+	"runtime/debug"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/featuregate"
+)
+
+func TestModuleVersion(t *testing.T) {
+	info := &debug.BuildInfo{
+		Deps: []*debug.Module{
+			{Path: ottlModulePath, Version: "v0.132.0"},
+			{Path: pdataModulePath, Version: "v1.38.0", Replace: &debug.Module{Path: "../pdata"}},
+		},
+	}
+
+	assert.Equal(t, "v0.132.0", moduleVersion(info, ottlModulePath))
+	assert.Equal(t, "replaced by ../pdata", moduleVersion(info, pdataModulePath))
+	assert.Equal(t, "", moduleVersion(info, "example.com/missing"))
+}
+
+func TestEnabledFeatureGates(t *testing.T) {
+	reg := featuregate.NewRegistry()
+	reg.MustRegister("test.beta", featuregate.StageBeta)
+	reg.MustRegister("test.alpha", featuregate.StageAlpha)
+	reg.MustRegister("test.another", featuregate.StageBeta)
+
+	assert.Equal(t, []string{"test.another", "test.beta"}, enabledFeatureGates(reg))
+}
+
+func TestCollectVersionInfo(t *testing.T) {
+	vi := collectVersionInfo()
+
+	// The test binary embeds the same dependency versions as the CLI
+	assert.NotEqual(t, "unknown", vi.OTTL)
+	assert.NotEqual(t, "unknown", vi.Pdata)
+	assert.NotEmpty(t, vi.Go)
+}
+
+func TestWriteVersionInfo(t *testing.T) {
+	tests := []struct {
+		name     string
+		info     versionInfo
+		expected string
+	}{
+		{
+			name: "no feature gates",
+			info: versionInfo{CLI: "v1.0.0", Go: "go1.24.0", OTTL: "v0.132.0", Pdata: "v1.38.0"},
+			expected: "ottl version v1.0.0\n  go:    go1.24.0\n  ottl:  v0.132.0\n  pdata: v1.38.0\n" +
+				"  feature gates: none enabled\n",
+		},
+		{
+			name: "with feature gates",
+			info: versionInfo{CLI: "dev", Go: "go1.24.0", OTTL: "v0.132.0", Pdata: "v1.38.0", FeatureGates: []string{"a.b"}},
+			expected: "ottl version dev\n  go:    go1.24.0\n  ottl:  v0.132.0\n  pdata: v1.38.0\n" +
+				"  feature gates:\n    a.b\n",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			var buf bytes.Buffer
+			writeVersionInfo(&buf, test.info)
+			assert.Equal(t, test.expected, buf.String())
+		})
+	}
+}
+
+func TestVersionFlag(t *testing.T) {
+	var buf bytes.Buffer
+	rootCmd.SetOut(&buf)
+	rootCmd.SetArgs([]string{"--version"})
+	defer func() {
+		rootCmd.SetOut(nil)
+		rootCmd.SetArgs(nil)
+	}()
+
+	require.NoError(t, rootCmd.Execute())
+	assert.Contains(t, buf.String(), "ottl version")
+	assert.Contains(t, buf.String(), "ottl:")
+}
@@ -0,0 +1,140 @@
+// Copyright 2025 Dose de Telemetria GmbH
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/signal"
+	"slices"
+	"sort"
+	"strings"
+	"syscall"
+	"time"
+)
+
+// watchInterval is how often watched files are checked for changes
+const watchInterval = 200 * time.Millisecond
+
+// fileStamp identifies one version of a file on disk
+type fileStamp struct {
+	exists  bool
+	size    int64
+	modTime time.Time
+}
+
+// statFile returns the current stamp of a file, which is zero when the file is missing
+func statFile(path string) fileStamp {
+	info, err := os.Stat(path)
+	if err != nil {
+		return fileStamp{}
+	}
+	return fileStamp{exists: true, size: info.Size(), modTime: info.ModTime()}
+}
+
+// watchFiles calls run once, then again with the changed paths whenever any of the files
+// changes on disk, until ctx is done. Files are polled so that editors replacing a file
+// on save are handled like any other write. The paths are listed again on every poll, and
+// files that join or leave the list count as changed.
+func watchFiles(ctx context.Context, paths func() []string, interval time.Duration, run func(changed []string)) {
+	stamps := make(map[string]fileStamp)
+	for _, path := range paths() {
+		stamps[path] = statFile(path)
+	}
+	run(nil)
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+
+		var changed []string
+		current := make(map[string]bool)
+		for _, path := range paths() {
+			current[path] = true
+			stamp := statFile(path)
+			if previous, ok := stamps[path]; !ok || stamp != previous {
+				stamps[path] = stamp
+				changed = append(changed, path)
+			}
+		}
+		var removed []string
+		for path := range stamps {
+			if !current[path] {
+				delete(stamps, path)
+				removed = append(removed, path)
+			}
+		}
+		sort.Strings(removed)
+		changed = append(changed, removed...)
+		if len(changed) > 0 {
+			run(changed)
+		}
+	}
+}
+
+// watchTransform reruns the transformation every time the statement file or the input file changes.
+// Failures are reported on stderr without stopping the watch.
+func watchTransform() error {
+	paths := []string{inputFile}
+	var stdinStatement string
+	if statementFile != "" {
+		paths = append(paths, statementFile)
+	} else {
+		statement, err := readStatement("")
+		if err != nil {
+			return &stageError{stage: stageRead, statement: -1, err: err}
+		}
+		stdinStatement = statement
+	}
+
+	// With --rotated, the files the file exporter rotates out of the input file are watched too,
+	// since rotation may leave the input file unchanged or missing
+	watched := func() []string {
+		if !rotatedFlag {
+			return paths
+		}
+		rotated, _ := rotatedFiles(inputFile)
+		return append(slices.Clone(paths), rotated...)
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	_, _ = fmt.Fprintf(os.Stderr, "watching %s (press Ctrl+C to stop)\n", strings.Join(watched(), ", "))
+	watchFiles(ctx, watched, watchInterval, func(changed []string) {
+		if len(changed) > 0 {
+			_, _ = fmt.Fprintf(os.Stderr, "\n--- %s changed at %s\n", strings.Join(changed, ", "), time.Now().Format(time.TimeOnly))
+		}
+
+		statement := stdinStatement
+		if statementFile != "" {
+			var err error
+			if statement, err = readStatement(statementFile); err != nil {
+				writeTransformError(os.Stderr, &stageError{stage: stageRead, statement: -1, err: err})
+				return
+			}
+		}
+		if err := transformFile(statement, statementSourceName(), inputFile); err != nil {
+			writeTransformError(os.Stderr, err)
+		}
+	})
+	return nil
+}
@@ -0,0 +1,114 @@
+package main
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWatchFiles(t *testing.T) {
+	dir := t.TempDir()
+	statement := filepath.Join(dir, "transform.ottl")
+	input := filepath.Join(dir, "traces.json")
+	require.NoError(t, os.WriteFile(statement, []byte(`set(span.name, "a")`), 0o600))
+	require.NoError(t, os.WriteFile(input, []byte("{}"), 0o600))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	runs := make(chan []string, 10)
+	done := make(chan struct{})
+	go func() {
+		watchFiles(ctx, func() []string { return []string{input, statement} }, 10*time.Millisecond, func(changed []string) { runs <- changed })
+		close(done)
+	}()
+
+	assert.Nil(t, <-runs, "the first run happens before any change")
+
+	require.NoError(t, os.WriteFile(statement, []byte(`set(span.name, "longer")`), 0o600))
+	select {
+	case changed := <-runs:
+		assert.Equal(t, []string{statement}, changed)
+	case <-time.After(5 * time.Second):
+		t.Fatal("change to the statement file was not detected")
+	}
+
+	require.NoError(t, os.Remove(input))
+	select {
+	case changed := <-runs:
+		assert.Equal(t, []string{input}, changed)
+	case <-time.After(5 * time.Second):
+		t.Fatal("removal of the input file was not detected")
+	}
+
+	cancel()
+	<-done
+	assert.Empty(t, runs)
+}
+
+func TestWatchFilesRotated(t *testing.T) {
+	dir := t.TempDir()
+	input := filepath.Join(dir, "traces.json")
+	rotated := filepath.Join(dir, "traces-2025-01-01T10-00-00.000.json")
+	require.NoError(t, os.WriteFile(input, []byte("{}\n"), 0o600))
+
+	paths := func() []string {
+		files, err := rotatedFiles(input)
+		require.NoError(t, err)
+		return append(files, input)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	runs := make(chan []string, 10)
+	done := make(chan struct{})
+	go func() {
+		watchFiles(ctx, paths, 10*time.Millisecond, func(changed []string) { runs <- changed })
+		close(done)
+	}()
+
+	assert.Nil(t, <-runs)
+
+	// Rotation moves the input file aside, leaving the input file missing
+	require.NoError(t, os.Rename(input, rotated))
+	select {
+	case changed := <-runs:
+		assert.Equal(t, []string{rotated, input}, changed)
+	case <-time.After(5 * time.Second):
+		t.Fatal("rotation was not detected")
+	}
+
+	// Removing the rotated file drops it from the watched files
+	require.NoError(t, os.Remove(rotated))
+	select {
+	case changed := <-runs:
+		assert.Equal(t, []string{rotated}, changed)
+	case <-time.After(5 * time.Second):
+		t.Fatal("removal of the rotated file was not detected")
+	}
+
+	cancel()
+	<-done
+}
+
+func TestReadStatement(t *testing.T) {
+	dir := t.TempDir()
+	filename := filepath.Join(dir, "transform.ottl")
+	require.NoError(t, os.WriteFile(filename, []byte("  set(span.name, \"x\")\n"), 0o600))
+
+	statement, err := readStatement(filename)
+	require.NoError(t, err)
+	assert.Equal(t, `  set(span.name, "x")`, statement)
+
+	empty := filepath.Join(dir, "empty.ottl")
+	require.NoError(t, os.WriteFile(empty, []byte("\n"), 0o600))
+	_, err = readStatement(empty)
+	assert.Error(t, err)
+
+	_, err = readStatement(filepath.Join(dir, "missing.ottl"))
+	assert.Error(t, err)
+}
@@ -0,0 +1,109 @@
+// Copyright 2025 Dose de Telemetria GmbH
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package functions holds the OTTL function factories available to the ottl CLI.
+//
+// Every context has its own factory map, initialized with the standard OTTL
+// functions and the functions the transform processor adds on top of them.
+// The func_*.go files are ported from the transform processor, whose function
+// implementations live in an internal package and cannot be imported.
+// Custom functions are added by importing a package that calls Register from
+// its init function, and building the CLI with that import (see "Custom
+// Functions" in the README).
+package functions
+
+import (
+	"fmt"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl/contexts/ottldatapoint"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl/contexts/ottllog"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl/contexts/ottlmetric"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl/contexts/ottlprofile"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl/contexts/ottlresource"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl/contexts/ottlscope"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl/contexts/ottlspan"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl/contexts/ottlspanevent"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl/ottlfuncs"
+)
+
+// Factory maps used by the CLI parsers, keyed by function name. Besides the
+// standard OTTL functions, they include the functions the transform processor
+// adds for each context, so statements valid in the collector parse here too.
+var (
+	Resource  = ottlfuncs.StandardFuncs[ottlresource.TransformContext]()
+	Scope     = ottlfuncs.StandardFuncs[ottlscope.TransformContext]()
+	Span      = withFactories(ottlfuncs.StandardFuncs[ottlspan.TransformContext](), ottlfuncs.NewIsRootSpanFactory())
+	SpanEvent = ottlfuncs.StandardFuncs[ottlspanevent.TransformContext]()
+	Log       = ottlfuncs.StandardFuncs[ottllog.TransformContext]()
+	Metric    = withFactories(ottlfuncs.StandardFuncs[ottlmetric.TransformContext](), metricFactories()...)
+	DataPoint = withFactories(ottlfuncs.StandardFuncs[ottldatapoint.TransformContext](), dataPointFactories()...)
+	Profile   = ottlfuncs.StandardFuncs[ottlprofile.TransformContext]()
+)
+
+// metricFactories returns the transform processor's metric-only functions
+func metricFactories() []ottl.Factory[ottlmetric.TransformContext] {
+	return []ottl.Factory[ottlmetric.TransformContext]{
+		newExtractSumMetricFactory(),
+		newExtractCountMetricFactory(),
+		newConvertGaugeToSumFactory(),
+		newConvertSumToGaugeFactory(),
+		newCopyMetricFactory(),
+		newScaleMetricFactory(),
+		newAggregateOnAttributesFactory(),
+		newconvertExponentialHistToExplicitHistFactory(),
+		newAggregateOnAttributeValueFactory(),
+		newConvertSummaryQuantileValToGaugeFactory(),
+	}
+}
+
+// dataPointFactories returns the transform processor's datapoint-only functions
+func dataPointFactories() []ottl.Factory[ottldatapoint.TransformContext] {
+	return []ottl.Factory[ottldatapoint.TransformContext]{
+		newConvertSummarySumValToSumFactory(),
+		newConvertSummaryCountValToSumFactory(),
+	}
+}
+
+// withFactories adds factories to a map, replacing existing entries with the same name
+func withFactories[K any](factories map[string]ottl.Factory[K], fs ...ottl.Factory[K]) map[string]ottl.Factory[K] {
+	for _, f := range fs {
+		factories[f.Name()] = f
+	}
+	return factories
+}
+
+// Register adds factories to a context's factory map. It returns an error if a
+// function with the same name is already registered, or is given twice; assign to
+// the map directly to deliberately replace an existing function.
+func Register[K any](factories map[string]ottl.Factory[K], fs ...ottl.Factory[K]) error {
+	seen := make(map[string]bool, len(fs))
+	for _, f := range fs {
+		if _, exists := factories[f.Name()]; exists || seen[f.Name()] {
+			return fmt.Errorf("function %q is already registered", f.Name())
+		}
+		seen[f.Name()] = true
+	}
+	for _, f := range fs {
+		factories[f.Name()] = f
+	}
+	return nil
+}
+
+// MustRegister is like Register but panics on error. It is meant for init functions.
+func MustRegister[K any](factories map[string]ottl.Factory[K], fs ...ottl.Factory[K]) {
+	if err := Register(factories, fs...); err != nil {
+		panic(err)
+	}
+}
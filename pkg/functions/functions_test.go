@@ -0,0 +1,88 @@
+package functions
+
+import (
+	"context"
+	"testing"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl/contexts/ottlspan"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestFactory[K any](name string) ottl.Factory[K] {
+	return ottl.NewFactory(name, nil, func(ottl.FunctionContext, ottl.Arguments) (ottl.ExprFunc[K], error) {
+		return func(context.Context, K) (any, error) { return nil, nil }, nil
+	})
+}
+
+func TestStandardFunctions(t *testing.T) {
+	for name, factories := range map[string]int{
+		"resource":  len(Resource),
+		"scope":     len(Scope),
+		"span":      len(Span),
+		"spanevent": len(SpanEvent),
+		"log":       len(Log),
+		"metric":    len(Metric),
+		"datapoint": len(DataPoint),
+		"profile":   len(Profile),
+	} {
+		assert.NotZero(t, factories, "expected standard functions for %s context", name)
+	}
+
+	assert.Contains(t, Span, "set")
+	assert.Contains(t, Log, "delete_key")
+	assert.Contains(t, Metric, "Concat")
+	assert.Contains(t, DataPoint, "IsMatch")
+}
+
+func TestTransformProcessorFunctions(t *testing.T) {
+	for _, name := range []string{
+		"convert_sum_to_gauge",
+		"convert_gauge_to_sum",
+		"extract_sum_metric",
+		"extract_count_metric",
+		"copy_metric",
+		"scale_metric",
+		"aggregate_on_attributes",
+		"aggregate_on_attribute_value",
+		"convert_exponential_histogram_to_histogram",
+		"convert_summary_quantile_val_to_gauge",
+	} {
+		assert.Contains(t, Metric, name)
+	}
+
+	assert.Contains(t, DataPoint, "convert_summary_sum_val_to_sum")
+	assert.Contains(t, DataPoint, "convert_summary_count_val_to_sum")
+	assert.Contains(t, Span, "IsRootSpan")
+}
+
+func TestRegister(t *testing.T) {
+	factories := map[string]ottl.Factory[ottlspan.TransformContext]{}
+
+	require.NoError(t, Register(factories, newTestFactory[ottlspan.TransformContext]("MyFunc")))
+	assert.Contains(t, factories, "MyFunc")
+
+	err := Register(factories,
+		newTestFactory[ottlspan.TransformContext]("Other"),
+		newTestFactory[ottlspan.TransformContext]("MyFunc"),
+	)
+	assert.Error(t, err)
+	assert.NotContains(t, factories, "Other", "a failed registration should not add any function")
+
+	err = Register(factories,
+		newTestFactory[ottlspan.TransformContext]("Twice"),
+		newTestFactory[ottlspan.TransformContext]("Twice"),
+	)
+	assert.EqualError(t, err, `function "Twice" is already registered`)
+	assert.NotContains(t, factories, "Twice")
+}
+
+func TestMustRegister(t *testing.T) {
+	factories := map[string]ottl.Factory[ottlspan.TransformContext]{}
+	MustRegister(factories, newTestFactory[ottlspan.TransformContext]("MyFunc"))
+
+	assert.Panics(t, func() {
+		MustRegister(factories, newTestFactory[ottlspan.TransformContext]("MyFunc"))
+	})
+}